@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/graphqlclient"
+	"github-admin-tool/progressbar"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errArchiveTargetFlags  = errors.New("must set either repos or stale-days")
+	errArchiveTargetDouble = errors.New("cannot set both repos and stale-days")
+	errArchiveStaleDays    = errors.New("stale-days must be greater than 0")
+	archiveCmd             = &cobra.Command{ // nolint // needed for cobra
+		Use:   "archive",
+		Short: "Archive or unarchive repos in provided list, or list stale repos as archive candidates",
+		RunE:  archiveRun,
+	}
+)
+
+func archiveRun(cmd *cobra.Command, args []string) error {
+	err := archiveCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+		time.Now(),
+	)
+
+	return err
+}
+
+func archiveCommand(cmd *cobra.Command, repo *repository, now time.Time) error {
+	dryRun, reposFilePath, unarchive, staleDays, candidatesFilePath, err := archiveFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	log.SetFlags(0)
+
+	ctx := context.Background()
+
+	// Finding stale repos only reads, the candidates are archived by passing the list back in with --repos
+	if staleDays > 0 {
+		candidates, err := archiveStaleCandidates(ctx, now.AddDate(0, 0, -staleDays))
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		for _, candidate := range candidates {
+			log.Printf("Stale repository: %s", candidate)
+		}
+
+		if err := archiveWriteCandidates(candidatesFilePath, candidates); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		log.Printf("%d stale repositories written to %s", len(candidates), candidatesFilePath)
+
+		return nil
+	}
+
+	repositoryList, err := repo.reader.read(reposFilePath)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if dryRun {
+		log.Printf("This is a dry run, the run would process %d repositories", len(repositoryList))
+	}
+
+	for _, repositoryName := range repositoryList {
+		result, err := archiveApply(ctx, repositoryName, !unarchive, dryRun)
+		if err != nil {
+			log.Printf("Error (%s): %v", repositoryName, err)
+
+			continue
+		}
+
+		log.Print(result)
+	}
+
+	return nil
+}
+
+// archiveApply sets the archived state of the repository when it is not already in that state.
+func archiveApply(ctx context.Context, repositoryName string, archive, dryRun bool) (string, error) {
+	action := "archive"
+	if !archive {
+		action = "unarchive"
+	}
+
+	path := fmt.Sprintf("/repos/%s/%s", config.Org, repositoryName)
+
+	client := restclient.NewClient(path, config.Token, http.MethodGet)
+
+	var current struct {
+		Archived bool `json:"archived"`
+	}
+	if err := client.Run(ctx, &current); err != nil {
+		return "", fmt.Errorf("get repository: %w", err)
+	}
+
+	if current.Archived == archive {
+		return fmt.Sprintf("No need to %s %s", action, repositoryName), nil
+	}
+
+	if dryRun {
+		return fmt.Sprintf("Would %s %s", action, repositoryName), nil
+	}
+
+	client = restclient.NewClient(path, config.Token, http.MethodPatch)
+	if err := client.SetBody(map[string]bool{"archived": archive}); err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	var response interface{}
+	if err := client.Run(ctx, &response); err != nil {
+		return "", fmt.Errorf("%s repository: %w", action, err)
+	}
+
+	return fmt.Sprintf("Successful %s of %s", action, repositoryName), nil
+}
+
+// archiveStaleCandidates returns the unarchived repos with no push since cutoff and no open pull requests.
+// Repos that have never been pushed to are judged on when they were created.
+func archiveStaleCandidates(ctx context.Context, cutoff time.Time) ([]string, error) {
+	var (
+		cursor     *string
+		totalCount int
+		candidates []string
+		iteration  int
+		bar        progressbar.Bar
+	)
+
+	client := graphqlclient.NewClient()
+	req := reportRequest(archiveStaleQuery())
+
+	for {
+		// Set new cursor on every loop to paginate through 100 at a time
+		req.Var("after", cursor)
+
+		var response ArchiveStaleResponse
+		if err := client.Run(ctx, req, &response); err != nil {
+			return candidates, fmt.Errorf("graphql call: %w", err)
+		}
+
+		cursor = &response.Organization.Repositories.PageInfo.EndCursor
+		totalCount = response.Organization.Repositories.TotalCount
+
+		for _, node := range response.Organization.Repositories.Nodes {
+			if node.IsArchived || node.PullRequests.TotalCount > 0 {
+				continue
+			}
+
+			field, value := "pushedAt", node.PushedAt
+			if value == "" {
+				field, value = "createdAt", node.CreatedAt
+			}
+
+			lastActivity, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return candidates, fmt.Errorf("%s for %s: %w", field, node.Name, err)
+			}
+
+			if !lastActivity.Before(cutoff) {
+				continue
+			}
+
+			candidates = append(candidates, node.Name)
+		}
+
+		if iteration == 0 {
+			bar.NewOption(0, totalCount)
+		}
+
+		bar.Play(iteration)
+
+		iteration += IterationCount
+
+		if !response.Organization.Repositories.PageInfo.HasNextPage {
+			break
+		}
+	}
+
+	bar.Play(totalCount)
+	bar.Finish("Get repository data")
+
+	return candidates, nil
+}
+
+func archiveStaleQuery() string {
+	var query strings.Builder
+
+	query.WriteString("query ($org: String! $after: String) {")
+	query.WriteString("		organization(login:$org) {")
+	query.WriteString("			repositories(first: 100, after: $after, orderBy: {field: NAME, direction: ASC}) {")
+	query.WriteString("				totalCount")
+	query.WriteString("				pageInfo {")
+	query.WriteString("					endCursor")
+	query.WriteString("					hasNextPage")
+	query.WriteString("				}")
+	query.WriteString("				nodes {")
+	query.WriteString("					name")
+	query.WriteString("					createdAt")
+	query.WriteString("					isArchived")
+	query.WriteString("					pushedAt")
+	query.WriteString("					pullRequests(states: OPEN) {")
+	query.WriteString("						totalCount")
+	query.WriteString("					}")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("		}")
+	query.WriteString("}")
+
+	return query.String()
+}
+
+// archiveWriteCandidates writes the candidates one per line so the file can be reviewed and used with --repos.
+func archiveWriteCandidates(filePath string, candidates []string) error {
+	var content strings.Builder
+
+	for _, candidate := range candidates {
+		content.WriteString(candidate + "\n")
+	}
+
+	if err := os.WriteFile(filePath, []byte(content.String()), 0o600); err != nil {
+		return fmt.Errorf("could not write candidates file: %w", err)
+	}
+
+	return nil
+}
+
+func archiveFlagCheck(cmd *cobra.Command) (
+	dryRun bool,
+	reposFilePath string,
+	unarchive bool,
+	staleDays int,
+	candidatesFilePath string,
+	err error,
+) {
+	dryRun, err = cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return dryRun, reposFilePath, unarchive, staleDays, candidatesFilePath, fmt.Errorf("%w", err)
+	}
+
+	reposFilePath, err = cmd.Flags().GetString("repos")
+	if err != nil {
+		return dryRun, reposFilePath, unarchive, staleDays, candidatesFilePath, fmt.Errorf("%w", err)
+	}
+
+	unarchive, err = cmd.Flags().GetBool("unarchive")
+	if err != nil {
+		return dryRun, reposFilePath, unarchive, staleDays, candidatesFilePath, fmt.Errorf("%w", err)
+	}
+
+	staleDays, err = cmd.Flags().GetInt("stale-days")
+	if err != nil {
+		return dryRun, reposFilePath, unarchive, staleDays, candidatesFilePath, fmt.Errorf("%w", err)
+	}
+
+	candidatesFilePath, err = cmd.Flags().GetString("file-path")
+	if err != nil {
+		return dryRun, reposFilePath, unarchive, staleDays, candidatesFilePath, fmt.Errorf("%w", err)
+	}
+
+	if staleDays < 0 || (cmd.Flags().Changed("stale-days") && staleDays == 0) {
+		return dryRun, reposFilePath, unarchive, staleDays, candidatesFilePath, errArchiveStaleDays
+	}
+
+	if reposFilePath == "" && staleDays == 0 {
+		return dryRun, reposFilePath, unarchive, staleDays, candidatesFilePath, errArchiveTargetFlags
+	}
+
+	if reposFilePath != "" && staleDays > 0 {
+		return dryRun, reposFilePath, unarchive, staleDays, candidatesFilePath, errArchiveTargetDouble
+	}
+
+	return dryRun, reposFilePath, unarchive, staleDays, candidatesFilePath, nil
+}
+
+// nolint // needed for cobra
+func init() {
+	archiveCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	archiveCmd.Flags().BoolP("unarchive", "u", false, "unarchive the repositories instead of archiving them")
+	archiveCmd.Flags().IntP("stale-days", "s", 0, "list repositories with no push in this many days and no open pull requests instead of archiving")
+	archiveCmd.Flags().StringP("file-path", "f", "stale_repos.txt", "file path for the stale repository list, used with stale-days")
+	archiveCmd.Flags().SortFlags = false
+	rootCmd.AddCommand(archiveCmd)
+}
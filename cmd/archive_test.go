@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_archiveApply(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	repoURL := "https://api.github.com/repos/some-org/some-repo"
+
+	tests := []struct {
+		name          string
+		archive       bool
+		dryRun        bool
+		getFile       string
+		getStatus     int
+		wantResult    string
+		wantErr       bool
+		wantPatchCall int
+	}{
+		{
+			name:      "archiveApply fails on get",
+			archive:   true,
+			getFile:   "testdata/mockRest404Response.json",
+			getStatus: 404,
+			wantErr:   true,
+		},
+		{
+			name:       "archiveApply already unarchived",
+			archive:    false,
+			getFile:    "testdata/mockGetRepositoryResponse.json",
+			getStatus:  200,
+			wantResult: "No need to unarchive some-repo",
+		},
+		{
+			name:       "archiveApply dry run",
+			archive:    true,
+			dryRun:     true,
+			getFile:    "testdata/mockGetRepositoryResponse.json",
+			getStatus:  200,
+			wantResult: "Would archive some-repo",
+		},
+		{
+			name:          "archiveApply archive",
+			archive:       true,
+			getFile:       "testdata/mockGetRepositoryResponse.json",
+			getStatus:     200,
+			wantResult:    "Successful archive of some-repo",
+			wantPatchCall: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			mockHTTPResponder("GET", repoURL, tt.getFile, tt.getStatus)
+			mockHTTPResponder("PATCH", repoURL, "testdata/mockGetRepositoryResponse.json", 200)
+
+			got, err := archiveApply(context.Background(), "some-repo", tt.archive, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("archiveApply() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got != tt.wantResult {
+				t.Errorf("archiveApply() = %v, want %v", got, tt.wantResult)
+			}
+
+			if calls := httpmock.GetCallCountInfo()["PATCH "+repoURL]; calls != tt.wantPatchCall {
+				t.Errorf("archiveApply() PATCH calls = %d, want %d", calls, tt.wantPatchCall)
+			}
+		})
+	}
+}
+
+func Test_archiveStaleCandidates(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	tests := []struct {
+		name               string
+		mockHTTPReturnFile string
+		mockHTTPStatusCode int
+		want               []string
+		wantErr            bool
+	}{
+		{
+			name:               "archiveStaleCandidates fails on graphql call",
+			mockHTTPReturnFile: "testdata/mockEmptyResponse.json",
+			mockHTTPStatusCode: 500,
+			wantErr:            true,
+		},
+		{
+			name:               "archiveStaleCandidates success",
+			mockHTTPReturnFile: "testdata/mockArchiveStaleResponse.json",
+			mockHTTPStatusCode: 200,
+			want:               []string{"stale-repo", "never-pushed"},
+		},
+		{
+			name:               "archiveStaleCandidates skips a new repo that has never been pushed to",
+			mockHTTPReturnFile: "testdata/mockArchiveStaleNewRepoResponse.json",
+			mockHTTPStatusCode: 200,
+		},
+	}
+
+	cutoff := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTPResponder("POST", "https://api.github.com/graphql", tt.mockHTTPReturnFile, tt.mockHTTPStatusCode)
+
+			got, err := archiveStaleCandidates(context.Background(), cutoff)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("archiveStaleCandidates() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("archiveStaleCandidates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_archiveCommand(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	mockHTTPResponder("POST", "https://api.github.com/graphql", "testdata/mockArchiveStaleResponse.json", 200)
+
+	candidatesFile := filepath.Join(t.TempDir(), "stale_repos.txt")
+
+	archiveTestFlags := func(cmd *cobra.Command) {
+		cmd.Flags().Bool("dry-run", true, "dry run flag")
+		cmd.Flags().String("repos", "", "repos file")
+		cmd.Flags().Bool("unarchive", false, "unarchive flag")
+		cmd.Flags().Int("stale-days", 0, "stale days flag")
+		cmd.Flags().String("file-path", candidatesFile, "file path flag")
+	}
+
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		repo    *repository
+		wantErr bool
+	}{
+		{
+			name:    "archiveCommand fails on missing flags",
+			cmd:     &cobra.Command{Use: "archive"},
+			wantErr: true,
+		},
+		{
+			name:    "archiveCommand fails with no target",
+			cmd:     mockFlagsCmd(archiveTestFlags),
+			wantErr: true,
+		},
+		{
+			name:    "archiveCommand fails with both targets",
+			cmd:     mockFlagsCmd(archiveTestFlags, "repos", "testdata/two_repo_list.txt", "stale-days", "365"),
+			wantErr: true,
+		},
+		{
+			name:    "archiveCommand fails with zero stale days",
+			cmd:     mockFlagsCmd(archiveTestFlags, "stale-days", "0"),
+			wantErr: true,
+		},
+		{
+			name:    "archiveCommand fails on repo read",
+			cmd:     mockFlagsCmd(archiveTestFlags, "repos", "testdata/two_repo_list.txt"),
+			repo:    &repository{reader: &mockRepositoryReader{readFail: true}},
+			wantErr: true,
+		},
+		{
+			name:    "archiveCommand writes stale candidates",
+			cmd:     mockFlagsCmd(archiveTestFlags, "stale-days", "365"),
+			wantErr: false,
+		},
+	}
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := archiveCommand(tt.cmd, tt.repo, now); (err != nil) != tt.wantErr {
+				t.Errorf("archiveCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	content, err := os.ReadFile(candidatesFile)
+	if err != nil {
+		t.Fatalf("failed to read candidates file: %v", err)
+	}
+
+	if string(content) != "stale-repo\nnever-pushed\n" {
+		t.Errorf("archiveCommand() candidates file = %q", string(content))
+	}
+}
@@ -10,6 +10,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var branchProtectionCmd = &cobra.Command{ // nolint // needed for cobra
+	Use:   "branch-protection",
+	Short: "Manage branch protection rules for repos in provided list",
+}
+
 type BranchProtectionArgs struct {
 	Name     string
 	DataType string
@@ -54,6 +59,10 @@ func branchProtectionQuery(
 		mutationName = "updateBranchProtectionRule"
 	}
 
+	if action == "delete" {
+		mutationName = "deleteBranchProtectionRule"
+	}
+
 	mutation.WriteString(fmt.Sprintf("mutation %s(", mutationName))
 	mutation.WriteString("$clientMutationId: String!,")
 
@@ -68,9 +77,16 @@ func branchProtectionQuery(
 	input.WriteString("})")
 
 	output.WriteString("{")
-	output.WriteString("branchProtectionRule {")
-	output.WriteString("id")
-	output.WriteString("}")
+
+	// Delete payload has no rule to return
+	if action == "delete" {
+		output.WriteString("clientMutationId")
+	} else {
+		output.WriteString("branchProtectionRule {")
+		output.WriteString("id")
+		output.WriteString("}")
+	}
+
 	output.WriteString("}}")
 
 	query = mutation.String() + input.String() + output.String()
@@ -235,6 +251,29 @@ func branchProtectionUpdateCheck(
 		}
 	}
 
+	// Copy replaces every setting on the rule with the same pattern only
+	if action == "Copy" {
+		if branchProtection.Pattern != branchNamePattern {
+			return false, false
+		}
+
+		// If rule already has every setting of the source rule, no need to update
+		if branchProtectionCopyMatch(branchProtection) {
+			return false, true
+		}
+	}
+
+	if action == "Push-restrictions" {
+		if branchProtection.Pattern != branchNamePattern {
+			return false, false
+		}
+
+		// If rule already has the same push restrictions and allowances, no need to update
+		if pushRestrictionsMatch(branchProtection) {
+			return false, true
+		}
+	}
+
 	return true, false
 }
 
@@ -261,6 +300,24 @@ func branchProtectionUpdate(
 	return nil
 }
 
+func branchProtectionDelete(branchProtectionRuleID string, s *githubBranchProtectionSender) error {
+	branchProtectionArgs := []BranchProtectionArgs{
+		{
+			Name:     "branchProtectionRuleId",
+			DataType: "String",
+			Value:    branchProtectionRuleID,
+		},
+	}
+	query, requestVars := branchProtectionQuery(branchProtectionArgs, "delete")
+	req := branchProtectionRequest(query, requestVars)
+
+	if err := s.sender.send(req); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
 func branchProtectionCreate(
 	branchProtectionArgs []BranchProtectionArgs,
 	repositoryID,
@@ -374,3 +431,8 @@ func branchProtectionDisplayInfo(updated, created, info, problems []string, batc
 		log.Printf("Error (%s): %v", batchInfo, err)
 	}
 }
+
+// nolint // needed for cobra
+func init() {
+	rootCmd.AddCommand(branchProtectionCmd)
+}
@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/graphqlclient"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	branchProtectionCopyRule BranchProtectionCopyRule // nolint // resolved from the source repository before apply
+	errCopyRuleNotFound      = errors.New("branch protection rule not found in source repository")
+	branchProtectionCopyCmd  = &cobra.Command{ // nolint // needed for cobra
+		Use:   "copy",
+		Short: "Copy a branch protection rule from a template repo to repos in provided list",
+		RunE:  branchProtectionCopyRun,
+	}
+)
+
+func branchProtectionCopyRun(cmd *cobra.Command, args []string) error {
+	sourceRepository, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	pattern, err := cmd.Flags().GetString("pattern")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	rule, err := branchProtectionCopySource(context.Background(), sourceRepository, pattern)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	branchProtectionCopyRule = rule
+
+	log.Printf("Copying branch protection rule %s from %s", pattern, sourceRepository)
+
+	err = branchProtectionCommand(
+		cmd,
+		setBranchProtectionCopyArgs(rule),
+		"Copy",
+		pattern,
+		&repository{
+			reader: &repositoryReaderService{},
+			getter: &repositoryGetterService{},
+		},
+		&githubRepositorySender{
+			sender: &repositorySenderService{},
+		},
+		&githubBranchProtectionSender{
+			sender: &branchProtectionSenderService{},
+		},
+	)
+
+	return err
+}
+
+// branchProtectionCopySource returns the rule with the given pattern from the source repository.
+func branchProtectionCopySource(
+	ctx context.Context,
+	sourceRepository,
+	pattern string,
+) (
+	rule BranchProtectionCopyRule,
+	err error,
+) {
+	var cursor *string
+
+	client := graphqlclient.NewClient()
+	req := reportRequest(branchProtectionCopyQuery())
+	req.Var("repo", sourceRepository)
+
+	for {
+		req.Var("after", cursor)
+
+		var respData BranchProtectionCopyResponse
+		if err := client.Run(ctx, req, &respData); err != nil {
+			return rule, fmt.Errorf("graphql call: %w", err)
+		}
+
+		for _, node := range respData.Repository.BranchProtectionRules.Nodes {
+			if node.Pattern == pattern {
+				return node, nil
+			}
+		}
+
+		if !respData.Repository.BranchProtectionRules.PageInfo.HasNextPage {
+			break
+		}
+
+		cursor = &respData.Repository.BranchProtectionRules.PageInfo.EndCursor
+	}
+
+	return rule, fmt.Errorf("%w: %s in %s", errCopyRuleNotFound, pattern, sourceRepository)
+}
+
+func setBranchProtectionCopyArgs(rule BranchProtectionCopyRule) []BranchProtectionArgs {
+	requiredStatusChecks := make([]map[string]string, 0, len(rule.RequiredStatusChecks))
+
+	for _, check := range rule.RequiredStatusChecks {
+		statusCheck := map[string]string{"context": check.Context}
+		if check.App != nil {
+			statusCheck["appId"] = check.App.ID
+		}
+
+		requiredStatusChecks = append(requiredStatusChecks, statusCheck)
+	}
+
+	requiredDeploymentEnvironments := []string{}
+	requiredDeploymentEnvironments = append(requiredDeploymentEnvironments, rule.RequiredDeploymentEnvironments...)
+
+	booleans := []struct {
+		name  string
+		value bool
+	}{
+		{"allowsDeletions", rule.AllowsDeletions},
+		{"allowsForcePushes", rule.AllowsForcePushes},
+		{"blocksCreations", rule.BlocksCreations},
+		{"dismissesStaleReviews", rule.DismissesStaleReviews},
+		{"isAdminEnforced", rule.IsAdminEnforced},
+		{"lockAllowsFetchAndMerge", rule.LockAllowsFetchAndMerge},
+		{"lockBranch", rule.LockBranch},
+		{"requireLastPushApproval", rule.RequireLastPushApproval},
+		{"requiresApprovingReviews", rule.RequiresApprovingReviews},
+		{"requiresCodeOwnerReviews", rule.RequiresCodeOwnerReviews},
+		{"requiresCommitSignatures", rule.RequiresCommitSignatures},
+		{"requiresConversationResolution", rule.RequiresConversationResolution},
+		{"requiresDeployments", rule.RequiresDeployments},
+		{"requiresLinearHistory", rule.RequiresLinearHistory},
+		{"requiresStatusChecks", rule.RequiresStatusChecks},
+		{"requiresStrictStatusChecks", rule.RequiresStrictStatusChecks},
+		{"restrictsPushes", rule.RestrictsPushes},
+		{"restrictsReviewDismissals", rule.RestrictsReviewDismissals},
+	}
+
+	branchProtectionArgs := make([]BranchProtectionArgs, 0, len(booleans)+8)
+
+	for _, boolean := range booleans {
+		branchProtectionArgs = append(branchProtectionArgs, BranchProtectionArgs{
+			Name:     boolean.name,
+			DataType: "Boolean",
+			Value:    boolean.value,
+		})
+	}
+
+	return append(
+		branchProtectionArgs,
+		BranchProtectionArgs{
+			Name:     "requiredApprovingReviewCount",
+			DataType: "Int",
+			Value:    rule.RequiredApprovingReviewCount,
+		},
+		BranchProtectionArgs{
+			Name:     "requiredStatusChecks",
+			DataType: "[RequiredStatusCheckInput!]",
+			Value:    requiredStatusChecks,
+		},
+		BranchProtectionArgs{
+			Name:     "requiredDeploymentEnvironments",
+			DataType: "[String!]",
+			Value:    requiredDeploymentEnvironments,
+		},
+		BranchProtectionArgs{
+			Name:     "pushActorIds",
+			DataType: "[ID!]",
+			Value:    allowanceActorIDs(rule.PushAllowances),
+		},
+		BranchProtectionArgs{
+			Name:     "bypassForcePushActorIds",
+			DataType: "[ID!]",
+			Value:    allowanceActorIDs(rule.BypassForcePushAllowances),
+		},
+		BranchProtectionArgs{
+			Name:     "bypassPullRequestActorIds",
+			DataType: "[ID!]",
+			Value:    allowanceActorIDs(rule.BypassPullRequestAllowances),
+		},
+		BranchProtectionArgs{
+			Name:     "reviewDismissalActorIds",
+			DataType: "[ID!]",
+			Value:    allowanceActorIDs(rule.ReviewDismissalAllowances),
+		},
+	)
+}
+
+// branchProtectionCopyMatch reports whether the rule already has every setting of the source rule, lists are
+// compared ignoring order.
+func branchProtectionCopyMatch(branchProtection BranchProtectionRulesNode) bool {
+	target := BranchProtectionCopyRule{
+		Pattern:                        branchProtection.Pattern,
+		AllowsDeletions:                branchProtection.AllowsDeletions,
+		AllowsForcePushes:              branchProtection.AllowsForcePushes,
+		BlocksCreations:                branchProtection.BlocksCreations,
+		DismissesStaleReviews:          branchProtection.DismissesStaleReviews,
+		IsAdminEnforced:                branchProtection.IsAdminEnforced,
+		LockAllowsFetchAndMerge:        branchProtection.LockAllowsFetchAndMerge,
+		LockBranch:                     branchProtection.LockBranch,
+		RequireLastPushApproval:        branchProtection.RequireLastPushApproval,
+		RequiredApprovingReviewCount:   branchProtection.RequiredApprovingReviewCount,
+		RequiredDeploymentEnvironments: branchProtection.RequiredDeploymentEnvironments,
+		RequiredStatusChecks:           branchProtection.RequiredStatusChecks,
+		RequiresApprovingReviews:       branchProtection.RequiresApprovingReviews,
+		RequiresCodeOwnerReviews:       branchProtection.RequiresCodeOwnerReviews,
+		RequiresCommitSignatures:       branchProtection.RequiresCommitSignatures,
+		RequiresConversationResolution: branchProtection.RequiresConversationResolution,
+		RequiresDeployments:            branchProtection.RequiresDeployments,
+		RequiresLinearHistory:          branchProtection.RequiresLinearHistory,
+		RequiresStatusChecks:           branchProtection.RequiresStatusChecks,
+		RequiresStrictStatusChecks:     branchProtection.RequiresStrictStatusChecks,
+		RestrictsPushes:                branchProtection.RestrictsPushes,
+		RestrictsReviewDismissals:      branchProtection.RestrictsReviewDismissals,
+		PushAllowances:                 branchProtection.PushAllowances,
+		BypassForcePushAllowances:      branchProtection.BypassForcePushAllowances,
+		BypassPullRequestAllowances:    branchProtection.BypassPullRequestAllowances,
+		ReviewDismissalAllowances:      branchProtection.ReviewDismissalAllowances,
+	}
+
+	return reflect.DeepEqual(
+		branchProtectionArgsSorted(setBranchProtectionCopyArgs(branchProtectionCopyRule)),
+		branchProtectionArgsSorted(setBranchProtectionCopyArgs(target)),
+	)
+}
+
+// branchProtectionArgsSorted sorts the list values in place, setBranchProtectionCopyArgs builds new lists.
+func branchProtectionArgsSorted(branchProtectionArgs []BranchProtectionArgs) []BranchProtectionArgs {
+	for _, arg := range branchProtectionArgs {
+		switch value := arg.Value.(type) {
+		case []string:
+			sort.Strings(value)
+		case []map[string]string:
+			sort.Slice(value, func(i, j int) bool {
+				return value[i]["context"]+value[i]["appId"] < value[j]["context"]+value[j]["appId"]
+			})
+		}
+	}
+
+	return branchProtectionArgs
+}
+
+func allowanceActorIDs(allowances BranchProtectionAllowances) []string {
+	actorIDs := []string{}
+
+	for _, node := range allowances.Nodes {
+		if node.Actor.ID != "" {
+			actorIDs = append(actorIDs, node.Actor.ID)
+		}
+	}
+
+	return actorIDs
+}
+
+func branchProtectionCopyQuery() string {
+	var query strings.Builder
+
+	query.WriteString("query ($org: String! $repo: String! $after: String) {")
+	query.WriteString("	repository(owner: $org, name: $repo) {")
+	query.WriteString("		branchProtectionRules(first: 100, after: $after) {")
+	query.WriteString("			pageInfo {")
+	query.WriteString("				endCursor")
+	query.WriteString("				hasNextPage")
+	query.WriteString("			}")
+	query.WriteString("			nodes {")
+	query.WriteString("				pattern")
+	query.WriteString("				allowsDeletions")
+	query.WriteString("				allowsForcePushes")
+	query.WriteString("				blocksCreations")
+	query.WriteString("				dismissesStaleReviews")
+	query.WriteString("				isAdminEnforced")
+	query.WriteString("				lockAllowsFetchAndMerge")
+	query.WriteString("				lockBranch")
+	query.WriteString("				requireLastPushApproval")
+	query.WriteString("				requiredApprovingReviewCount")
+	query.WriteString("				requiredDeploymentEnvironments")
+	query.WriteString("				requiredStatusChecks {")
+	query.WriteString("					context")
+	query.WriteString("					app {")
+	query.WriteString("						id")
+	query.WriteString("					}")
+	query.WriteString("				}")
+	query.WriteString("				requiresApprovingReviews")
+	query.WriteString("				requiresCodeOwnerReviews")
+	query.WriteString("				requiresCommitSignatures")
+	query.WriteString("				requiresConversationResolution")
+	query.WriteString("				requiresDeployments")
+	query.WriteString("				requiresLinearHistory")
+	query.WriteString("				requiresStatusChecks")
+	query.WriteString("				requiresStrictStatusChecks")
+	query.WriteString("				restrictsPushes")
+	query.WriteString("				restrictsReviewDismissals")
+	branchProtectionCopyActorQuery(&query, "pushAllowances")
+	branchProtectionCopyActorQuery(&query, "bypassForcePushAllowances")
+	branchProtectionCopyActorQuery(&query, "bypassPullRequestAllowances")
+	branchProtectionCopyActorQuery(&query, "reviewDismissalAllowances")
+	query.WriteString("			}")
+	query.WriteString("		}")
+	query.WriteString("	}")
+	query.WriteString("}")
+
+	return query.String()
+}
+
+func branchProtectionCopyActorQuery(query *strings.Builder, connection string) {
+	query.WriteString(fmt.Sprintf("				%s(first: 100) {", connection))
+	query.WriteString("					nodes {")
+	query.WriteString("						actor {")
+	query.WriteString("							... on App { id }")
+	query.WriteString("							... on Team { id }")
+	query.WriteString("							... on User { id }")
+	query.WriteString("						}")
+	query.WriteString("					}")
+	query.WriteString("				}")
+}
+
+// nolint // needed for cobra
+func init() {
+	branchProtectionCopyCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	branchProtectionCopyCmd.Flags().StringP("from", "f", "", "template repository (without org/ prefix) to copy the branch protection rule from")
+	branchProtectionCopyCmd.Flags().StringP("pattern", "p", "", "pattern of the branch protection rule to copy")
+	branchProtectionCopyCmd.MarkFlagRequired("repos")
+	branchProtectionCopyCmd.MarkFlagRequired("from")
+	branchProtectionCopyCmd.MarkFlagRequired("pattern")
+	branchProtectionCopyCmd.Flags().SortFlags = false
+	branchProtectionCmd.AddCommand(branchProtectionCopyCmd)
+}
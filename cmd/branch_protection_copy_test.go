@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_setBranchProtectionCopyArgs(t *testing.T) {
+	rule := BranchProtectionCopyRule{
+		Pattern:                        "main",
+		RequiresApprovingReviews:       true,
+		RequiredApprovingReviewCount:   2,
+		RequiredDeploymentEnvironments: []string{"production"},
+		RequiredStatusChecks: []RequiredStatusCheck{
+			{Context: "build", App: &struct {
+				ID string `json:"id"`
+			}{ID: "A_app-node-id"}},
+			{Context: "lint"},
+		},
+		PushAllowances: BranchProtectionAllowances{
+			Nodes: []BranchProtectionAllowancesNode{
+				{Actor: BranchProtectionActor{ID: "T_team-node-id"}},
+			},
+		},
+	}
+
+	got := setBranchProtectionCopyArgs(rule)
+
+	want := map[string]interface{}{
+		"requiresApprovingReviews":     true,
+		"requiresCommitSignatures":     false,
+		"requiredApprovingReviewCount": 2,
+		"requiredStatusChecks": []map[string]string{
+			{"context": "build", "appId": "A_app-node-id"},
+			{"context": "lint"},
+		},
+		"requiredDeploymentEnvironments": []string{"production"},
+		"pushActorIds":                   []string{"T_team-node-id"},
+		"reviewDismissalActorIds":        []string{},
+	}
+
+	values := make(map[string]interface{}, len(got))
+	for _, arg := range got {
+		values[arg.Name] = arg.Value
+	}
+
+	for name, wantValue := range want {
+		if !reflect.DeepEqual(values[name], wantValue) {
+			t.Errorf("setBranchProtectionCopyArgs() %s = %v, want %v", name, values[name], wantValue)
+		}
+	}
+
+	if len(got) != 25 {
+		t.Errorf("setBranchProtectionCopyArgs() returned %d args, want 25", len(got))
+	}
+}
+
+func Test_branchProtectionCopySource(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	tests := []struct {
+		name               string
+		pattern            string
+		mockHTTPReturnFile string
+		mockHTTPStatusCode int
+		wantPattern        string
+		wantErr            bool
+	}{
+		{
+			name:               "branchProtectionCopySource fails on graphql call",
+			pattern:            "main",
+			mockHTTPReturnFile: "testdata/mockEmptyResponse.json",
+			mockHTTPStatusCode: 500,
+			wantErr:            true,
+		},
+		{
+			name:               "branchProtectionCopySource fails on missing pattern",
+			pattern:            "develop",
+			mockHTTPReturnFile: "testdata/mockBranchProtectionCopyResponse.json",
+			mockHTTPStatusCode: 200,
+			wantErr:            true,
+		},
+		{
+			name:               "branchProtectionCopySource success",
+			pattern:            "main",
+			mockHTTPReturnFile: "testdata/mockBranchProtectionCopyResponse.json",
+			mockHTTPStatusCode: 200,
+			wantPattern:        "main",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTPResponder("POST", "https://api.github.com/graphql", tt.mockHTTPReturnFile, tt.mockHTTPStatusCode)
+
+			got, err := branchProtectionCopySource(context.Background(), "template-repo", tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("branchProtectionCopySource() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got.Pattern != tt.wantPattern {
+				t.Errorf("branchProtectionCopySource() pattern = %v, want %v", got.Pattern, tt.wantPattern)
+			}
+		})
+	}
+}
+
+func Test_branchProtectionCopyRun(t *testing.T) {
+	mockCmdNoFlags := &cobra.Command{
+		Use: "copy",
+	}
+
+	mockCmdNoPattern := &cobra.Command{
+		Use: "copy",
+	}
+	mockCmdNoPattern.Flags().StringP("from", "f", "template-repo", "from flag")
+
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		wantErr bool
+	}{
+		{
+			name:    "branchProtectionCopyRun fails on missing from flag",
+			cmd:     mockCmdNoFlags,
+			wantErr: true,
+		},
+		{
+			name:    "branchProtectionCopyRun fails on missing pattern flag",
+			cmd:     mockCmdNoPattern,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := branchProtectionCopyRun(tt.cmd, []string{}); (err != nil) != tt.wantErr {
+				t.Errorf("branchProtectionCopyRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
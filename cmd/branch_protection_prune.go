@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errPruneEmptyFlags       = errors.New("must set pattern or unused or both")
+	branchProtectionPruneCmd = &cobra.Command{ // nolint // needed for cobra
+		Use:   "prune",
+		Short: "Delete branch protection rules by pattern or rules that match no branches for repos in provided list",
+		RunE:  branchProtectionPruneRun,
+	}
+)
+
+func branchProtectionPruneRun(cmd *cobra.Command, args []string) error {
+	err := branchProtectionPruneCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+			getter: &repositoryGetterService{},
+		},
+		&githubRepositorySender{
+			sender: &repositorySenderService{},
+		},
+		&githubBranchProtectionSender{
+			sender: &branchProtectionSenderService{},
+		},
+	)
+
+	return err
+}
+
+func branchProtectionPruneCommand(
+	cmd *cobra.Command,
+	repo *repository,
+	repoSender *githubRepositorySender,
+	branchProtectionSender *githubBranchProtectionSender,
+) error {
+	dryRun, reposFilePath, err := branchProtectionFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	pattern, unused, err := branchProtectionPruneFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	repositoryList, err := repo.reader.read(reposFilePath)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	log.SetFlags(0)
+
+	if dryRun {
+		log.Printf("This is a dry run, the run would process %d repositories", len(repositoryList))
+	}
+
+	callLimit := 100
+	for left := 0; left < len(repositoryList); left += callLimit {
+		right := left + callLimit
+		if right > len(repositoryList) {
+			right = len(repositoryList)
+		}
+
+		repositories, err := repo.getter.get(repositoryList[left:right], repoSender)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		deleted, info, problems := branchProtectionPrune(
+			repositories,
+			pattern,
+			unused,
+			dryRun,
+			branchProtectionSender,
+		)
+
+		branchProtectionPruneDisplayInfo(deleted, info, problems, dryRun, fmt.Sprintf("Batch %d-%d", left, right))
+	}
+
+	return nil
+}
+
+// branchProtectionPrune deletes the rules matching pattern, or only those matching no branches when unused is set.
+// In dry run the rules are returned as deleted without calling the API so they can be reviewed first.
+func branchProtectionPrune(
+	repositories map[string]*RepositoriesNode,
+	pattern string,
+	unused,
+	dryRun bool,
+	sender *githubBranchProtectionSender,
+) (
+	deleted,
+	info,
+	problems []string,
+) {
+	for _, repository := range repositories {
+		pruned := 0
+
+		for _, branchProtection := range repository.BranchProtectionRules.Nodes {
+			if pattern != "" && branchProtection.Pattern != pattern {
+				continue
+			}
+
+			if unused && branchProtection.MatchingRefs.TotalCount > 0 {
+				continue
+			}
+
+			if !dryRun {
+				if err := branchProtectionDelete(branchProtection.ID, sender); err != nil {
+					problems = append(problems, err.Error())
+
+					continue
+				}
+			}
+
+			pruned++
+
+			deleted = append(
+				deleted,
+				fmt.Sprintf(
+					"Branch protection rule for %v with branch name: %s (matching branches: %d)",
+					repository.NameWithOwner,
+					branchProtection.Pattern,
+					branchProtection.MatchingRefs.TotalCount,
+				),
+			)
+		}
+
+		if pruned == 0 {
+			info = append(info, fmt.Sprintf("No branch protection rules to prune for %v", repository.NameWithOwner))
+		}
+	}
+
+	return deleted, info, problems
+}
+
+func branchProtectionPruneFlagCheck(cmd *cobra.Command) (pattern string, unused bool, err error) {
+	pattern, err = cmd.Flags().GetString("pattern")
+	if err != nil {
+		return pattern, unused, fmt.Errorf("%w", err)
+	}
+
+	unused, err = cmd.Flags().GetBool("unused")
+	if err != nil {
+		return pattern, unused, fmt.Errorf("%w", err)
+	}
+
+	if pattern == "" && !unused {
+		return pattern, unused, errPruneEmptyFlags
+	}
+
+	return pattern, unused, nil
+}
+
+func branchProtectionPruneDisplayInfo(deleted, info, problems []string, dryRun bool, batchInfo string) {
+	deletedLabel := "Deleted"
+	if dryRun {
+		deletedLabel = "Would delete"
+	}
+
+	for _, repo := range deleted {
+		log.Printf("%s (%s): %v", deletedLabel, batchInfo, repo)
+	}
+
+	for _, i := range info {
+		log.Printf("Info (%s): %v", batchInfo, i)
+	}
+
+	for _, err := range problems {
+		log.Printf("Error (%s): %v", batchInfo, err)
+	}
+}
+
+// nolint // needed for cobra
+func init() {
+	branchProtectionPruneCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	branchProtectionPruneCmd.Flags().StringP("pattern", "p", "", "branch protection rule pattern to delete")
+	branchProtectionPruneCmd.Flags().BoolP("unused", "u", false, "only delete rules whose pattern matches no branches")
+	branchProtectionPruneCmd.MarkFlagRequired("repos")
+	branchProtectionPruneCmd.Flags().SortFlags = false
+	branchProtectionCmd.AddCommand(branchProtectionPruneCmd)
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func Test_branchProtectionPrune(t *testing.T) {
+	type args struct {
+		repositories map[string]*RepositoriesNode
+		pattern      string
+		unused       bool
+		dryRun       bool
+		sender       *githubBranchProtectionSender
+	}
+
+	mockRepositories := map[string]*RepositoriesNode{"repo0": {
+		ID:            "repoIdTEST",
+		NameWithOwner: "org/some-repo-name",
+		BranchProtectionRules: BranchProtectionRules{
+			Nodes: []BranchProtectionRulesNode{
+				{
+					ID:           "rule-in-use",
+					Pattern:      "main",
+					MatchingRefs: MatchingRefs{TotalCount: 1},
+				},
+				{
+					ID:      "rule-unused",
+					Pattern: "master",
+				},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantDeleted  []string
+		wantInfo     []string
+		wantProblems []string
+	}{
+		{
+			name: "branchProtectionPrune by pattern",
+			args: args{
+				repositories: mockRepositories,
+				pattern:      "main",
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{},
+				},
+			},
+			wantDeleted: []string{
+				"Branch protection rule for org/some-repo-name with branch name: main (matching branches: 1)",
+			},
+		},
+		{
+			name: "branchProtectionPrune unused rules",
+			args: args{
+				repositories: mockRepositories,
+				unused:       true,
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{},
+				},
+			},
+			wantDeleted: []string{
+				"Branch protection rule for org/some-repo-name with branch name: master (matching branches: 0)",
+			},
+		},
+		{
+			name: "branchProtectionPrune pattern still in use",
+			args: args{
+				repositories: mockRepositories,
+				pattern:      "main",
+				unused:       true,
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{},
+				},
+			},
+			wantInfo: []string{"No branch protection rules to prune for org/some-repo-name"},
+		},
+		{
+			name: "branchProtectionPrune dry run does not send",
+			args: args{
+				repositories: mockRepositories,
+				unused:       true,
+				dryRun:       true,
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{sendFail: true, action: "delete"},
+				},
+			},
+			wantDeleted: []string{
+				"Branch protection rule for org/some-repo-name with branch name: master (matching branches: 0)",
+			},
+		},
+		{
+			name: "branchProtectionPrune delete failure",
+			args: args{
+				repositories: mockRepositories,
+				unused:       true,
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{sendFail: true, action: "delete"},
+				},
+			},
+			wantInfo:     []string{"No branch protection rules to prune for org/some-repo-name"},
+			wantProblems: []string{"delete: test"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotDeleted, gotInfo, gotProblems := branchProtectionPrune(
+				tt.args.repositories,
+				tt.args.pattern,
+				tt.args.unused,
+				tt.args.dryRun,
+				tt.args.sender,
+			)
+			if !reflect.DeepEqual(gotDeleted, tt.wantDeleted) {
+				t.Errorf("branchProtectionPrune() gotDeleted = %v, want %v", gotDeleted, tt.wantDeleted)
+			}
+			if !reflect.DeepEqual(gotInfo, tt.wantInfo) {
+				t.Errorf("branchProtectionPrune() gotInfo = %v, want %v", gotInfo, tt.wantInfo)
+			}
+			if !reflect.DeepEqual(gotProblems, tt.wantProblems) {
+				t.Errorf("branchProtectionPrune() gotProblems = %v, want %v", gotProblems, tt.wantProblems)
+			}
+		})
+	}
+}
+
+func Test_branchProtectionPruneCommand(t *testing.T) {
+	var (
+		mockDryRun     bool
+		mockRepos2File string
+	)
+
+	mockCmdNoPruneFlags := &cobra.Command{
+		Use: "prune",
+	}
+	mockCmdNoPruneFlags.Flags().BoolVarP(&mockDryRun, "dry-run", "d", true, "dry run flag")
+	mockCmdNoPruneFlags.Flags().StringVarP(&mockRepos2File, "repos", "r", "testdata/two_repo_list.txt", "repos file")
+
+	mockCmdNoPattern := &cobra.Command{
+		Use: "prune",
+	}
+	mockCmdNoPattern.Flags().BoolVarP(&mockDryRun, "dry-run", "d", true, "dry run flag")
+	mockCmdNoPattern.Flags().StringVarP(&mockRepos2File, "repos", "r", "testdata/two_repo_list.txt", "repos file")
+	mockCmdNoPattern.Flags().StringP("pattern", "p", "", "pattern flag")
+	mockCmdNoPattern.Flags().BoolP("unused", "u", false, "unused flag")
+
+	mockCmdUnused := &cobra.Command{
+		Use: "prune",
+	}
+	mockCmdUnused.Flags().BoolVarP(&mockDryRun, "dry-run", "d", true, "dry run flag")
+	mockCmdUnused.Flags().StringVarP(&mockRepos2File, "repos", "r", "testdata/two_repo_list.txt", "repos file")
+	mockCmdUnused.Flags().StringP("pattern", "p", "", "pattern flag")
+	mockCmdUnused.Flags().BoolP("unused", "u", true, "unused flag")
+
+	type args struct {
+		cmd  *cobra.Command
+		repo *repository
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "branchProtectionPruneCommand fails on missing prune flags",
+			args: args{
+				cmd: mockCmdNoPruneFlags,
+			},
+			wantErr: true,
+		},
+		{
+			name: "branchProtectionPruneCommand fails on no pattern or unused",
+			args: args{
+				cmd: mockCmdNoPattern,
+			},
+			wantErr: true,
+		},
+		{
+			name: "branchProtectionPruneCommand fails on repo read",
+			args: args{
+				cmd: mockCmdUnused,
+				repo: &repository{
+					reader: &mockRepositoryReader{readFail: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "branchProtectionPruneCommand fails on repo get",
+			args: args{
+				cmd: mockCmdUnused,
+				repo: &repository{
+					reader: &mockRepositoryReader{returnValue: []string{"some-repo-name"}},
+					getter: &mockRepositoryGetter{getFail: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "branchProtectionPruneCommand is success",
+			args: args{
+				cmd: mockCmdUnused,
+				repo: &repository{
+					reader: &mockRepositoryReader{returnValue: []string{"some-repo-name"}},
+					getter: &mockRepositoryGetter{
+						returnValue: map[string]*RepositoriesNode{"repo0": {
+							ID:            "repoIdTEST",
+							NameWithOwner: "org/some-repo-name",
+						}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := branchProtectionPruneCommand(
+				tt.args.cmd,
+				tt.args.repo,
+				&githubRepositorySender{sender: &mockRepositorySender{}},
+				&githubBranchProtectionSender{sender: &mockSender{}},
+			); (err != nil) != tt.wantErr {
+				t.Errorf("branchProtectionPruneCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -383,8 +383,157 @@ func Test_branchProtectionApply(t *testing.T) {
 			},
 			wantErrors: []string{"create: test"},
 		},
+		{
+			name: "branchProtectionApply with push restrictions the same",
+			args: args{
+				repoSearchResult: map[string]*RepositoriesNode{"repo0": {
+					ID:            "repoIdTEST",
+					NameWithOwner: "org/push-restrictions-duplicate",
+					DefaultBranchRef: DefaultBranchRef{
+						Name: "default-branch-name",
+					},
+					BranchProtectionRules: BranchProtectionRules{
+						Nodes: []BranchProtectionRulesNode{{
+							RestrictsPushes: true,
+							Pattern:         "default-branch-name",
+							PushAllowances: BranchProtectionAllowances{
+								Nodes: []BranchProtectionAllowancesNode{
+									{Actor: BranchProtectionActor{ID: "T_team-node-id"}},
+								},
+							},
+							BypassForcePushAllowances: BranchProtectionAllowances{
+								Nodes: []BranchProtectionAllowancesNode{
+									{Actor: BranchProtectionActor{ID: "U_user-node-id"}},
+								},
+							},
+						}},
+					},
+				}},
+				action: "Push-restrictions",
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{sendFail: false},
+				},
+			},
+			wantInfo: []string{
+				"Push-restrictions already turned on for org/push-restrictions-duplicate with branch name: default-branch-name",
+			},
+		},
+		{
+			name: "branchProtectionApply with push restrictions different allowances",
+			args: args{
+				repoSearchResult: map[string]*RepositoriesNode{"repo0": {
+					ID:            "repoIdTEST",
+					NameWithOwner: "org/push-restrictions-changed",
+					DefaultBranchRef: DefaultBranchRef{
+						Name: "default-branch-name",
+					},
+					BranchProtectionRules: BranchProtectionRules{
+						Nodes: []BranchProtectionRulesNode{{
+							RestrictsPushes: true,
+							Pattern:         "default-branch-name",
+							PushAllowances: BranchProtectionAllowances{
+								Nodes: []BranchProtectionAllowancesNode{
+									{Actor: BranchProtectionActor{ID: "T_old-team-node-id"}},
+								},
+							},
+						}},
+					},
+				}},
+				action: "Push-restrictions",
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{sendFail: false},
+				},
+			},
+			wantModified: []string{
+				"Push-restrictions changed for org/push-restrictions-changed with branch name: default-branch-name",
+			},
+		},
+		{
+			name: "branchProtectionApply copy only updates rule with same pattern",
+			args: args{
+				repoSearchResult: map[string]*RepositoriesNode{"repo0": {
+					ID:            "repoIdTEST",
+					NameWithOwner: "org/copy-target",
+					DefaultBranchRef: DefaultBranchRef{
+						Name: "default-branch-name",
+					},
+					BranchProtectionRules: BranchProtectionRules{
+						Nodes: []BranchProtectionRulesNode{
+							{Pattern: "default-branch-name"},
+							{Pattern: "release/*"},
+						},
+					},
+				}},
+				action:     "Copy",
+				branchName: "release/*",
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{sendFail: false},
+				},
+			},
+			wantModified: []string{
+				"Copy changed for org/copy-target with branch name: release/*",
+			},
+		},
+		{
+			name: "branchProtectionApply copy with settings the same",
+			args: args{
+				repoSearchResult: map[string]*RepositoriesNode{"repo0": {
+					ID:            "repoIdTEST",
+					NameWithOwner: "org/copy-duplicate",
+					DefaultBranchRef: DefaultBranchRef{
+						Name: "default-branch-name",
+					},
+					BranchProtectionRules: BranchProtectionRules{
+						Nodes: []BranchProtectionRulesNode{{
+							RequiresCommitSignatures: true,
+							RequiredStatusChecks:     []RequiredStatusCheck{{Context: "lint"}, {Context: "build"}},
+							Pattern:                  "release/*",
+							PushAllowances: BranchProtectionAllowances{
+								Nodes: []BranchProtectionAllowancesNode{
+									{Actor: BranchProtectionActor{ID: "U_user-node-id"}},
+									{Actor: BranchProtectionActor{ID: "T_team-node-id"}},
+								},
+							},
+						}},
+					},
+				}},
+				action:     "Copy",
+				branchName: "release/*",
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{sendFail: false},
+				},
+			},
+			wantInfo: []string{
+				"Copy already turned on for org/copy-duplicate with branch name: release/*",
+			},
+		},
+	}
+
+	originalCopyRule := branchProtectionCopyRule
+	branchProtectionCopyRule = BranchProtectionCopyRule{
+		RequiresCommitSignatures: true,
+		RequiredStatusChecks:     []RequiredStatusCheck{{Context: "build"}, {Context: "lint"}},
+		PushAllowances: BranchProtectionAllowances{
+			Nodes: []BranchProtectionAllowancesNode{
+				{Actor: BranchProtectionActor{ID: "T_team-node-id"}},
+				{Actor: BranchProtectionActor{ID: "U_user-node-id"}},
+			},
+		},
 	}
 
+	originalPushRestrictions := pushRestrictions
+	restrictsPushes := true
+	pushRestrictions = pushRestrictionsSettings{
+		restrictsPushes: &restrictsPushes,
+		pushActorIDs:    []string{"T_team-node-id"},
+		pushActorsSet:   true,
+	}
+
+	defer func() {
+		pushRestrictions = originalPushRestrictions
+		branchProtectionCopyRule = originalCopyRule
+	}()
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			gotModified, gotCreated, gotInfo, gotErrors := branchProtectionApply(
@@ -468,6 +617,23 @@ func Test_branchProtectionQuery(t *testing.T) {
 				"requiresApprovingReviews": true,
 			},
 		},
+		{
+			name: "branchProtectionQuery returns delete query",
+			args: args{
+				branchProtectionArgs: []BranchProtectionArgs{
+					{
+						Name:     "branchProtectionRuleId",
+						DataType: "String",
+						Value:    "some-rule-id",
+					},
+				},
+				action: "delete",
+			},
+			filePath: "testdata/mockDeleteBranchProtectionQuery.txt",
+			wantRequestVars: map[string]interface{}{
+				"branchProtectionRuleId": "some-rule-id",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -592,6 +758,51 @@ func Test_branchProtectionUpdate(t *testing.T) {
 	}
 }
 
+func Test_branchProtectionDelete(t *testing.T) {
+	type args struct {
+		branchProtectionRuleID string
+		sender                 *githubBranchProtectionSender
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "branchProtectionDelete is successful",
+			args: args{
+				branchProtectionRuleID: "some-rule-id",
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "branchProtectionDelete is failure",
+			args: args{
+				branchProtectionRuleID: "some-rule-id",
+				sender: &githubBranchProtectionSender{
+					sender: &mockSender{sendFail: true},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := branchProtectionDelete(
+				tt.args.branchProtectionRuleID,
+				tt.args.sender,
+			); (err != nil) != tt.wantErr {
+				t.Errorf("branchProtectionDelete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_branchProtectionCreate(t *testing.T) {
 	type args struct {
 		branchProtectionArgs []BranchProtectionArgs
@@ -0,0 +1,17 @@
+package cmd
+
+// changeCount counts the repositories where a setting was changed and where it was already set.
+type changeCount struct {
+	changed   int
+	unchanged int
+}
+
+func (c *changeCount) add(changed bool) {
+	if changed {
+		c.changed++
+
+		return
+	}
+
+	c.unchanged++
+}
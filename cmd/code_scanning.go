@@ -0,0 +1,433 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	codeScanningConfigured    = "configured"
+	codeScanningNotConfigured = "not-configured"
+)
+
+var (
+	errCodeScanningQuerySuite = errors.New("query-suite must be default or extended")
+	errCodeScanningLanguage   = errors.New("invalid CodeQL language")
+	errCodeScanningSkipped    = errors.New("default setup unavailable")
+	errCodeScanningRunFailed  = errors.New("configuration run did not succeed")
+	errCodeScanningTimeout    = errors.New("configuration run did not complete in time")
+	codeScanningCmd           = &cobra.Command{ // nolint // needed for cobra
+		Use:   "code-scanning",
+		Short: "Show, enable and disable code scanning default setup for repos in provided list",
+		RunE:  codeScanningRun,
+	}
+)
+
+// codeScanningPollInterval and codeScanningPollAttempts set how long --wait waits for a configuration run to complete.
+var (
+	codeScanningPollInterval = 10 * time.Second // nolint // expected global
+	codeScanningPollAttempts = 60               // nolint // expected global
+)
+
+// codeScanningLanguages maps the repository languages GitHub detects to the CodeQL language that analyses them.
+var codeScanningLanguages = map[string]string{ // nolint // expected global
+	"C":          "c-cpp",
+	"C++":        "c-cpp",
+	"C#":         "csharp",
+	"Go":         "go",
+	"Java":       "java-kotlin",
+	"Kotlin":     "java-kotlin",
+	"JavaScript": "javascript-typescript",
+	"TypeScript": "javascript-typescript",
+	"Python":     "python",
+	"Ruby":       "ruby",
+	"Swift":      "swift",
+}
+
+// codeScanningActionsLanguage is analysed from workflow files, which are not in the repository languages.
+const codeScanningActionsLanguage = "actions"
+
+type codeScanningSetup struct {
+	enable     bool
+	querySuite string
+	languages  []string
+	wait       bool
+}
+
+func codeScanningRun(cmd *cobra.Command, args []string) error {
+	err := codeScanningCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+	)
+
+	return err
+}
+
+func codeScanningCommand(cmd *cobra.Command, repo *repository) error {
+	dryRun, reposFilePath, err := branchProtectionFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	setup, err := codeScanningFlags(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	repositoryList, err := repo.reader.read(reposFilePath)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	log.SetFlags(0)
+
+	ctx := context.Background()
+
+	// Without the enable flag the current state is shown and nothing is changed
+	if !cmd.Flags().Changed("enable") {
+		for _, repositoryName := range repositoryList {
+			current, err := codeScanningDefaultSetup(ctx, repositoryName)
+			if errors.Is(err, errCodeScanningSkipped) {
+				log.Printf("Skipped (%s): %v", repositoryName, err)
+
+				continue
+			}
+
+			if err != nil {
+				log.Printf("Error (%s): %v", repositoryName, err)
+
+				continue
+			}
+
+			log.Printf("Code scanning default setup for repo %s: %s", repositoryName, codeScanningDescribe(current))
+		}
+
+		return nil
+	}
+
+	if dryRun {
+		log.Printf("This is a dry run, the run would process %d repositories", len(repositoryList))
+	}
+
+	var (
+		count   changeCount
+		skipped int
+	)
+
+	for _, repositoryName := range repositoryList {
+		changed, result, err := codeScanningUpdate(ctx, repositoryName, setup, dryRun)
+		if errors.Is(err, errCodeScanningSkipped) {
+			log.Printf("Skipped (%s): %v", repositoryName, err)
+
+			skipped++
+
+			continue
+		}
+
+		if err != nil {
+			log.Printf("Error (%s): %v", repositoryName, err)
+
+			continue
+		}
+
+		log.Print(result)
+		count.add(changed)
+	}
+
+	log.Printf("Code scanning default setup: %d changed, %d unchanged, %d skipped", count.changed, count.unchanged, skipped)
+
+	return nil
+}
+
+// codeScanningUpdate configures default setup on the repository unless it is already in the wanted state, with wait
+// set it waits for the configuration run to complete. It returns whether the repository was changed.
+func codeScanningUpdate(
+	ctx context.Context,
+	repositoryName string,
+	setup codeScanningSetup,
+	dryRun bool,
+) (bool, string, error) {
+	current, err := codeScanningDefaultSetup(ctx, repositoryName)
+	if err != nil {
+		return false, "", err
+	}
+
+	update := CodeScanningDefaultSetupUpdate{State: codeScanningNotConfigured}
+
+	if setup.enable {
+		languages, err := codeScanningRepositoryLanguages(ctx, repositoryName, setup.languages)
+		if err != nil {
+			return false, "", err
+		}
+
+		update = CodeScanningDefaultSetupUpdate{
+			State:      codeScanningConfigured,
+			QuerySuite: setup.querySuite,
+			Languages:  languages,
+		}
+	}
+
+	if codeScanningMatches(current, update) {
+		return false, fmt.Sprintf(
+			"Code scanning default setup already '%s' for repo %s",
+			codeScanningDescribe(current),
+			repositoryName,
+		), nil
+	}
+
+	wanted := codeScanningDescribe(CodeScanningDefaultSetup{
+		State:      update.State,
+		QuerySuite: update.QuerySuite,
+		Languages:  update.Languages,
+	})
+
+	if dryRun {
+		return true, fmt.Sprintf("Would set code scanning default setup to '%s' for repo %s", wanted, repositoryName), nil
+	}
+
+	client := restclient.NewClient(
+		fmt.Sprintf("/repos/%s/%s/code-scanning/default-setup", config.Org, repositoryName),
+		config.Token,
+		http.MethodPatch,
+	)
+	if err := client.SetBody(update); err != nil {
+		return false, "", fmt.Errorf("%w", err)
+	}
+
+	var response struct {
+		RunID int64 `json:"run_id"` // nolint // this is from github
+	}
+	if err := client.Run(ctx, &response); err != nil {
+		if errors.Is(err, restclient.ErrForbidden) {
+			return false, "", fmt.Errorf("%w: %v", errCodeScanningSkipped, err)
+		}
+
+		return false, "", fmt.Errorf("update code scanning default setup: %w", err)
+	}
+
+	// No run is started when GitHub has nothing to change
+	if response.RunID != 0 && !setup.wait {
+		return true, fmt.Sprintf(
+			"Successful setting code scanning default setup to '%s' for repo %s, configuration run %d pending",
+			wanted,
+			repositoryName,
+			response.RunID,
+		), nil
+	}
+
+	if response.RunID != 0 {
+		if err := codeScanningWaitForRun(ctx, repositoryName, response.RunID); err != nil {
+			return false, "", err
+		}
+	}
+
+	return true, fmt.Sprintf(
+		"Successful setting code scanning default setup to '%s' for repo %s",
+		wanted,
+		repositoryName,
+	), nil
+}
+
+// codeScanningDefaultSetup returns the current default setup, a repository that cannot use code scanning is skipped.
+func codeScanningDefaultSetup(ctx context.Context, repositoryName string) (CodeScanningDefaultSetup, error) {
+	client := restclient.NewClient(
+		fmt.Sprintf("/repos/%s/%s/code-scanning/default-setup", config.Org, repositoryName),
+		config.Token,
+		http.MethodGet,
+	)
+
+	var response CodeScanningDefaultSetup
+	if err := client.Run(ctx, &response); err != nil {
+		if errors.Is(err, restclient.ErrNotFound) || errors.Is(err, restclient.ErrForbidden) {
+			return response, fmt.Errorf("%w: %v", errCodeScanningSkipped, err)
+		}
+
+		return response, fmt.Errorf("get code scanning default setup: %w", err)
+	}
+
+	return response, nil
+}
+
+// codeScanningRepositoryLanguages returns the requested languages the repository has code in, or nil to let GitHub
+// choose when none were requested. A repository without a CodeQL supported language is skipped.
+func codeScanningRepositoryLanguages(ctx context.Context, repositoryName string, requested []string) ([]string, error) {
+	client := restclient.NewClient(
+		fmt.Sprintf("/repos/%s/%s/languages", config.Org, repositoryName),
+		config.Token,
+		http.MethodGet,
+	)
+
+	var response map[string]int
+	if err := client.Run(ctx, &response); err != nil {
+		return nil, fmt.Errorf("get repository languages: %w", err)
+	}
+
+	supported := map[string]bool{codeScanningActionsLanguage: true}
+	found := false
+
+	for language := range response {
+		if codeQLLanguage, ok := codeScanningLanguages[language]; ok {
+			supported[codeQLLanguage] = true
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: no CodeQL supported languages in the repository", errCodeScanningSkipped)
+	}
+
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	var languages []string
+
+	for _, language := range requested {
+		if supported[language] {
+			languages = append(languages, language)
+		}
+	}
+
+	if len(languages) == 0 {
+		return nil, fmt.Errorf(
+			"%w: none of %s in the repository",
+			errCodeScanningSkipped,
+			strings.Join(requested, ", "),
+		)
+	}
+
+	sort.Strings(languages)
+
+	return languages, nil
+}
+
+// codeScanningWaitForRun polls the configuration workflow run until it completes.
+func codeScanningWaitForRun(ctx context.Context, repositoryName string, runID int64) error {
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs/%d", config.Org, repositoryName, runID)
+
+	for attempt := 0; attempt < codeScanningPollAttempts; attempt++ {
+		var run struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		}
+		if err := restclient.NewClient(path, config.Token, http.MethodGet).Run(ctx, &run); err != nil {
+			return fmt.Errorf("get configuration run: %w", err)
+		}
+
+		if run.Status == "completed" {
+			if run.Conclusion != "success" {
+				return fmt.Errorf("%w: %s", errCodeScanningRunFailed, run.Conclusion)
+			}
+
+			return nil
+		}
+
+		time.Sleep(codeScanningPollInterval)
+	}
+
+	return fmt.Errorf("%w: run %d", errCodeScanningTimeout, runID)
+}
+
+// codeScanningMatches checks the current setup against the update, languages are only compared when requested.
+func codeScanningMatches(current CodeScanningDefaultSetup, update CodeScanningDefaultSetupUpdate) bool {
+	if current.State != update.State {
+		return false
+	}
+
+	if update.State == codeScanningNotConfigured {
+		return true
+	}
+
+	if current.QuerySuite != update.QuerySuite {
+		return false
+	}
+
+	if update.Languages == nil {
+		return true
+	}
+
+	currentLanguages := append([]string{}, current.Languages...)
+	sort.Strings(currentLanguages)
+
+	return strings.Join(currentLanguages, ",") == strings.Join(update.Languages, ",")
+}
+
+func codeScanningDescribe(setup CodeScanningDefaultSetup) string {
+	if setup.State != codeScanningConfigured {
+		return setup.State
+	}
+
+	description := fmt.Sprintf("%s, query suite %s", setup.State, setup.QuerySuite)
+	if len(setup.Languages) > 0 {
+		description += fmt.Sprintf(", languages %s", strings.Join(setup.Languages, ", "))
+	}
+
+	return description
+}
+
+func codeScanningFlags(cmd *cobra.Command) (codeScanningSetup, error) {
+	var (
+		setup codeScanningSetup
+		err   error
+	)
+
+	setup.enable, err = cmd.Flags().GetBool("enable")
+	if err != nil {
+		return setup, fmt.Errorf("%w", err)
+	}
+
+	setup.querySuite, err = cmd.Flags().GetString("query-suite")
+	if err != nil {
+		return setup, fmt.Errorf("%w", err)
+	}
+
+	if setup.querySuite != "default" && setup.querySuite != "extended" {
+		return setup, fmt.Errorf("%w: %s", errCodeScanningQuerySuite, setup.querySuite)
+	}
+
+	setup.languages, err = cmd.Flags().GetStringSlice("languages")
+	if err != nil {
+		return setup, fmt.Errorf("%w", err)
+	}
+
+	setup.wait, err = cmd.Flags().GetBool("wait")
+	if err != nil {
+		return setup, fmt.Errorf("%w", err)
+	}
+
+	valid := map[string]bool{codeScanningActionsLanguage: true}
+	for _, language := range codeScanningLanguages {
+		valid[language] = true
+	}
+
+	for _, language := range setup.languages {
+		if !valid[language] {
+			return setup, fmt.Errorf("%w: %s", errCodeScanningLanguage, language)
+		}
+	}
+
+	return setup, nil
+}
+
+// nolint // needed for cobra
+func init() {
+	codeScanningCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	codeScanningCmd.Flags().Bool("enable", true, "boolean indicating the status of code scanning default setup, the current state is shown when not set")
+	codeScanningCmd.Flags().String("query-suite", "default", "CodeQL query suite when enabling: default or extended")
+	codeScanningCmd.Flags().StringSlice("languages", []string{}, "CodeQL languages to analyse when enabling, e.g. go,python, GitHub chooses when not set")
+	codeScanningCmd.Flags().Bool("wait", false, "wait up to 10 minutes per repo for the configuration run to complete, otherwise it is reported as pending")
+	codeScanningCmd.MarkFlagRequired("repos")
+	codeScanningCmd.Flags().SortFlags = false
+	rootCmd.AddCommand(codeScanningCmd)
+}
@@ -0,0 +1,522 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"github-admin-tool/restclient"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func codeScanningTestFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", true, "dry run flag")
+	cmd.Flags().String("repos", "testdata/two_repo_list.txt", "repos file")
+	cmd.Flags().Bool("enable", true, "enable flag")
+	cmd.Flags().String("query-suite", "", "query suite flag")
+	cmd.Flags().StringSlice("languages", []string{}, "languages flag")
+	cmd.Flags().Bool("wait", false, "wait flag")
+}
+
+func Test_codeScanningFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		want    codeScanningSetup
+		wantErr bool
+	}{
+		{
+			name:    "codeScanningFlags fails on missing flags",
+			cmd:     &cobra.Command{Use: "code-scanning"},
+			wantErr: true,
+		},
+		{
+			name:    "codeScanningFlags fails on invalid query suite",
+			cmd:     mockFlagsCmd(codeScanningTestFlags, "enable", "true", "query-suite", "security-and-quality"),
+			wantErr: true,
+		},
+		{
+			name: "codeScanningFlags fails on invalid language",
+			cmd: mockFlagsCmd(
+				codeScanningTestFlags,
+				"enable", "true",
+				"query-suite", "default",
+				"languages", "go,cobol",
+			),
+			wantErr: true,
+		},
+		{
+			name: "codeScanningFlags success",
+			cmd: mockFlagsCmd(
+				codeScanningTestFlags,
+				"enable", "true",
+				"query-suite", "extended",
+				"languages", "python,go",
+			),
+			want: codeScanningSetup{enable: true, querySuite: "extended", languages: []string{"python", "go"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := codeScanningFlags(tt.cmd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("codeScanningFlags() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("codeScanningFlags() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_codeScanningRepositoryLanguages(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	languagesURL := "https://api.github.com/repos/some-org/repo1/languages"
+
+	tests := []struct {
+		name        string
+		requested   []string
+		file        string
+		statusCode  int
+		want        []string
+		wantErr     bool
+		wantSkipped bool
+	}{
+		{
+			name:       "codeScanningRepositoryLanguages fails",
+			file:       "testdata/mockRest404Response.json",
+			statusCode: 404,
+			wantErr:    true,
+		},
+		{
+			name:        "codeScanningRepositoryLanguages skips unsupported languages",
+			file:        "testdata/mockRepositoryLanguagesUnsupportedResponse.json",
+			statusCode:  200,
+			wantErr:     true,
+			wantSkipped: true,
+		},
+		{
+			name:        "codeScanningRepositoryLanguages skips when requested languages are not found",
+			requested:   []string{"ruby", "swift"},
+			file:        "testdata/mockRepositoryLanguagesResponse.json",
+			statusCode:  200,
+			wantErr:     true,
+			wantSkipped: true,
+		},
+		{
+			name:       "codeScanningRepositoryLanguages lets GitHub choose",
+			file:       "testdata/mockRepositoryLanguagesResponse.json",
+			statusCode: 200,
+		},
+		{
+			name:       "codeScanningRepositoryLanguages requested languages found",
+			requested:  []string{"ruby", "python", "actions", "go"},
+			file:       "testdata/mockRepositoryLanguagesResponse.json",
+			statusCode: 200,
+			want:       []string{"actions", "go", "python"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			mockHTTPResponder("GET", languagesURL, tt.file, tt.statusCode)
+
+			got, err := codeScanningRepositoryLanguages(context.Background(), "repo1", tt.requested)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("codeScanningRepositoryLanguages() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if skipped := errors.Is(err, errCodeScanningSkipped); skipped != tt.wantSkipped {
+				t.Errorf("codeScanningRepositoryLanguages() skipped = %v, want %v", skipped, tt.wantSkipped)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("codeScanningRepositoryLanguages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_codeScanningUpdate(t *testing.T) {
+	originalConfig := config
+	originalPollInterval := codeScanningPollInterval
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+		codeScanningPollInterval = originalPollInterval
+	}()
+
+	config.Org = MockOrgName
+	codeScanningPollInterval = 0
+
+	defaultSetupURL := "https://api.github.com/repos/some-org/repo1/code-scanning/default-setup"
+	languagesURL := "https://api.github.com/repos/some-org/repo1/languages"
+	runURL := "https://api.github.com/repos/some-org/repo1/actions/runs/42"
+	enable := codeScanningSetup{enable: true, querySuite: "extended"}
+	enableWait := codeScanningSetup{enable: true, querySuite: "extended", wait: true}
+
+	tests := []struct {
+		name          string
+		setup         codeScanningSetup
+		dryRun        bool
+		mockResponses []dependabotMockResponse
+		wantChanged   bool
+		wantResult    string
+		wantErr       bool
+		wantSkipped   bool
+		wantPatchCall int
+	}{
+		{
+			name:  "codeScanningUpdate skips repo without code scanning",
+			setup: enable,
+			mockResponses: []dependabotMockResponse{
+				{"GET", defaultSetupURL, "testdata/mockRest403Response.json", 403},
+			},
+			wantErr:     true,
+			wantSkipped: true,
+		},
+		{
+			name:  "codeScanningUpdate skips unsupported languages",
+			setup: enable,
+			mockResponses: []dependabotMockResponse{
+				{"GET", defaultSetupURL, "testdata/mockCodeScanningNotConfiguredResponse.json", 200},
+				{"GET", languagesURL, "testdata/mockRepositoryLanguagesUnsupportedResponse.json", 200},
+			},
+			wantErr:     true,
+			wantSkipped: true,
+		},
+		{
+			name:  "codeScanningUpdate already configured",
+			setup: codeScanningSetup{enable: true, querySuite: "default"},
+			mockResponses: []dependabotMockResponse{
+				{"GET", defaultSetupURL, "testdata/mockCodeScanningDefaultSetupResponse.json", 200},
+				{"GET", languagesURL, "testdata/mockRepositoryLanguagesResponse.json", 200},
+			},
+			wantResult: "Code scanning default setup already 'configured, query suite default, " +
+				"languages javascript-typescript, python' for repo repo1",
+		},
+		{
+			name:  "codeScanningUpdate already disabled",
+			setup: codeScanningSetup{querySuite: "default"},
+			mockResponses: []dependabotMockResponse{
+				{"GET", defaultSetupURL, "testdata/mockCodeScanningNotConfiguredResponse.json", 200},
+			},
+			wantResult: "Code scanning default setup already 'not-configured' for repo repo1",
+		},
+		{
+			name:   "codeScanningUpdate dry run",
+			setup:  enable,
+			dryRun: true,
+			mockResponses: []dependabotMockResponse{
+				{"GET", defaultSetupURL, "testdata/mockCodeScanningDefaultSetupResponse.json", 200},
+				{"GET", languagesURL, "testdata/mockRepositoryLanguagesResponse.json", 200},
+			},
+			wantChanged: true,
+			wantResult:  "Would set code scanning default setup to 'configured, query suite extended' for repo repo1",
+		},
+		{
+			name:  "codeScanningUpdate fails on update",
+			setup: enable,
+			mockResponses: []dependabotMockResponse{
+				{"GET", defaultSetupURL, "testdata/mockCodeScanningNotConfiguredResponse.json", 200},
+				{"GET", languagesURL, "testdata/mockRepositoryLanguagesResponse.json", 200},
+				{"PATCH", defaultSetupURL, "testdata/mockRest404Response.json", 422},
+			},
+			wantErr:       true,
+			wantPatchCall: 1,
+		},
+		{
+			name:  "codeScanningUpdate fails when configuration run fails",
+			setup: enableWait,
+			mockResponses: []dependabotMockResponse{
+				{"GET", defaultSetupURL, "testdata/mockCodeScanningNotConfiguredResponse.json", 200},
+				{"GET", languagesURL, "testdata/mockRepositoryLanguagesResponse.json", 200},
+				{"PATCH", defaultSetupURL, "testdata/mockCodeScanningUpdateResponse.json", 202},
+				{"GET", runURL, "testdata/mockActionsRunFailedResponse.json", 200},
+			},
+			wantErr:       true,
+			wantPatchCall: 1,
+		},
+		{
+			name:  "codeScanningUpdate enables default setup without waiting",
+			setup: enable,
+			mockResponses: []dependabotMockResponse{
+				{"GET", defaultSetupURL, "testdata/mockCodeScanningNotConfiguredResponse.json", 200},
+				{"GET", languagesURL, "testdata/mockRepositoryLanguagesResponse.json", 200},
+				{"PATCH", defaultSetupURL, "testdata/mockCodeScanningUpdateResponse.json", 202},
+				{"GET", runURL, "testdata/mockActionsRunFailedResponse.json", 200},
+			},
+			wantChanged: true,
+			wantResult: "Successful setting code scanning default setup to 'configured, query suite extended' " +
+				"for repo repo1, configuration run 42 pending",
+			wantPatchCall: 1,
+		},
+		{
+			name:  "codeScanningUpdate enables default setup",
+			setup: enableWait,
+			mockResponses: []dependabotMockResponse{
+				{"GET", defaultSetupURL, "testdata/mockCodeScanningNotConfiguredResponse.json", 200},
+				{"GET", languagesURL, "testdata/mockRepositoryLanguagesResponse.json", 200},
+				{"PATCH", defaultSetupURL, "testdata/mockCodeScanningUpdateResponse.json", 202},
+				{"GET", runURL, "testdata/mockActionsRunCompletedResponse.json", 200},
+			},
+			wantChanged:   true,
+			wantResult:    "Successful setting code scanning default setup to 'configured, query suite extended' for repo repo1",
+			wantPatchCall: 1,
+		},
+		{
+			name:  "codeScanningUpdate disables default setup",
+			setup: codeScanningSetup{querySuite: "default"},
+			mockResponses: []dependabotMockResponse{
+				{"GET", defaultSetupURL, "testdata/mockCodeScanningDefaultSetupResponse.json", 200},
+				{"PATCH", defaultSetupURL, "testdata/mockRest20xEmptyResponse.json", 200},
+			},
+			wantChanged:   true,
+			wantResult:    "Successful setting code scanning default setup to 'not-configured' for repo repo1",
+			wantPatchCall: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			for _, mockResponse := range tt.mockResponses {
+				mockHTTPResponder(mockResponse.method, mockResponse.url, mockResponse.file, mockResponse.statusCode)
+			}
+
+			changed, result, err := codeScanningUpdate(context.Background(), "repo1", tt.setup, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("codeScanningUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if skipped := errors.Is(err, errCodeScanningSkipped); skipped != tt.wantSkipped {
+				t.Errorf("codeScanningUpdate() skipped = %v, want %v", skipped, tt.wantSkipped)
+			}
+
+			if changed != tt.wantChanged || result != tt.wantResult {
+				t.Errorf("codeScanningUpdate() = %v, %v, want %v, %v", changed, result, tt.wantChanged, tt.wantResult)
+			}
+
+			if calls := httpmock.GetCallCountInfo()["PATCH "+defaultSetupURL]; calls != tt.wantPatchCall {
+				t.Errorf("codeScanningUpdate PATCH calls = %d, want %d", calls, tt.wantPatchCall)
+			}
+		})
+	}
+}
+
+func Test_codeScanningWaitForRun(t *testing.T) {
+	originalConfig := config
+	originalPollInterval := codeScanningPollInterval
+	originalPollAttempts := codeScanningPollAttempts
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+		codeScanningPollInterval = originalPollInterval
+		codeScanningPollAttempts = originalPollAttempts
+	}()
+
+	config.Org = MockOrgName
+	codeScanningPollInterval = 0
+	codeScanningPollAttempts = 2
+
+	runURL := "https://api.github.com/repos/some-org/repo1/actions/runs/42"
+
+	mockFile := func(filePath string) string {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("failed to read test data: %v", err)
+		}
+
+		return string(content)
+	}
+
+	tests := []struct {
+		name          string
+		mockResponses []string
+		statusCode    int
+		wantErr       error
+		wantCalls     int
+	}{
+		{
+			name:          "codeScanningWaitForRun fails to get run",
+			mockResponses: []string{"testdata/mockRest404Response.json"},
+			statusCode:    http.StatusNotFound,
+			wantErr:       restclient.ErrNotFound,
+			wantCalls:     1,
+		},
+		{
+			name: "codeScanningWaitForRun times out",
+			mockResponses: []string{
+				"testdata/mockActionsRunInProgressResponse.json",
+				"testdata/mockActionsRunInProgressResponse.json",
+			},
+			statusCode: http.StatusOK,
+			wantErr:    errCodeScanningTimeout,
+			wantCalls:  2,
+		},
+		{
+			name: "codeScanningWaitForRun completes",
+			mockResponses: []string{
+				"testdata/mockActionsRunInProgressResponse.json",
+				"testdata/mockActionsRunCompletedResponse.json",
+			},
+			statusCode: http.StatusOK,
+			wantCalls:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			responses := make([]*http.Response, 0, len(tt.mockResponses))
+			for _, mockResponse := range tt.mockResponses {
+				responses = append(responses, httpmock.NewStringResponse(tt.statusCode, mockFile(mockResponse)))
+			}
+
+			httpmock.RegisterResponder("GET", runURL, httpmock.ResponderFromMultipleResponses(responses))
+
+			if err := codeScanningWaitForRun(context.Background(), "repo1", 42); !errors.Is(err, tt.wantErr) {
+				t.Errorf("codeScanningWaitForRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if calls := httpmock.GetCallCountInfo()["GET "+runURL]; calls != tt.wantCalls {
+				t.Errorf("codeScanningWaitForRun GET calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func Test_codeScanningCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		repo    *repository
+		wantErr bool
+	}{
+		{
+			name:    "codeScanningCommand fails on missing flags",
+			cmd:     &cobra.Command{Use: "code-scanning"},
+			wantErr: true,
+		},
+		{
+			name:    "codeScanningCommand fails on invalid query suite",
+			cmd:     mockFlagsCmd(codeScanningTestFlags, "enable", "true", "query-suite", "all"),
+			wantErr: true,
+		},
+		{
+			name:    "codeScanningCommand fails on repo read",
+			cmd:     mockFlagsCmd(codeScanningTestFlags, "enable", "true", "query-suite", "default"),
+			repo:    &repository{reader: &mockRepositoryReader{readFail: true}},
+			wantErr: true,
+		},
+		{
+			name:    "codeScanningCommand status with no repos",
+			cmd:     mockFlagsCmd(codeScanningTestFlags, "query-suite", "default"),
+			repo:    &repository{reader: &mockRepositoryReader{}},
+			wantErr: false,
+		},
+		{
+			name:    "codeScanningCommand enable with no repos",
+			cmd:     mockFlagsCmd(codeScanningTestFlags, "enable", "true", "query-suite", "default"),
+			repo:    &repository{reader: &mockRepositoryReader{}},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := codeScanningCommand(tt.cmd, tt.repo); (err != nil) != tt.wantErr {
+				t.Errorf("codeScanningCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_codeScanningMatches(t *testing.T) {
+	current := CodeScanningDefaultSetup{
+		State:      "configured",
+		QuerySuite: "default",
+		Languages:  []string{"python", "go"},
+	}
+
+	tests := []struct {
+		name   string
+		update CodeScanningDefaultSetupUpdate
+		want   bool
+	}{
+		{
+			name:   "codeScanningMatches different state",
+			update: CodeScanningDefaultSetupUpdate{State: "not-configured"},
+			want:   false,
+		},
+		{
+			name:   "codeScanningMatches different query suite",
+			update: CodeScanningDefaultSetupUpdate{State: "configured", QuerySuite: "extended"},
+			want:   false,
+		},
+		{
+			name:   "codeScanningMatches languages not requested",
+			update: CodeScanningDefaultSetupUpdate{State: "configured", QuerySuite: "default"},
+			want:   true,
+		},
+		{
+			name: "codeScanningMatches different languages",
+			update: CodeScanningDefaultSetupUpdate{
+				State:      "configured",
+				QuerySuite: "default",
+				Languages:  []string{"go"},
+			},
+			want: false,
+		},
+		{
+			name: "codeScanningMatches same languages",
+			update: CodeScanningDefaultSetupUpdate{
+				State:      "configured",
+				QuerySuite: "default",
+				Languages:  []string{"go", "python"},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := codeScanningMatches(current, tt.update); got != tt.want {
+				t.Errorf("codeScanningMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errDefaultBranchEmptyName = errors.New("must set a branch name to rename the default branch to")
+	defaultBranchCmd          = &cobra.Command{ // nolint // needed for cobra
+		Use:   "default-branch",
+		Short: "Manage the default branch of repos in provided list",
+	}
+	defaultBranchRenameCmd = &cobra.Command{ // nolint // needed for cobra
+		Use:   "rename",
+		Short: "Rename the default branch of repos in provided list",
+		RunE:  defaultBranchRenameRun,
+	}
+)
+
+func defaultBranchRenameRun(cmd *cobra.Command, args []string) error {
+	err := defaultBranchRenameCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+			getter: &repositoryGetterService{},
+		},
+		&githubRepositorySender{
+			sender: &repositorySenderService{},
+		},
+	)
+
+	return err
+}
+
+func defaultBranchRenameCommand(
+	cmd *cobra.Command,
+	repo *repository,
+	repoSender *githubRepositorySender,
+) error {
+	dryRun, reposFilePath, err := branchProtectionFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	newName, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if newName == "" {
+		return errDefaultBranchEmptyName
+	}
+
+	repositoryList, err := repo.reader.read(reposFilePath)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	log.SetFlags(0)
+
+	if dryRun {
+		log.Printf("This is a dry run, the run would process %d repositories", len(repositoryList))
+	}
+
+	ctx := context.Background()
+
+	callLimit := 100
+	for left := 0; left < len(repositoryList); left += callLimit {
+		right := left + callLimit
+		if right > len(repositoryList) {
+			right = len(repositoryList)
+		}
+
+		repositories, err := repo.getter.get(repositoryList[left:right], repoSender)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		renamed, info, problems := defaultBranchRename(ctx, repositories, newName, dryRun)
+
+		defaultBranchRenameDisplayInfo(renamed, info, problems, dryRun, fmt.Sprintf("Batch %d-%d", left, right))
+	}
+
+	return nil
+}
+
+// defaultBranchRename renames the default branch of each repository to newName. GitHub retargets open pull
+// requests and branch protection rules whose pattern is the old name itself. In dry run nothing is changed.
+func defaultBranchRename(
+	ctx context.Context,
+	repositories map[string]*RepositoriesNode,
+	newName string,
+	dryRun bool,
+) (
+	renamed,
+	info,
+	problems []string,
+) {
+	for _, repository := range repositories {
+		oldName := repository.DefaultBranchRef.Name
+
+		if oldName == "" {
+			info = append(info, fmt.Sprintf("No default branch for %v", repository.NameWithOwner))
+
+			continue
+		}
+
+		if oldName == newName {
+			info = append(info, fmt.Sprintf("Default branch already %s for %v", newName, repository.NameWithOwner))
+
+			continue
+		}
+
+		pullRequests, err := defaultBranchOpenPullRequests(ctx, repository.NameWithOwner, oldName)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", repository.NameWithOwner, err))
+
+			continue
+		}
+
+		if !dryRun {
+			if err := defaultBranchRenameBranch(ctx, repository.NameWithOwner, oldName, newName); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", repository.NameWithOwner, err))
+
+				continue
+			}
+		}
+
+		renamed = append(
+			renamed,
+			fmt.Sprintf(
+				"Default branch for %v from %s to %s (open pull requests retargeted: %d)",
+				repository.NameWithOwner,
+				oldName,
+				newName,
+				pullRequests,
+			),
+		)
+	}
+
+	return renamed, info, problems
+}
+
+// defaultBranchOpenPullRequests counts the open pull requests with the branch as their base.
+func defaultBranchOpenPullRequests(ctx context.Context, nameWithOwner, branch string) (int, error) {
+	total := 0
+
+	for page := 1; ; page++ {
+		client := restclient.NewClient(
+			fmt.Sprintf(
+				"/repos/%s/pulls?state=open&base=%s&per_page=100&page=%d",
+				nameWithOwner,
+				url.QueryEscape(branch),
+				page,
+			),
+			config.Token,
+			http.MethodGet,
+		)
+
+		var response []struct {
+			Number int `json:"number"`
+		}
+		if err := client.Run(ctx, &response); err != nil {
+			return total, fmt.Errorf("list pull requests: %w", err)
+		}
+
+		total += len(response)
+
+		if len(response) < IterationCount {
+			return total, nil
+		}
+	}
+}
+
+func defaultBranchRenameBranch(ctx context.Context, nameWithOwner, oldName, newName string) error {
+	client := restclient.NewClient(
+		fmt.Sprintf("/repos/%s/branches/%s/rename", nameWithOwner, url.PathEscape(oldName)),
+		config.Token,
+		http.MethodPost,
+	)
+
+	if err := client.SetBody(map[string]string{"new_name": newName}); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	var response interface{}
+	if err := client.Run(ctx, &response); err != nil {
+		return fmt.Errorf("rename branch: %w", err)
+	}
+
+	return nil
+}
+
+func defaultBranchRenameDisplayInfo(renamed, info, problems []string, dryRun bool, batchInfo string) {
+	renamedLabel := "Renamed"
+	if dryRun {
+		renamedLabel = "Would rename"
+	}
+
+	for _, repo := range renamed {
+		log.Printf("%s (%s): %v", renamedLabel, batchInfo, repo)
+	}
+
+	for _, i := range info {
+		log.Printf("Info (%s): %v", batchInfo, i)
+	}
+
+	for _, err := range problems {
+		log.Printf("Error (%s): %v", batchInfo, err)
+	}
+}
+
+// nolint // needed for cobra
+func init() {
+	defaultBranchRenameCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	defaultBranchRenameCmd.Flags().StringP("to", "t", "main", "name to rename the default branch to")
+	defaultBranchRenameCmd.MarkFlagRequired("repos")
+	defaultBranchRenameCmd.Flags().SortFlags = false
+	defaultBranchCmd.AddCommand(defaultBranchRenameCmd)
+	rootCmd.AddCommand(defaultBranchCmd)
+}
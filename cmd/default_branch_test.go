@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_defaultBranchRename(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mockHTTPResponder(
+		"GET",
+		"https://api.github.com/repos/org/some-repo-name/pulls?state=open&base=master&per_page=100&page=1",
+		"testdata/mockListPullRequestsResponse.json",
+		200,
+	)
+	mockHTTPResponder(
+		"POST",
+		"https://api.github.com/repos/org/some-repo-name/branches/master/rename",
+		"testdata/mockRenameBranchResponse.json",
+		201,
+	)
+	mockHTTPResponder(
+		"GET",
+		"https://api.github.com/repos/org/pr-list-fails/pulls?state=open&base=master&per_page=100&page=1",
+		"testdata/mockRest404Response.json",
+		404,
+	)
+
+	mockRepository := &RepositoriesNode{
+		ID:            "repoIdTEST",
+		NameWithOwner: "org/some-repo-name",
+		DefaultBranchRef: DefaultBranchRef{
+			Name: "master",
+		},
+	}
+
+	type args struct {
+		repositories map[string]*RepositoriesNode
+		dryRun       bool
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantRenamed  []string
+		wantInfo     []string
+		wantProblems []string
+	}{
+		{
+			name: "defaultBranchRename skips repo without default branch",
+			args: args{
+				repositories: map[string]*RepositoriesNode{
+					"repo0": {NameWithOwner: "org/empty-repo"},
+				},
+			},
+			wantInfo: []string{"No default branch for org/empty-repo"},
+		},
+		{
+			name: "defaultBranchRename skips repo already compliant",
+			args: args{
+				repositories: map[string]*RepositoriesNode{
+					"repo0": {NameWithOwner: "org/compliant-repo", DefaultBranchRef: DefaultBranchRef{Name: "main"}},
+				},
+			},
+			wantInfo: []string{"Default branch already main for org/compliant-repo"},
+		},
+		{
+			name: "defaultBranchRename fails on pull request list",
+			args: args{
+				repositories: map[string]*RepositoriesNode{
+					"repo0": {NameWithOwner: "org/pr-list-fails", DefaultBranchRef: DefaultBranchRef{Name: "master"}},
+				},
+			},
+			wantProblems: []string{
+				"org/pr-list-fails: list pull requests: not found status, https://api.github.com/repos/org/pr-list-fails/pulls?state=open&base=master&per_page=100&page=1", // nolint // long result
+			},
+		},
+		{
+			name: "defaultBranchRename dry run",
+			args: args{
+				repositories: map[string]*RepositoriesNode{"repo0": mockRepository},
+				dryRun:       true,
+			},
+			wantRenamed: []string{
+				"Default branch for org/some-repo-name from master to main (open pull requests retargeted: 2)", // nolint // long result
+			},
+		},
+		{
+			name: "defaultBranchRename success",
+			args: args{
+				repositories: map[string]*RepositoriesNode{"repo0": mockRepository},
+			},
+			wantRenamed: []string{
+				"Default branch for org/some-repo-name from master to main (open pull requests retargeted: 2)", // nolint // long result
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRenamed, gotInfo, gotProblems := defaultBranchRename(
+				context.Background(),
+				tt.args.repositories,
+				"main",
+				tt.args.dryRun,
+			)
+			if !reflect.DeepEqual(gotRenamed, tt.wantRenamed) {
+				t.Errorf("defaultBranchRename() gotRenamed = %v, want %v", gotRenamed, tt.wantRenamed)
+			}
+			if !reflect.DeepEqual(gotInfo, tt.wantInfo) {
+				t.Errorf("defaultBranchRename() gotInfo = %v, want %v", gotInfo, tt.wantInfo)
+			}
+			if !reflect.DeepEqual(gotProblems, tt.wantProblems) {
+				t.Errorf("defaultBranchRename() gotProblems = %v, want %v", gotProblems, tt.wantProblems)
+			}
+		})
+	}
+
+	if calls := httpmock.GetCallCountInfo()["POST https://api.github.com/repos/org/some-repo-name/branches/master/rename"]; calls != 1 { // nolint // long url
+		t.Errorf("defaultBranchRename() rename calls = %d, want 1", calls)
+	}
+}
+
+func Test_defaultBranchRenameCommand(t *testing.T) {
+	newCmd := func(to string) *cobra.Command {
+		cmd := &cobra.Command{Use: "rename"}
+		cmd.Flags().Bool("dry-run", true, "dry run flag")
+		cmd.Flags().String("repos", "testdata/two_repo_list.txt", "repos file")
+		cmd.Flags().String("to", to, "to flag")
+
+		return cmd
+	}
+
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		repo    *repository
+		wantErr bool
+	}{
+		{
+			name:    "defaultBranchRenameCommand fails on missing flags",
+			cmd:     &cobra.Command{Use: "rename"},
+			wantErr: true,
+		},
+		{
+			name:    "defaultBranchRenameCommand fails on empty branch name",
+			cmd:     newCmd(""),
+			wantErr: true,
+		},
+		{
+			name:    "defaultBranchRenameCommand fails on repo read",
+			cmd:     newCmd("main"),
+			repo:    &repository{reader: &mockRepositoryReader{readFail: true}},
+			wantErr: true,
+		},
+		{
+			name: "defaultBranchRenameCommand fails on repo get",
+			cmd:  newCmd("main"),
+			repo: &repository{
+				reader: &mockRepositoryReader{returnValue: []string{"some-repo-name"}},
+				getter: &mockRepositoryGetter{getFail: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "defaultBranchRenameCommand is success",
+			cmd:  newCmd("main"),
+			repo: &repository{
+				reader: &mockRepositoryReader{returnValue: []string{"some-repo-name"}},
+				getter: &mockRepositoryGetter{
+					returnValue: map[string]*RepositoriesNode{"repo0": {
+						NameWithOwner:    "org/some-repo-name",
+						DefaultBranchRef: DefaultBranchRef{Name: "main"},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := defaultBranchRenameCommand(
+				tt.cmd,
+				tt.repo,
+				&githubRepositorySender{sender: &mockRepositorySender{}},
+			); (err != nil) != tt.wantErr {
+				t.Errorf("defaultBranchRenameCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -60,61 +60,80 @@ func dependabotCommand(cmd *cobra.Command, repo *repository) error {
 
 	ctx := context.Background()
 
+	var alertsCount, securityUpdatesCount changeCount
+
 	for _, repositoryName := range repositoryList {
 		if isAlertsFlagSet {
-			if err := dependabotToggleAlerts(ctx, repositoryName, dependabotHTTPMethod(alertsFlag)); err != nil {
+			changed, err := dependabotToggleAlerts(ctx, repositoryName, alertsFlag)
+			if err != nil {
 				return fmt.Errorf("%w", err)
 			}
 
+			alertsCount.add(changed)
+
 			// If alerts being turned off, this turns off security updates so we can continue onto next iteration here
 			if !alertsFlag {
 				continue
 			}
 		}
 
-		if err := dependabotProcessSecurityUpdates(
-			ctx,
-			isSecurityUpdatesFlagSet,
-			securityUpdatesFlag,
-			repositoryName,
-		); err != nil {
-			return fmt.Errorf("%w", err)
+		if isSecurityUpdatesFlagSet {
+			changed, err := dependabotToggleSecurityUpdates(ctx, repositoryName, securityUpdatesFlag)
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
+
+			securityUpdatesCount.add(changed)
 		}
 	}
 
-	return nil
-}
+	if isAlertsFlagSet {
+		log.Printf("Dependabot alerts: %d changed, %d unchanged", alertsCount.changed, alertsCount.unchanged)
+	}
 
-func dependabotProcessSecurityUpdates(
-	ctx context.Context,
-	isSecurityUpdatesFlagSet,
-	securityUpdatesFlag bool,
-	repositoryName string,
-) error {
 	if isSecurityUpdatesFlagSet {
-		if err := dependabotToggleSecurityUpdates(
-			ctx,
-			repositoryName,
-			dependabotHTTPMethod(securityUpdatesFlag),
-		); err != nil {
-			return fmt.Errorf("%w", err)
-		}
+		log.Printf(
+			"Dependabot security updates: %d changed, %d unchanged",
+			securityUpdatesCount.changed,
+			securityUpdatesCount.unchanged,
+		)
 	}
 
 	return nil
 }
 
-func dependabotToggleAlerts(ctx context.Context, repositoryName, method string) error {
-	client := restclient.NewClient(
-		fmt.Sprintf("/repos/%s/%s/vulnerability-alerts", config.Org, repositoryName),
-		config.Token,
-		method,
-	)
+// dependabotToggleAlerts sets dependabot alerts on the repository when they are not already in that state,
+// it returns whether the setting was changed.
+func dependabotToggleAlerts(ctx context.Context, repositoryName string, enable bool) (bool, error) {
+	path := fmt.Sprintf("/repos/%s/%s/vulnerability-alerts", config.Org, repositoryName)
+	method := dependabotHTTPMethod(enable)
+
+	// Enabled alerts return a 204 and disabled alerts a 404
+	var current interface{}
+
+	enabled := true
+	if err := restclient.NewClient(path, config.Token, http.MethodGet).Run(ctx, &current); err != nil {
+		if !errors.Is(err, restclient.ErrNotFound) {
+			return false, fmt.Errorf("%w", err)
+		}
+
+		enabled = false
+	}
+
+	if enabled == enable {
+		log.Printf(
+			"Dependabot alerts already '%s' for repo %s",
+			dependabotStatus(method),
+			repositoryName,
+		)
+
+		return false, nil
+	}
 
 	var response interface{}
 
-	if err := client.Run(ctx, response); err != nil {
-		return fmt.Errorf("%w", err)
+	if err := restclient.NewClient(path, config.Token, method).Run(ctx, response); err != nil {
+		return false, fmt.Errorf("%w", err)
 	}
 
 	log.Printf(
@@ -123,20 +142,39 @@ func dependabotToggleAlerts(ctx context.Context, repositoryName, method string)
 		repositoryName,
 	)
 
-	return nil
+	return true, nil
 }
 
-func dependabotToggleSecurityUpdates(ctx context.Context, repositoryName, method string) error {
-	client := restclient.NewClient(
-		fmt.Sprintf("/repos/%s/%s/automated-security-fixes", config.Org, repositoryName),
-		config.Token,
-		method,
-	)
+// dependabotToggleSecurityUpdates sets dependabot security updates on the repository when they are not already
+// in that state, it returns whether the setting was changed.
+func dependabotToggleSecurityUpdates(ctx context.Context, repositoryName string, enable bool) (bool, error) {
+	path := fmt.Sprintf("/repos/%s/%s/automated-security-fixes", config.Org, repositoryName)
+	method := dependabotHTTPMethod(enable)
+
+	// A 404 is returned when security updates have never been enabled
+	var current struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := restclient.NewClient(path, config.Token, http.MethodGet).Run(ctx, &current); err != nil {
+		if !errors.Is(err, restclient.ErrNotFound) {
+			return false, fmt.Errorf("%w", err)
+		}
+	}
+
+	if current.Enabled == enable {
+		log.Printf(
+			"Dependabot security updates already '%s' for repo %s",
+			dependabotStatus(method),
+			repositoryName,
+		)
+
+		return false, nil
+	}
 
 	var response interface{}
 
-	if err := client.Run(ctx, response); err != nil {
-		return fmt.Errorf("%w", err)
+	if err := restclient.NewClient(path, config.Token, method).Run(ctx, response); err != nil {
+		return false, fmt.Errorf("%w", err)
 	}
 
 	log.Printf(
@@ -145,7 +183,7 @@ func dependabotToggleSecurityUpdates(ctx context.Context, repositoryName, method
 		repositoryName,
 	)
 
-	return nil
+	return true, nil
 }
 
 func dependabotGetFlags(cmd *cobra.Command) (
@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	dependabotAlertStateOpen      = "open"
+	dependabotAlertStateDismissed = "dismissed"
+	dependabotAlertCommentLength  = 280
+)
+
+var (
+	errDependabotAlertsGHSA    = errors.New("ghsa must be a GitHub advisory ID, e.g. GHSA-xxxx-xxxx-xxxx")
+	errDependabotAlertsReason  = errors.New("reason must be fix_started, inaccurate, no_bandwidth, not_used or tolerable_risk")
+	errDependabotAlertsComment = errors.New("comment must be 280 characters or fewer")
+	dependabotAlertsCmd        = &cobra.Command{ // nolint // needed for cobra
+		Use:   "dependabot-alerts",
+		Short: "Dismiss and reopen dependabot alerts for an advisory on repos in provided list",
+	}
+	dependabotAlertsDismissCmd = &cobra.Command{ // nolint // needed for cobra
+		Use:   "dismiss",
+		Short: "Dismiss the open alerts for an advisory on repos in provided list",
+		RunE:  dependabotAlertsDismissRun,
+	}
+	dependabotAlertsReopenCmd = &cobra.Command{ // nolint // needed for cobra
+		Use:   "reopen",
+		Short: "Reopen the dismissed alerts for an advisory on repos in provided list",
+		RunE:  dependabotAlertsReopenRun,
+	}
+)
+
+// dependabotAlertsDismissReasons are the reasons GitHub accepts when dismissing an alert.
+var dependabotAlertsDismissReasons = map[string]bool{ // nolint // expected global
+	"fix_started":    true,
+	"inaccurate":     true,
+	"no_bandwidth":   true,
+	"not_used":       true,
+	"tolerable_risk": true,
+}
+
+// dependabotAlertsChange is the state change applied to every alert for the advisory.
+type dependabotAlertsChange struct {
+	ghsa    string
+	reason  string
+	comment string
+	dismiss bool
+}
+
+// fromState is the state of the alerts the change applies to.
+func (c dependabotAlertsChange) fromState() string {
+	if c.dismiss {
+		return dependabotAlertStateOpen
+	}
+
+	return dependabotAlertStateDismissed
+}
+
+func (c dependabotAlertsChange) action() string {
+	if c.dismiss {
+		return "dismiss"
+	}
+
+	return "reopen"
+}
+
+func dependabotAlertsDismissRun(cmd *cobra.Command, args []string) error {
+	err := dependabotAlertsCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+		true,
+	)
+
+	return err
+}
+
+func dependabotAlertsReopenRun(cmd *cobra.Command, args []string) error {
+	err := dependabotAlertsCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+		false,
+	)
+
+	return err
+}
+
+func dependabotAlertsCommand(cmd *cobra.Command, repo *repository, dismiss bool) error {
+	dryRun, reposFilePath, err := branchProtectionFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	change, err := dependabotAlertsFlags(cmd, dismiss)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	repositoryList, err := repo.reader.read(reposFilePath)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	log.SetFlags(0)
+
+	if dryRun {
+		log.Printf(
+			"This is a dry run, the run would %s %s alerts for %s on %d repositories",
+			change.action(),
+			change.fromState(),
+			change.ghsa,
+			len(repositoryList),
+		)
+	}
+
+	ctx := context.Background()
+	total := 0
+
+	for _, repositoryName := range repositoryList {
+		results, err := dependabotAlertsUpdateRepository(ctx, repositoryName, change, dryRun)
+		for _, result := range results {
+			log.Print(result)
+		}
+
+		total += len(results)
+
+		if err != nil {
+			log.Printf("Error (%s): %v", repositoryName, err)
+		}
+	}
+
+	log.Printf("Dependabot alerts for %s to %s: %d", change.ghsa, change.action(), total)
+
+	return nil
+}
+
+// dependabotAlertsUpdateRepository changes the state of every alert for the advisory in the repository, returning
+// a line for each alert it has changed or would change.
+func dependabotAlertsUpdateRepository(
+	ctx context.Context,
+	repositoryName string,
+	change dependabotAlertsChange,
+	dryRun bool,
+) ([]string, error) {
+	var results []string
+
+	alerts, err := dependabotAlertsMatching(ctx, repositoryName, change)
+	if err != nil {
+		return results, err
+	}
+
+	for _, alert := range alerts {
+		description := fmt.Sprintf(
+			"alert #%d (%s %s) in %s",
+			alert.Number,
+			alert.Dependency.Package.Ecosystem,
+			alert.Dependency.Package.Name,
+			repositoryName,
+		)
+
+		if dryRun {
+			results = append(results, fmt.Sprintf("Would %s %s", change.action(), description))
+
+			continue
+		}
+
+		if err := dependabotAlertsSetState(ctx, repositoryName, alert.Number, change); err != nil {
+			return results, err
+		}
+
+		results = append(results, fmt.Sprintf("Successful %s of %s", change.action(), description))
+	}
+
+	return results, nil
+}
+
+// dependabotAlertsMatching returns the alerts for the advisory that are in the state the change applies to.
+func dependabotAlertsMatching(
+	ctx context.Context,
+	repositoryName string,
+	change dependabotAlertsChange,
+) ([]DependabotAlertResponse, error) {
+	var alerts []DependabotAlertResponse
+
+	path := fmt.Sprintf(
+		"/repos/%s/%s/dependabot/alerts?state=%s&per_page=100",
+		config.Org,
+		repositoryName,
+		change.fromState(),
+	)
+
+	for path != "" {
+		client := restclient.NewClient(path, config.Token, http.MethodGet)
+
+		var response []DependabotAlertResponse
+		if err := client.Run(ctx, &response); err != nil {
+			return alerts, fmt.Errorf("list dependabot alerts: %w", err)
+		}
+
+		for _, alert := range response {
+			if strings.EqualFold(alert.SecurityAdvisory.GHSAID, change.ghsa) {
+				alerts = append(alerts, alert)
+			}
+		}
+
+		path = client.NextPath()
+	}
+
+	return alerts, nil
+}
+
+func dependabotAlertsSetState(
+	ctx context.Context,
+	repositoryName string,
+	number int,
+	change dependabotAlertsChange,
+) error {
+	client := restclient.NewClient(
+		fmt.Sprintf("/repos/%s/%s/dependabot/alerts/%d", config.Org, repositoryName, number),
+		config.Token,
+		http.MethodPatch,
+	)
+
+	body := DependabotAlertUpdate{State: dependabotAlertStateOpen}
+	if change.dismiss {
+		body = DependabotAlertUpdate{
+			State:            dependabotAlertStateDismissed,
+			DismissedReason:  change.reason,
+			DismissedComment: change.comment,
+		}
+	}
+
+	if err := client.SetBody(body); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	var response interface{}
+	if err := client.Run(ctx, &response); err != nil {
+		return fmt.Errorf("update dependabot alert #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+// dependabotAlertsFlags returns the change to make, only a dismissal has a reason and comment.
+func dependabotAlertsFlags(cmd *cobra.Command, dismiss bool) (dependabotAlertsChange, error) {
+	change := dependabotAlertsChange{dismiss: dismiss}
+
+	var err error
+
+	change.ghsa, err = cmd.Flags().GetString("ghsa")
+	if err != nil {
+		return change, fmt.Errorf("%w", err)
+	}
+
+	validGHSA := regexp.MustCompile("^(?i)GHSA(-[a-z0-9]{4}){3}$")
+	if !validGHSA.MatchString(change.ghsa) {
+		return change, fmt.Errorf("%w: %s", errDependabotAlertsGHSA, change.ghsa)
+	}
+
+	if !dismiss {
+		return change, nil
+	}
+
+	change.reason, err = cmd.Flags().GetString("reason")
+	if err != nil {
+		return change, fmt.Errorf("%w", err)
+	}
+
+	if !dependabotAlertsDismissReasons[change.reason] {
+		return change, fmt.Errorf("%w: %s", errDependabotAlertsReason, change.reason)
+	}
+
+	change.comment, err = cmd.Flags().GetString("comment")
+	if err != nil {
+		return change, fmt.Errorf("%w", err)
+	}
+
+	if utf8.RuneCountInString(change.comment) > dependabotAlertCommentLength {
+		return change, errDependabotAlertsComment
+	}
+
+	return change, nil
+}
+
+// nolint // needed for cobra
+func init() {
+	dependabotAlertsDismissCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	dependabotAlertsDismissCmd.Flags().StringP("ghsa", "g", "", "GitHub advisory ID of the alerts to dismiss, e.g. GHSA-xxxx-xxxx-xxxx")
+	dependabotAlertsDismissCmd.Flags().String("reason", "", "reason for dismissal: fix_started, inaccurate, no_bandwidth, not_used or tolerable_risk")
+	dependabotAlertsDismissCmd.Flags().String("comment", "", "optional comment recorded with the dismissal, up to 280 characters")
+	dependabotAlertsDismissCmd.MarkFlagRequired("repos")
+	dependabotAlertsDismissCmd.MarkFlagRequired("ghsa")
+	dependabotAlertsDismissCmd.MarkFlagRequired("reason")
+	dependabotAlertsDismissCmd.Flags().SortFlags = false
+
+	dependabotAlertsReopenCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	dependabotAlertsReopenCmd.Flags().StringP("ghsa", "g", "", "GitHub advisory ID of the alerts to reopen, e.g. GHSA-xxxx-xxxx-xxxx")
+	dependabotAlertsReopenCmd.MarkFlagRequired("repos")
+	dependabotAlertsReopenCmd.MarkFlagRequired("ghsa")
+	dependabotAlertsReopenCmd.Flags().SortFlags = false
+
+	dependabotAlertsCmd.AddCommand(dependabotAlertsDismissCmd)
+	dependabotAlertsCmd.AddCommand(dependabotAlertsReopenCmd)
+	rootCmd.AddCommand(dependabotAlertsCmd)
+}
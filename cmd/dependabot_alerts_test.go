@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func dependabotAlertsTestFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", true, "dry run flag")
+	cmd.Flags().String("repos", "testdata/two_repo_list.txt", "repos file")
+	cmd.Flags().String("ghsa", "", "ghsa flag")
+	cmd.Flags().String("reason", "", "reason flag")
+	cmd.Flags().String("comment", "", "comment flag")
+}
+
+func Test_dependabotAlertsFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		dismiss bool
+		want    dependabotAlertsChange
+		wantErr bool
+	}{
+		{
+			name:    "dependabotAlertsFlags fails on missing ghsa flag",
+			cmd:     &cobra.Command{Use: "dismiss"},
+			dismiss: true,
+			wantErr: true,
+		},
+		{
+			name:    "dependabotAlertsFlags fails on invalid ghsa",
+			cmd:     mockFlagsCmd(dependabotAlertsTestFlags, "ghsa", "CVE-2018-6188", "reason", "tolerable_risk"),
+			dismiss: true,
+			wantErr: true,
+		},
+		{
+			name:    "dependabotAlertsFlags fails on invalid reason",
+			cmd:     mockFlagsCmd(dependabotAlertsTestFlags, "ghsa", "GHSA-rf4j-j272-fj86", "reason", "false_positive"),
+			dismiss: true,
+			wantErr: true,
+		},
+		{
+			name: "dependabotAlertsFlags fails on long comment",
+			cmd: mockFlagsCmd(
+				dependabotAlertsTestFlags,
+				"ghsa", "GHSA-rf4j-j272-fj86",
+				"reason", "tolerable_risk",
+				"comment", strings.Repeat("a", 281),
+			),
+			dismiss: true,
+			wantErr: true,
+		},
+		{
+			name: "dependabotAlertsFlags counts comment characters not bytes",
+			cmd: mockFlagsCmd(
+				dependabotAlertsTestFlags,
+				"ghsa", "GHSA-rf4j-j272-fj86",
+				"reason", "tolerable_risk",
+				"comment", strings.Repeat("é", 280),
+			),
+			dismiss: true,
+			want: dependabotAlertsChange{
+				ghsa:    "GHSA-rf4j-j272-fj86",
+				reason:  "tolerable_risk",
+				comment: strings.Repeat("é", 280),
+				dismiss: true,
+			},
+		},
+		{
+			name:    "dependabotAlertsFlags reopen does not need a reason",
+			cmd:     mockFlagsCmd(dependabotAlertsTestFlags, "ghsa", "GHSA-rf4j-j272-fj86"),
+			dismiss: false,
+			want:    dependabotAlertsChange{ghsa: "GHSA-rf4j-j272-fj86"},
+		},
+		{
+			name: "dependabotAlertsFlags dismiss",
+			cmd: mockFlagsCmd(
+				dependabotAlertsTestFlags,
+				"ghsa", "GHSA-rf4j-j272-fj86",
+				"reason", "tolerable_risk",
+				"comment", "not exposed",
+			),
+			dismiss: true,
+			want: dependabotAlertsChange{
+				ghsa:    "GHSA-rf4j-j272-fj86",
+				reason:  "tolerable_risk",
+				comment: "not exposed",
+				dismiss: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dependabotAlertsFlags(tt.cmd, tt.dismiss)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("dependabotAlertsFlags() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dependabotAlertsFlags() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dependabotAlertsUpdateRepository(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	alertsURL := "https://api.github.com/repos/some-org/repo1/dependabot/alerts"
+	dismiss := dependabotAlertsChange{ghsa: "GHSA-rf4j-j272-fj86", reason: "tolerable_risk", dismiss: true}
+
+	tests := []struct {
+		name          string
+		change        dependabotAlertsChange
+		dryRun        bool
+		listURL       string
+		listFile      string
+		listStatus    int
+		patchFile     string
+		patchStatus   int
+		want          []string
+		wantErr       bool
+		wantPatchCall int
+	}{
+		{
+			name:       "dependabotAlertsUpdateRepository fails to list alerts",
+			change:     dismiss,
+			listURL:    alertsURL + "?state=open&per_page=100",
+			listFile:   "testdata/mockRest403Response.json",
+			listStatus: 403,
+			wantErr:    true,
+		},
+		{
+			name:       "dependabotAlertsUpdateRepository no matching alerts",
+			change:     dependabotAlertsChange{ghsa: "GHSA-xxxx-xxxx-xxxx", dismiss: true},
+			listURL:    alertsURL + "?state=open&per_page=100",
+			listFile:   "testdata/mockDependabotAlertsResponse.json",
+			listStatus: 200,
+		},
+		{
+			name:       "dependabotAlertsUpdateRepository dismiss dry run",
+			change:     dismiss,
+			dryRun:     true,
+			listURL:    alertsURL + "?state=open&per_page=100",
+			listFile:   "testdata/mockDependabotAlertsResponse.json",
+			listStatus: 200,
+			want:       []string{"Would dismiss alert #2 (pip django) in repo1"},
+		},
+		{
+			name:          "dependabotAlertsUpdateRepository dismiss fails",
+			change:        dismiss,
+			listURL:       alertsURL + "?state=open&per_page=100",
+			listFile:      "testdata/mockDependabotAlertsResponse.json",
+			listStatus:    200,
+			patchFile:     "testdata/mockRest404Response.json",
+			patchStatus:   404,
+			wantErr:       true,
+			wantPatchCall: 1,
+		},
+		{
+			name:          "dependabotAlertsUpdateRepository dismiss",
+			change:        dismiss,
+			listURL:       alertsURL + "?state=open&per_page=100",
+			listFile:      "testdata/mockDependabotAlertsResponse.json",
+			listStatus:    200,
+			patchFile:     "testdata/mockRest20xEmptyResponse.json",
+			patchStatus:   200,
+			want:          []string{"Successful dismiss of alert #2 (pip django) in repo1"},
+			wantPatchCall: 1,
+		},
+		{
+			name:          "dependabotAlertsUpdateRepository reopen",
+			change:        dependabotAlertsChange{ghsa: "ghsa-rf4j-j272-fj86"},
+			listURL:       alertsURL + "?state=dismissed&per_page=100",
+			listFile:      "testdata/mockDependabotAlertsResponse.json",
+			listStatus:    200,
+			patchFile:     "testdata/mockRest20xEmptyResponse.json",
+			patchStatus:   200,
+			want:          []string{"Successful reopen of alert #2 (pip django) in repo1"},
+			wantPatchCall: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			mockHTTPResponder("GET", tt.listURL, tt.listFile, tt.listStatus)
+
+			if tt.patchFile != "" {
+				mockHTTPResponder("PATCH", alertsURL+"/2", tt.patchFile, tt.patchStatus)
+			}
+
+			got, err := dependabotAlertsUpdateRepository(context.Background(), "repo1", tt.change, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("dependabotAlertsUpdateRepository() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dependabotAlertsUpdateRepository() = %v, want %v", got, tt.want)
+			}
+
+			if calls := httpmock.GetCallCountInfo()["PATCH "+alertsURL+"/2"]; calls != tt.wantPatchCall {
+				t.Errorf("dependabotAlertsUpdateRepository PATCH calls = %d, want %d", calls, tt.wantPatchCall)
+			}
+		})
+	}
+}
+
+func Test_dependabotAlertsCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		repo    *repository
+		wantErr bool
+	}{
+		{
+			name:    "dependabotAlertsCommand fails on missing flags",
+			cmd:     &cobra.Command{Use: "dismiss"},
+			wantErr: true,
+		},
+		{
+			name:    "dependabotAlertsCommand fails on invalid reason",
+			cmd:     mockFlagsCmd(dependabotAlertsTestFlags, "ghsa", "GHSA-rf4j-j272-fj86"),
+			wantErr: true,
+		},
+		{
+			name:    "dependabotAlertsCommand fails on repo read",
+			cmd:     mockFlagsCmd(dependabotAlertsTestFlags, "ghsa", "GHSA-rf4j-j272-fj86", "reason", "tolerable_risk"),
+			repo:    &repository{reader: &mockRepositoryReader{readFail: true}},
+			wantErr: true,
+		},
+		{
+			name:    "dependabotAlertsCommand with no repos",
+			cmd:     mockFlagsCmd(dependabotAlertsTestFlags, "ghsa", "GHSA-rf4j-j272-fj86", "reason", "tolerable_risk"),
+			repo:    &repository{reader: &mockRepositoryReader{}},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := dependabotAlertsCommand(tt.cmd, tt.repo, true); (err != nil) != tt.wantErr {
+				t.Errorf("dependabotAlertsCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
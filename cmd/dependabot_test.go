@@ -339,15 +339,14 @@ func Test_dependabotCommand(t *testing.T) {
 		t.Errorf("setting alerts flag errors with error = %v", err)
 	}
 
+	alertsURL := "/repos/some-org/some-repo/vulnerability-alerts"
+	securityUpdatesURL := "/repos/some-org/some-repo/automated-security-fixes"
+
 	tests := []struct {
-		name                 string
-		args                 args
-		mockHTTPMethod       string
-		mockHTTPURL          string
-		mockHTTPResponseFile string
-		mockHTTPStatusCode   int
-		mockUpdateCall       bool
-		wantErr              bool
+		name          string
+		args          args
+		mockResponses []dependabotMockResponse
+		wantErr       bool
 	}{
 		{
 			name: "dependabotCommand flag check error",
@@ -380,11 +379,11 @@ func Test_dependabotCommand(t *testing.T) {
 					},
 				},
 			},
-			mockHTTPMethod:       "PUT",
-			mockHTTPURL:          "/repos/some-org/some-repo/vulnerability-alerts",
-			mockHTTPResponseFile: "testdata/mockRest20xEmptyResponse.json",
-			mockHTTPStatusCode:   204,
-			wantErr:              false,
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest404Response.json", 404},
+				{"PUT", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+			},
+			wantErr: false,
 		},
 		{
 			name: "dependabotCommand errors with alerts update",
@@ -398,11 +397,11 @@ func Test_dependabotCommand(t *testing.T) {
 					},
 				},
 			},
-			mockHTTPMethod:       "PUT",
-			mockHTTPURL:          "/repos/some-org/some-repo/vulnerability-alerts",
-			mockHTTPResponseFile: "testdata/mockRest404Response.json",
-			mockHTTPStatusCode:   404,
-			wantErr:              true,
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest404Response.json", 404},
+				{"PUT", alertsURL, "testdata/mockRest404Response.json", 404},
+			},
+			wantErr: true,
 		},
 		{
 			name: "dependabotCommand is successful with security updates update",
@@ -416,12 +415,12 @@ func Test_dependabotCommand(t *testing.T) {
 					},
 				},
 			},
-			mockHTTPMethod:       "PUT",
-			mockHTTPURL:          "/repos/some-org/some-repo/automated-security-fixes",
-			mockHTTPResponseFile: "testdata/mockRest20xEmptyResponse.json",
-			mockHTTPStatusCode:   204,
-			mockUpdateCall:       true,
-			wantErr:              false,
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+				{"GET", securityUpdatesURL, "testdata/mockAutomatedSecurityFixesDisabledResponse.json", 200},
+				{"PUT", securityUpdatesURL, "testdata/mockRest20xEmptyResponse.json", 204},
+			},
+			wantErr: false,
 		},
 		{
 			name: "dependabotCommand errors with security updates update",
@@ -435,12 +434,12 @@ func Test_dependabotCommand(t *testing.T) {
 					},
 				},
 			},
-			mockHTTPMethod:       "PUT",
-			mockHTTPURL:          "/repos/some-org/some-repo/automated-security-fixes",
-			mockHTTPResponseFile: "testdata/mockRest404Response.json",
-			mockHTTPStatusCode:   404,
-			mockUpdateCall:       true,
-			wantErr:              true,
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+				{"GET", securityUpdatesURL, "testdata/mockAutomatedSecurityFixesDisabledResponse.json", 200},
+				{"PUT", securityUpdatesURL, "testdata/mockRest404Response.json", 404},
+			},
+			wantErr: true,
 		},
 		{
 			name: "dependabotCommand errors with security updates update with no alerts",
@@ -468,12 +467,13 @@ func Test_dependabotCommand(t *testing.T) {
 					},
 				},
 			},
-			mockHTTPMethod:       "DELETE",
-			mockHTTPURL:          "/repos/some-org/some-repo/automated-security-fixes",
-			mockHTTPResponseFile: "testdata/mockRest20xEmptyResponse.json",
-			mockHTTPStatusCode:   204,
-			mockUpdateCall:       true,
-			wantErr:              false,
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest404Response.json", 404},
+				{"PUT", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+				{"GET", securityUpdatesURL, "testdata/mockAutomatedSecurityFixesEnabledResponse.json", 200},
+				{"DELETE", securityUpdatesURL, "testdata/mockRest20xEmptyResponse.json", 204},
+			},
+			wantErr: false,
 		},
 		{
 			name: "dependabotCommand is successful with alerts off",
@@ -487,31 +487,38 @@ func Test_dependabotCommand(t *testing.T) {
 					},
 				},
 			},
-			mockHTTPMethod:       "DELETE",
-			mockHTTPURL:          "/repos/some-org/some-repo/vulnerability-alerts",
-			mockHTTPResponseFile: "testdata/mockRest20xEmptyResponse.json",
-			mockHTTPStatusCode:   204,
-			wantErr:              false,
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+				{"DELETE", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+			},
+			wantErr: false,
+		},
+		{
+			name: "dependabotCommand is successful with nothing to change",
+			args: args{
+				cmd: mockCmdSecurityUpdatesOn,
+				repo: &repository{
+					reader: &mockRepositoryReader{
+						returnValue: []string{
+							"some-repo",
+						},
+					},
+				},
+			},
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+				{"GET", securityUpdatesURL, "testdata/mockAutomatedSecurityFixesEnabledResponse.json", 200},
+			},
+			wantErr: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.mockHTTPResponseFile != "" {
-				if tt.mockUpdateCall {
-					mockHTTPResponder(
-						"PUT",
-						"/repos/some-org/some-repo/vulnerability-alerts",
-						"testdata/mockRest20xEmptyResponse.json",
-						204,
-					)
-				}
-				mockHTTPResponder(
-					tt.mockHTTPMethod,
-					tt.mockHTTPURL,
-					tt.mockHTTPResponseFile,
-					tt.mockHTTPStatusCode,
-				)
+			httpmock.Reset()
+
+			for _, mockResponse := range tt.mockResponses {
+				mockHTTPResponder(mockResponse.method, mockResponse.url, mockResponse.file, mockResponse.statusCode)
 			}
 
 			if err := dependabotCommand(tt.args.cmd, tt.args.repo); (err != nil) != tt.wantErr {
@@ -522,12 +529,6 @@ func Test_dependabotCommand(t *testing.T) {
 }
 
 func Test_dependabotToggleAlerts(t *testing.T) {
-	type args struct {
-		ctx            context.Context
-		repositoryName string
-		method         string
-	}
-
 	originalConfig := config
 
 	httpmock.Activate()
@@ -541,65 +542,76 @@ func Test_dependabotToggleAlerts(t *testing.T) {
 	config.Org = MockOrgName
 
 	ctx := context.Background()
+	alertsURL := "/repos/some-org/some-repo/vulnerability-alerts"
 
 	tests := []struct {
-		name                 string
-		args                 args
-		mockHTTPMethod       string
-		mockHTTPURL          string
-		mockHTTPResponseFile string
-		mockHTTPStatusCode   int
-		wantErr              bool
+		name          string
+		enable        bool
+		mockResponses []dependabotMockResponse
+		want          bool
+		wantErr       bool
 	}{
+		{
+			name: "dependabotToggleAlerts errors getting current state",
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest401Response.json", 401},
+			},
+			wantErr: true,
+		},
 		{
 			name: "dependabotToggleAlerts errors with delete method",
-			args: args{
-				ctx:            ctx,
-				repositoryName: "some-repo",
-				method:         "DELETE",
-			},
-			mockHTTPMethod:       "DELETE",
-			mockHTTPURL:          "/repos/some-org/some-repo/vulnerability-alerts",
-			mockHTTPResponseFile: "testdata/mockRest404Response.json",
-			mockHTTPStatusCode:   404,
-			wantErr:              true,
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+				{"DELETE", alertsURL, "testdata/mockRest404Response.json", 404},
+			},
+			wantErr: true,
 		},
 		{
 			name: "dependabotToggleAlerts is successful",
-			args: args{
-				ctx:            ctx,
-				repositoryName: "some-repo",
-				method:         "DELETE",
-			},
-			mockHTTPURL:          "/repos/some-org/some-repo/vulnerability-alerts",
-			mockHTTPResponseFile: "testdata/mockRest20xEmptyResponse.json",
-			mockHTTPStatusCode:   204,
-			wantErr:              false,
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+				{"DELETE", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+			},
+			want: true,
+		},
+		{
+			name:   "dependabotToggleAlerts already enabled",
+			enable: true,
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest20xEmptyResponse.json", 204},
+			},
+			want: false,
+		},
+		{
+			name: "dependabotToggleAlerts already disabled",
+			mockResponses: []dependabotMockResponse{
+				{"GET", alertsURL, "testdata/mockRest404Response.json", 404},
+			},
+			want: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockHTTPResponder(
-				tt.args.method,
-				tt.mockHTTPURL,
-				tt.mockHTTPResponseFile,
-				tt.mockHTTPStatusCode,
-			)
-			if err := dependabotToggleAlerts(tt.args.ctx, tt.args.repositoryName, tt.args.method); (err != nil) != tt.wantErr {
+			httpmock.Reset()
+
+			for _, mockResponse := range tt.mockResponses {
+				mockHTTPResponder(mockResponse.method, mockResponse.url, mockResponse.file, mockResponse.statusCode)
+			}
+
+			got, err := dependabotToggleAlerts(ctx, "some-repo", tt.enable)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("dependabotToggleAlerts() error = %v, wantErr %v", err, tt.wantErr)
 			}
+
+			if got != tt.want {
+				t.Errorf("dependabotToggleAlerts() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
 
 func Test_dependabotToggleSecurityUpdates(t *testing.T) {
-	type args struct {
-		ctx            context.Context
-		repositoryName string
-		method         string
-	}
-
 	originalConfig := config
 
 	httpmock.Activate()
@@ -613,55 +625,73 @@ func Test_dependabotToggleSecurityUpdates(t *testing.T) {
 	config.Org = MockOrgName
 
 	ctx := context.Background()
+	securityUpdatesURL := "/repos/some-org/some-repo/automated-security-fixes"
 
 	tests := []struct {
-		name                 string
-		args                 args
-		mockHTTPURL          string
-		mockHTTPResponseFile string
-		mockHTTPStatusCode   int
-		wantErr              bool
+		name          string
+		enable        bool
+		mockResponses []dependabotMockResponse
+		want          bool
+		wantErr       bool
 	}{
+		{
+			name: "dependabotToggleSecurityUpdates errors getting current state",
+			mockResponses: []dependabotMockResponse{
+				{"GET", securityUpdatesURL, "testdata/mockRest401Response.json", 401},
+			},
+			wantErr: true,
+		},
 		{
 			name: "dependabotToggleSecurityUpdates errors with delete method",
-			args: args{
-				ctx:            ctx,
-				repositoryName: "some-repo",
-				method:         "DELETE",
-			},
-			mockHTTPURL:          "/repos/some-org/some-repo/automated-security-fixes",
-			mockHTTPResponseFile: "testdata/mockRest404Response.json",
-			mockHTTPStatusCode:   404,
-			wantErr:              true,
+			mockResponses: []dependabotMockResponse{
+				{"GET", securityUpdatesURL, "testdata/mockAutomatedSecurityFixesEnabledResponse.json", 200},
+				{"DELETE", securityUpdatesURL, "testdata/mockRest404Response.json", 404},
+			},
+			wantErr: true,
 		},
 		{
 			name: "dependabotToggleSecurityUpdates is successful",
-			args: args{
-				ctx:            ctx,
-				repositoryName: "some-repo",
-				method:         "DELETE",
-			},
-			mockHTTPURL:          "/repos/some-org/some-repo/automated-security-fixes",
-			mockHTTPResponseFile: "testdata/mockRest20xEmptyResponse.json",
-			mockHTTPStatusCode:   204,
-			wantErr:              false,
+			mockResponses: []dependabotMockResponse{
+				{"GET", securityUpdatesURL, "testdata/mockAutomatedSecurityFixesEnabledResponse.json", 200},
+				{"DELETE", securityUpdatesURL, "testdata/mockRest20xEmptyResponse.json", 204},
+			},
+			want: true,
+		},
+		{
+			name:   "dependabotToggleSecurityUpdates enables when never set",
+			enable: true,
+			mockResponses: []dependabotMockResponse{
+				{"GET", securityUpdatesURL, "testdata/mockRest404Response.json", 404},
+				{"PUT", securityUpdatesURL, "testdata/mockRest20xEmptyResponse.json", 204},
+			},
+			want: true,
+		},
+		{
+			name:   "dependabotToggleSecurityUpdates already enabled",
+			enable: true,
+			mockResponses: []dependabotMockResponse{
+				{"GET", securityUpdatesURL, "testdata/mockAutomatedSecurityFixesEnabledResponse.json", 200},
+			},
+			want: false,
 		},
 	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockHTTPResponder(
-				tt.args.method,
-				tt.mockHTTPURL,
-				tt.mockHTTPResponseFile,
-				tt.mockHTTPStatusCode,
-			)
-			if err := dependabotToggleSecurityUpdates(
-				tt.args.ctx,
-				tt.args.repositoryName,
-				tt.args.method,
-			); (err != nil) != tt.wantErr {
+			httpmock.Reset()
+
+			for _, mockResponse := range tt.mockResponses {
+				mockHTTPResponder(mockResponse.method, mockResponse.url, mockResponse.file, mockResponse.statusCode)
+			}
+
+			got, err := dependabotToggleSecurityUpdates(ctx, "some-repo", tt.enable)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("dependabotToggleSecurityUpdates() error = %v, wantErr %v", err, tt.wantErr)
 			}
+
+			if got != tt.want {
+				t.Errorf("dependabotToggleSecurityUpdates() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
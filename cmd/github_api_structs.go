@@ -1,23 +1,167 @@
 package cmd
 
 type BranchProtectionRulesNode struct {
-	ID                           string `json:"id"`
-	IsAdminEnforced              bool   `json:"isAdminEnforced"`
-	RequiresCommitSignatures     bool   `json:"requiresCommitSignatures"`
-	RestrictsPushes              bool   `json:"restrictsPushes"`
-	RequiresApprovingReviews     bool   `json:"requiresApprovingReviews"`
-	RequiresStatusChecks         bool   `json:"requiresStatusChecks"`
-	RequiresCodeOwnerReviews     bool   `json:"requiresCodeOwnerReviews"`
-	DismissesStaleReviews        bool   `json:"dismissesStaleReviews"`
-	RequiresStrictStatusChecks   bool   `json:"requiresStrictStatusChecks"`
-	RequiredApprovingReviewCount int    `json:"requiredApprovingReviewCount"`
-	AllowsForcePushes            bool   `json:"allowsForcePushes"`
-	AllowsDeletions              bool   `json:"allowsDeletions"`
-	Pattern                      string `json:"pattern"`
+	ID                             string                `json:"id"`
+	IsAdminEnforced                bool                  `json:"isAdminEnforced"`
+	RequiresCommitSignatures       bool                  `json:"requiresCommitSignatures"`
+	RestrictsPushes                bool                  `json:"restrictsPushes"`
+	RequiresApprovingReviews       bool                  `json:"requiresApprovingReviews"`
+	RequiresStatusChecks           bool                  `json:"requiresStatusChecks"`
+	RequiresCodeOwnerReviews       bool                  `json:"requiresCodeOwnerReviews"`
+	DismissesStaleReviews          bool                  `json:"dismissesStaleReviews"`
+	RequiresStrictStatusChecks     bool                  `json:"requiresStrictStatusChecks"`
+	RequiredApprovingReviewCount   int                   `json:"requiredApprovingReviewCount"`
+	RequireLastPushApproval        bool                  `json:"requireLastPushApproval"`
+	AllowsForcePushes              bool                  `json:"allowsForcePushes"`
+	AllowsDeletions                bool                  `json:"allowsDeletions"`
+	BlocksCreations                bool                  `json:"blocksCreations"`
+	LockAllowsFetchAndMerge        bool                  `json:"lockAllowsFetchAndMerge"`
+	LockBranch                     bool                  `json:"lockBranch"`
+	RequiresConversationResolution bool                  `json:"requiresConversationResolution"`
+	RequiresDeployments            bool                  `json:"requiresDeployments"`
+	RequiresLinearHistory          bool                  `json:"requiresLinearHistory"`
+	RestrictsReviewDismissals      bool                  `json:"restrictsReviewDismissals"`
+	RequiredDeploymentEnvironments []string              `json:"requiredDeploymentEnvironments"`
+	RequiredStatusChecks           []RequiredStatusCheck `json:"requiredStatusChecks"`
+	Pattern                        string                `json:"pattern"`
+
+	MatchingRefs                MatchingRefs               `json:"matchingRefs"`
+	PushAllowances              BranchProtectionAllowances `json:"pushAllowances"`
+	BypassForcePushAllowances   BranchProtectionAllowances `json:"bypassForcePushAllowances"`
+	BypassPullRequestAllowances BranchProtectionAllowances `json:"bypassPullRequestAllowances"`
+	ReviewDismissalAllowances   BranchProtectionAllowances `json:"reviewDismissalAllowances"`
+}
+
+type BranchProtectionCopyResponse struct {
+	Repository struct {
+		BranchProtectionRules struct {
+			PageInfo PageInfo                   `json:"pageInfo"`
+			Nodes    []BranchProtectionCopyRule `json:"nodes"`
+		} `json:"branchProtectionRules"`
+	} `json:"repository"`
+}
+
+type BranchProtectionCopyRule struct {
+	Pattern                        string                     `json:"pattern"`
+	AllowsDeletions                bool                       `json:"allowsDeletions"`
+	AllowsForcePushes              bool                       `json:"allowsForcePushes"`
+	BlocksCreations                bool                       `json:"blocksCreations"`
+	DismissesStaleReviews          bool                       `json:"dismissesStaleReviews"`
+	IsAdminEnforced                bool                       `json:"isAdminEnforced"`
+	LockAllowsFetchAndMerge        bool                       `json:"lockAllowsFetchAndMerge"`
+	LockBranch                     bool                       `json:"lockBranch"`
+	RequireLastPushApproval        bool                       `json:"requireLastPushApproval"`
+	RequiredApprovingReviewCount   int                        `json:"requiredApprovingReviewCount"`
+	RequiredDeploymentEnvironments []string                   `json:"requiredDeploymentEnvironments"`
+	RequiredStatusChecks           []RequiredStatusCheck      `json:"requiredStatusChecks"`
+	RequiresApprovingReviews       bool                       `json:"requiresApprovingReviews"`
+	RequiresCodeOwnerReviews       bool                       `json:"requiresCodeOwnerReviews"`
+	RequiresCommitSignatures       bool                       `json:"requiresCommitSignatures"`
+	RequiresConversationResolution bool                       `json:"requiresConversationResolution"`
+	RequiresDeployments            bool                       `json:"requiresDeployments"`
+	RequiresLinearHistory          bool                       `json:"requiresLinearHistory"`
+	RequiresStatusChecks           bool                       `json:"requiresStatusChecks"`
+	RequiresStrictStatusChecks     bool                       `json:"requiresStrictStatusChecks"`
+	RestrictsPushes                bool                       `json:"restrictsPushes"`
+	RestrictsReviewDismissals      bool                       `json:"restrictsReviewDismissals"`
+	PushAllowances                 BranchProtectionAllowances `json:"pushAllowances"`
+	BypassForcePushAllowances      BranchProtectionAllowances `json:"bypassForcePushAllowances"`
+	BypassPullRequestAllowances    BranchProtectionAllowances `json:"bypassPullRequestAllowances"`
+	ReviewDismissalAllowances      BranchProtectionAllowances `json:"reviewDismissalAllowances"`
+}
+
+type RequiredStatusCheck struct {
+	Context string `json:"context"`
+	App     *struct {
+		ID string `json:"id"`
+	} `json:"app"`
+}
+
+type MatchingRefs struct {
+	TotalCount int `json:"totalCount"`
+}
+
+type BranchProtectionAllowances struct {
+	TotalCount int                              `json:"totalCount"`
+	Nodes      []BranchProtectionAllowancesNode `json:"nodes"`
+}
+
+type BranchProtectionAllowancesNode struct {
+	Actor BranchProtectionActor `json:"actor"`
+}
+
+type BranchProtectionActor struct {
+	Type  string `json:"__typename"`
+	ID    string `json:"id"`
+	Slug  string `json:"slug,omitempty"`
+	Login string `json:"login,omitempty"`
+}
+
+type ActorLookupNode struct {
+	ID   string `json:"id"`
+	Team *struct {
+		ID string `json:"id"`
+	} `json:"team"`
 }
 
 type BranchProtectionRules struct {
-	Nodes []BranchProtectionRulesNode `json:"nodes"`
+	PageInfo PageInfo                    `json:"pageInfo"`
+	Nodes    []BranchProtectionRulesNode `json:"nodes"`
+}
+
+type Rulesets struct {
+	TotalCount int            `json:"totalCount"`
+	PageInfo   PageInfo       `json:"pageInfo"`
+	Nodes      []RulesetsNode `json:"nodes"`
+}
+
+type RulesetsNode struct {
+	ID           string              `json:"id"`
+	DatabaseID   int                 `json:"databaseId"`
+	Name         string              `json:"name"`
+	Target       string              `json:"target"`
+	Enforcement  string              `json:"enforcement"`
+	Source       RulesetSource       `json:"source"`
+	Conditions   RulesetConditions   `json:"conditions"`
+	Rules        RulesetRules        `json:"rules"`
+	BypassActors RulesetBypassActors `json:"bypassActors"`
+}
+
+type RulesetSource struct {
+	Type          string `json:"__typename"`
+	Login         string `json:"login,omitempty"`
+	NameWithOwner string `json:"nameWithOwner,omitempty"`
+}
+
+type RulesetConditions struct {
+	RefName        *RulesetConditionPatterns `json:"refName"`
+	RepositoryName *RulesetConditionPatterns `json:"repositoryName"`
+}
+
+type RulesetConditionPatterns struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+type RulesetRules struct {
+	TotalCount int                `json:"totalCount"`
+	Nodes      []RulesetRulesNode `json:"nodes"`
+}
+
+type RulesetRulesNode struct {
+	Type string `json:"type"`
+}
+
+type RulesetBypassActors struct {
+	TotalCount int                       `json:"totalCount"`
+	Nodes      []RulesetBypassActorsNode `json:"nodes"`
+}
+
+type RulesetBypassActorsNode struct {
+	Actor              *BranchProtectionActor `json:"actor"`
+	BypassMode         string                 `json:"bypassMode"`
+	OrganizationAdmin  bool                   `json:"organizationAdmin"`
+	RepositoryRoleName string                 `json:"repositoryRoleName"`
 }
 
 type Parent struct {
@@ -43,8 +187,9 @@ type RepositoriesNode struct {
 	NameWithOwner         string                `json:"nameWithOwner"`
 	RebaseMergeAllowed    bool                  `json:"rebaseMergeAllowed"`
 	SquashMergeAllowed    bool                  `json:"squashMergeAllowed"`
-	TeamPermissions       string                `json:"teamPermissions"`
+	TeamPermissions       map[string]string     `json:"teamPermissions"`
 	BranchProtectionRules BranchProtectionRules `json:"branchProtectionRules"`
+	Rulesets              Rulesets              `json:"rulesets"`
 	Parent                Parent
 	DefaultBranchRef      DefaultBranchRef
 }
@@ -76,11 +221,19 @@ type OrganizationTeamsResponse struct {
 	OrganizationTeams OrganizationTeams `json:"organization"`
 }
 
+type OrganizationTeamResponse struct {
+	Organization struct {
+		Team *TeamNodes `json:"team"`
+	} `json:"organization"`
+}
+
 type Teams struct {
+	PageInfo  PageInfo    `json:"pageInfo"`
 	TeamNodes []TeamNodes `json:"nodes"`
 }
 
 type TeamNodes struct {
+	Slug             string           `json:"slug"`
 	TeamRepositories TeamRepositories `json:"repositories"`
 }
 
@@ -99,6 +252,16 @@ type RepositoriesEdgeNode struct {
 	Name string `json:"name"`
 }
 
+type RulesetDefinition struct {
+	ID           int           `json:"id,omitempty"`
+	Name         string        `json:"name"`
+	Target       string        `json:"target,omitempty"`
+	Enforcement  string        `json:"enforcement"`
+	Conditions   interface{}   `json:"conditions,omitempty"`
+	Rules        []interface{} `json:"rules"`
+	BypassActors []interface{} `json:"bypass_actors"` // nolint // this is from github
+}
+
 type WebhookRepositoryResponse struct {
 	Organization struct {
 		Repositories struct {
@@ -115,19 +278,299 @@ type WebhookRepositoryResponse struct {
 	} `json:"organization"`
 }
 
+type ArchiveStaleResponse struct {
+	Organization struct {
+		Repositories struct {
+			PageInfo   PageInfo `json:"pageInfo"`
+			TotalCount int      `json:"totalCount"`
+			Nodes      []struct {
+				Name         string `json:"name"`
+				CreatedAt    string `json:"createdAt"`
+				IsArchived   bool   `json:"isArchived"`
+				PushedAt     string `json:"pushedAt"`
+				PullRequests struct {
+					TotalCount int `json:"totalCount"`
+				} `json:"pullRequests"`
+			} `json:"nodes"`
+		} `json:"repositories"`
+	} `json:"organization"`
+}
+
 type WebhookResponse struct {
-	Config WebhookResponseConfig `json:"config"`
-	Active bool                  `json:"active"`
-	ID     int                   `json:"id"`
-	Events []string              `json:"events"`
+	Config     WebhookResponseConfig  `json:"config"`
+	Active     bool                   `json:"active"`
+	ID         int                    `json:"id"`
+	Events     []string               `json:"events"`
+	Deliveries *WebhookDeliveryHealth `json:"deliveries,omitempty"`
+}
+
+type WebhookCreate struct {
+	Name   string              `json:"name"`
+	Active bool                `json:"active"`
+	Events []string            `json:"events"`
+	Config WebhookConfigUpdate `json:"config"`
+}
+
+type WebhookConfigUpdate struct {
+	URL         string `json:"url,omitempty"`
+	ContentType string `json:"content_type,omitempty"` // nolint // this is from github
+	Secret      string `json:"secret,omitempty"`
+	InsecureSSL string `json:"insecure_ssl,omitempty"` // nolint // this is from github
+}
+
+type WebhookUpdate struct {
+	Active *bool    `json:"active,omitempty"`
+	Events []string `json:"events,omitempty"`
+}
+
+// WebhookDeliveryHealth summarises the recent deliveries of a webhook, it is not returned by GitHub.
+type WebhookDeliveryHealth struct {
+	Deliveries     int     `json:"deliveries"`
+	LastStatusCode int     `json:"lastStatusCode"`
+	LastSuccessAt  string  `json:"lastSuccessAt"`
+	FailureRate    float64 `json:"failureRate"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID          int    `json:"id"`
+	DeliveredAt string `json:"delivered_at"` // nolint // this is from github
+	Status      string `json:"status"`
+	StatusCode  int    `json:"status_code"` // nolint // this is from github
+	Event       string `json:"event"`
 }
 
 type WebhookResponseConfig struct {
-	URL         string `json:"url"`
-	InsecureURL int    `json:"insecure_url"` // nolint // this is from github
+	URL         string             `json:"url"`
+	InsecureURL int                `json:"insecure_url"`           // nolint // this is from github
+	InsecureSSL WebhookInsecureSSL `json:"insecure_ssl,omitempty"` // nolint // this is from github
+	ContentType string             `json:"content_type,omitempty"` // nolint // this is from github
+	Secret      string             `json:"secret,omitempty"`
+}
+
+// WebhookInsecureSSL is "1" when SSL verification is disabled, GitHub can return it as a string or a number.
+type WebhookInsecureSSL string
+
+type WebhookFinding struct {
+	RepositoryName string `json:"repositoryName"`
+	Scope          string `json:"scope"`
+	WebhookID      int    `json:"webhookId"`
+	URL            string `json:"url"`
+	Finding        string `json:"finding"`
 }
 
 type Webhooks struct {
 	RepositoryName string
+	Scope          string
 	Webhooks       []WebhookResponse
 }
+
+type CollaboratorResponse struct {
+	Login    string `json:"login"`
+	RoleName string `json:"role_name"` // nolint // this is from github
+}
+
+type InvitationResponse struct {
+	Invitee struct {
+		Login string `json:"login"`
+	} `json:"invitee"`
+	Permissions string `json:"permissions"`
+}
+
+type Collaborator struct {
+	Login      string `json:"login"`
+	Type       string `json:"type"`
+	Permission string `json:"permission"`
+}
+
+type Collaborators struct {
+	RepositoryName string
+	Collaborators  []Collaborator
+}
+
+type MembersResponse struct {
+	Organization struct {
+		MembersWithRole struct {
+			TotalCount int      `json:"totalCount"`
+			PageInfo   PageInfo `json:"pageInfo"`
+			Edges      []struct {
+				Role                string `json:"role"`
+				HasTwoFactorEnabled *bool  `json:"hasTwoFactorEnabled"`
+				Node                struct {
+					Login string `json:"login"`
+					Name  string `json:"name"`
+				} `json:"node"`
+			} `json:"edges"`
+		} `json:"membersWithRole"`
+	} `json:"organization"`
+}
+
+type TeamMembersResponse struct {
+	Organization struct {
+		Team *struct {
+			Members struct {
+				PageInfo PageInfo `json:"pageInfo"`
+				Nodes    []struct {
+					Login string `json:"login"`
+				} `json:"nodes"`
+			} `json:"members"`
+		} `json:"team"`
+	} `json:"organization"`
+}
+
+type SAMLIdentitiesResponse struct {
+	Organization struct {
+		SAMLIdentityProvider *struct {
+			ExternalIdentities struct {
+				PageInfo PageInfo `json:"pageInfo"`
+				Nodes    []struct {
+					SAMLIdentity struct {
+						NameID string `json:"nameId"`
+					} `json:"samlIdentity"`
+					User *struct {
+						Login string `json:"login"`
+					} `json:"user"`
+				} `json:"nodes"`
+			} `json:"externalIdentities"`
+		} `json:"samlIdentityProvider"`
+	} `json:"organization"`
+}
+
+type Member struct {
+	Login            string   `json:"login"`
+	Name             string   `json:"name"`
+	Role             string   `json:"role"`
+	TwoFactorEnabled *bool    `json:"twoFactorEnabled"`
+	Teams            []string `json:"teams"`
+	SAMLIdentity     string   `json:"samlIdentity"`
+}
+
+type SecurityRepositoryResponse struct {
+	Organization struct {
+		Repositories struct {
+			PageInfo   PageInfo `json:"pageInfo"`
+			TotalCount int      `json:"totalCount"`
+			Nodes      []struct {
+				Name                          string `json:"name"`
+				IsArchived                    bool   `json:"isArchived"`
+				HasVulnerabilityAlertsEnabled bool   `json:"hasVulnerabilityAlertsEnabled"`
+			} `json:"nodes"`
+		} `json:"repositories"`
+	} `json:"organization"`
+}
+
+type SecurityAndAnalysisStatus struct {
+	Status string `json:"status"`
+}
+
+// SecurityAndAnalysis is the security_and_analysis object of a repository, each feature has an enabled or
+// disabled status and is missing when the feature is not available to the repository.
+type SecurityAndAnalysis struct {
+	AdvancedSecurity             *SecurityAndAnalysisStatus `json:"advanced_security,omitempty"`               // nolint // this is from github
+	DependabotSecurityUpdates    *SecurityAndAnalysisStatus `json:"dependabot_security_updates,omitempty"`     // nolint // this is from github
+	SecretScanning               *SecurityAndAnalysisStatus `json:"secret_scanning,omitempty"`                 // nolint // this is from github
+	SecretScanningPushProtection *SecurityAndAnalysisStatus `json:"secret_scanning_push_protection,omitempty"` // nolint // this is from github
+	SecretScanningValidityChecks *SecurityAndAnalysisStatus `json:"secret_scanning_validity_checks,omitempty"` // nolint // this is from github
+}
+
+type SecurityStatus struct {
+	RepositoryName                string `json:"repositoryName"`
+	VulnerabilityAlerts           string `json:"vulnerabilityAlerts"`
+	AutomatedSecurityFixes        string `json:"automatedSecurityFixes"`
+	SecretScanning                string `json:"secretScanning"`
+	PushProtection                string `json:"pushProtection"`
+	CodeScanningDefaultSetup      string `json:"codeScanningDefaultSetup"`
+	PrivateVulnerabilityReporting string `json:"privateVulnerabilityReporting"`
+	Error                         string `json:"error"`
+}
+
+type DependabotAlertResponse struct {
+	Number           int                       `json:"number"`
+	State            string                    `json:"state"`
+	Dependency       DependabotAlertDependency `json:"dependency"`
+	SecurityAdvisory DependabotAlertAdvisory   `json:"security_advisory"` // nolint // this is from github
+	HTMLURL          string                    `json:"html_url"`          // nolint // this is from github
+	CreatedAt        string                    `json:"created_at"`        // nolint // this is from github
+	Repository       struct {
+		Name string `json:"name"`
+	} `json:"repository"`
+}
+
+type DependabotAlertDependency struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	ManifestPath string `json:"manifest_path"` // nolint // this is from github
+}
+
+type DependabotAlertAdvisory struct {
+	GHSAID   string `json:"ghsa_id"` // nolint // this is from github
+	CVEID    string `json:"cve_id"`  // nolint // this is from github
+	Summary  string `json:"summary"`
+	Severity string `json:"severity"`
+}
+
+type DependabotAlertSummary struct {
+	RepositoryName        string         `json:"repositoryName"`
+	OpenAlerts            int            `json:"openAlerts"`
+	Severities            map[string]int `json:"severities"`
+	Ecosystems            map[string]int `json:"ecosystems"`
+	OldestCriticalAgeDays *int           `json:"oldestCriticalAgeDays"`
+}
+
+type DependabotAlertDetail struct {
+	RepositoryName string `json:"repositoryName"`
+	Number         int    `json:"number"`
+	Severity       string `json:"severity"`
+	Ecosystem      string `json:"ecosystem"`
+	Package        string `json:"package"`
+	ManifestPath   string `json:"manifestPath"`
+	GHSAID         string `json:"ghsaId"`
+	CVEID          string `json:"cveId"`
+	CreatedAt      string `json:"createdAt"`
+	AgeDays        int    `json:"ageDays"`
+	URL            string `json:"url"`
+}
+
+type DependabotAlertUpdate struct {
+	State            string `json:"state"`
+	DismissedReason  string `json:"dismissed_reason,omitempty"`  // nolint // this is from github
+	DismissedComment string `json:"dismissed_comment,omitempty"` // nolint // this is from github
+}
+
+type SecretScanningAlertResponse struct {
+	Number                 int    `json:"number"`
+	State                  string `json:"state"`
+	SecretType             string `json:"secret_type"`              // nolint // this is from github
+	SecretTypeDisplayName  string `json:"secret_type_display_name"` // nolint // this is from github
+	Resolution             string `json:"resolution"`
+	PushProtectionBypassed bool   `json:"push_protection_bypassed"` // nolint // this is from github
+	HTMLURL                string `json:"html_url"`                 // nolint // this is from github
+	CreatedAt              string `json:"created_at"`               // nolint // this is from github
+	Repository             struct {
+		Name string `json:"name"`
+	} `json:"repository"`
+}
+
+type SecretScanningAlert struct {
+	RepositoryName         string `json:"repositoryName"`
+	Number                 int    `json:"number"`
+	SecretType             string `json:"secretType"`
+	SecretTypeDisplayName  string `json:"secretTypeDisplayName"`
+	CreatedAt              string `json:"createdAt"`
+	PushProtectionBypassed bool   `json:"pushProtectionBypassed"`
+	Resolution             string `json:"resolution"`
+	URL                    string `json:"url"`
+}
+
+type CodeScanningDefaultSetup struct {
+	State      string   `json:"state"`
+	QuerySuite string   `json:"query_suite"` // nolint // this is from github
+	Languages  []string `json:"languages"`
+}
+
+type CodeScanningDefaultSetupUpdate struct {
+	State      string   `json:"state"`
+	QuerySuite string   `json:"query_suite,omitempty"` // nolint // this is from github
+	Languages  []string `json:"languages,omitempty"`
+}
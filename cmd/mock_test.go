@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
 )
 
 var (
@@ -30,7 +31,7 @@ var (
 			"Merge Commit Allowed",
 			"Squash Merge Allowed",
 			"Rebase Merge Allowed",
-			"Team Permissions",
+			"Rulesets",
 			"(BP1) IsAdminEnforced",
 			"(BP1) RequiresCommitSignatures",
 			"(BP1) RestrictsPushes",
@@ -110,7 +111,7 @@ func (m *mockReportJSON) uploader(filePath string, reportJSON []byte) error {
 func (m *mockReportJSON) generate(
 	ignoreArchived bool,
 	allResults []ReportResponse,
-	teamAccess map[string]string,
+	teamAccess map[string]map[string]string,
 ) ([]byte, error) {
 	if m.failgenerate {
 		return nil, errTestFail
@@ -127,12 +128,68 @@ func (m *mockReportJSON) generateWebhook([]Webhooks) ([]byte, error) {
 	return nil, nil
 }
 
+func (m *mockReportJSON) generateCollaborators([]Collaborators) ([]byte, error) {
+	if m.failgenerate {
+		return nil, errTestFail
+	}
+
+	return nil, nil
+}
+
+func (m *mockReportJSON) generateMembers([]Member) ([]byte, error) {
+	if m.failgenerate {
+		return nil, errTestFail
+	}
+
+	return nil, nil
+}
+
+func (m *mockReportJSON) generateSecurity([]SecurityStatus) ([]byte, error) {
+	if m.failgenerate {
+		return nil, errTestFail
+	}
+
+	return nil, nil
+}
+
+func (m *mockReportJSON) generateDependabotAlerts([]DependabotAlertSummary) ([]byte, error) {
+	if m.failgenerate {
+		return nil, errTestFail
+	}
+
+	return nil, nil
+}
+
+func (m *mockReportJSON) generateDependabotAlertDetails([]DependabotAlertDetail) ([]byte, error) {
+	if m.failgenerate {
+		return nil, errTestFail
+	}
+
+	return nil, nil
+}
+
+func (m *mockReportJSON) generateSecretAlerts([]SecretScanningAlert) ([]byte, error) {
+	if m.failgenerate {
+		return nil, errTestFail
+	}
+
+	return nil, nil
+}
+
+func (m *mockReportJSON) generateWebhookFindings([]WebhookFinding) ([]byte, error) {
+	if m.failgenerate {
+		return nil, errTestFail
+	}
+
+	return nil, nil
+}
+
 type mockReportAccess struct {
 	fail        bool
-	returnValue map[string]string
+	returnValue map[string]map[string]string
 }
 
-func (m *mockReportAccess) getReport() (map[string]string, error) {
+func (m *mockReportAccess) getReport() (map[string]map[string]string, error) {
 	if m.fail {
 		return m.returnValue, errTestAccessFail
 	}
@@ -209,8 +266,10 @@ func mockJSONMarshalError(v interface{}) ([]byte, error) {
 type mockReportWebhookGetterService struct {
 	failRepoList      bool
 	failWebhook       bool
+	failOrgWebhook    bool
 	returnRepoList    []repositoryCursorList
 	returnWebhookList []Webhooks
+	returnOrgWebhooks Webhooks
 }
 
 func (r *mockReportWebhookGetterService) getRepositoryList(report *reportWebhook) ([]repositoryCursorList, error) {
@@ -232,6 +291,105 @@ func (r *mockReportWebhookGetterService) getWebhooks(
 	return r.returnWebhookList, nil
 }
 
+func (r *mockReportWebhookGetterService) getOrgWebhooks(report *reportWebhook) (Webhooks, error) {
+	if r.failOrgWebhook {
+		return r.returnOrgWebhooks, errTestFail
+	}
+
+	return r.returnOrgWebhooks, nil
+}
+
+type mockReportCollaboratorsGetterService struct {
+	failRepoList            bool
+	failCollaborators       bool
+	returnRepoList          []string
+	returnCollaboratorsList []Collaborators
+}
+
+func (r *mockReportCollaboratorsGetterService) getRepositoryList(report *reportCollaborators) ([]string, error) {
+	if r.failRepoList {
+		return r.returnRepoList, errTestFail
+	}
+
+	return r.returnRepoList, nil
+}
+
+func (r *mockReportCollaboratorsGetterService) getCollaborators(list []string) ([]Collaborators, error) {
+	if r.failCollaborators {
+		return r.returnCollaboratorsList, errTestFail
+	}
+
+	return r.returnCollaboratorsList, nil
+}
+
+type mockReportMembersGetterService struct {
+	fail          bool
+	returnMembers []Member
+}
+
+func (r *mockReportMembersGetterService) getMembers(report *reportMembers) ([]Member, error) {
+	if r.fail {
+		return r.returnMembers, errTestFail
+	}
+
+	return r.returnMembers, nil
+}
+
+type mockReportSecurityGetterService struct {
+	failRepoList   bool
+	failStatus     bool
+	returnRepoList []SecurityStatus
+	returnStatus   []SecurityStatus
+}
+
+func (r *mockReportSecurityGetterService) getRepositoryList(report *reportSecurity) ([]SecurityStatus, error) {
+	if r.failRepoList {
+		return r.returnRepoList, errTestFail
+	}
+
+	return r.returnRepoList, nil
+}
+
+func (r *mockReportSecurityGetterService) getSecurityStatus(list []SecurityStatus) ([]SecurityStatus, error) {
+	if r.failStatus {
+		return r.returnStatus, errTestFail
+	}
+
+	return r.returnStatus, nil
+}
+
+type mockReportDependabotAlertsGetterService struct {
+	fail         bool
+	returnAlerts []DependabotAlertResponse
+}
+
+func (r *mockReportDependabotAlertsGetterService) getAlerts() ([]DependabotAlertResponse, error) {
+	if r.fail {
+		return r.returnAlerts, errTestFail
+	}
+
+	return r.returnAlerts, nil
+}
+
+type mockReportSecretAlertsGetterService struct {
+	fail bool
+}
+
+func (r *mockReportSecretAlertsGetterService) getAlerts(*reportSecretAlerts) ([]SecretScanningAlert, error) {
+	if r.fail {
+		return nil, errTestFail
+	}
+
+	return []SecretScanningAlert{{RepositoryName: "repo1", Number: 1, SecretType: "github_personal_access_token"}}, nil
+}
+
+type dependabotMockResponse struct {
+	method     string
+	url        string
+	file       string
+	statusCode int
+}
+
 func mockHTTPResponder(method, url, responseFile string, statusCode int) {
 	response, err := os.ReadFile(responseFile)
 	if err != nil {
@@ -244,3 +402,18 @@ func mockHTTPResponder(method, url, responseFile string, statusCode int) {
 		httpmock.NewStringResponder(statusCode, string(response)),
 	)
 }
+
+// mockFlagsCmd returns a command with the flags registered by addFlags, then sets each name and value pair in flags as
+// if it was given on the command line.
+func mockFlagsCmd(addFlags func(*cobra.Command), flags ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "mock"}
+	addFlags(cmd)
+
+	for i := 0; i+1 < len(flags); i += 2 {
+		if err := cmd.Flags().Set(flags[i], flags[i+1]); err != nil {
+			log.Fatalf("failed to set test flag %s: %v", flags[i], err)
+		}
+	}
+
+	return cmd
+}
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/graphqlclient"
+	"github-admin-tool/restclient"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pushRestrictionsFlag            bool                     // nolint // needed for cobra
+	pushRestrictionsBranchName      string                   // nolint // needed for cobra
+	pushRestrictions                pushRestrictionsSettings // nolint // resolved from flags before apply
+	errPushActorsWithoutRestriction = errors.New("push allowances can only be set when restrict-pushes is on")
+	errPushRestrictionsNothingToSet = errors.New("set restrict-pushes, push actors or force push actors")
+	errInvalidActor                 = errors.New("invalid actor name")
+	errActorNotFound                = errors.New("could not resolve actor")
+	pushRestrictionsCmd             = &cobra.Command{ // nolint // needed for cobra
+		Use:   "push-restrictions",
+		Short: "Set push allowances and force push bypass actors for repos in provided list",
+		RunE:  pushRestrictionsRun,
+	}
+)
+
+type actorSlugs struct {
+	teams []string
+	users []string
+	apps  []string
+}
+
+func (a actorSlugs) count() int {
+	return len(a.teams) + len(a.users) + len(a.apps)
+}
+
+// pushRestrictionsSettings are the settings given on the command line, anything not given is left unchanged on
+// the rule so a run that only sets force push actors does not wipe the push allowances.
+type pushRestrictionsSettings struct {
+	restrictsPushes    *bool
+	pushActorIDs       []string
+	pushActorsSet      bool
+	forcePushActorIDs  []string
+	forcePushActorsSet bool
+}
+
+func pushRestrictionsRun(cmd *cobra.Command, args []string) error {
+	pushActors, forcePushActors, settings, err := pushRestrictionsFlagCheck(cmd)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	// Only look up actors when we are going to apply them, dry run just counts repositories
+	if !dryRun {
+		ctx := context.Background()
+
+		if settings.pushActorIDs, err = actorResolve(ctx, pushActors); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		if settings.forcePushActorIDs, err = actorResolve(ctx, forcePushActors); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+	}
+
+	pushRestrictions = settings
+
+	err = branchProtectionCommand(
+		cmd,
+		setPushRestrictionsArgs(pushRestrictions),
+		"Push-restrictions",
+		pushRestrictionsBranchName,
+		&repository{
+			reader: &repositoryReaderService{},
+			getter: &repositoryGetterService{},
+		},
+		&githubRepositorySender{
+			sender: &repositorySenderService{},
+		},
+		&githubBranchProtectionSender{
+			sender: &branchProtectionSenderService{},
+		},
+	)
+
+	return err
+}
+
+// pushRestrictionsFlagCheck works out which settings to change, push actors turn the restriction on unless
+// restrict-pushes is set to false. Restricting pushes without push actors keeps the allowances already on the rule,
+// and a restricted rule with no allowances lets only admins push.
+func pushRestrictionsFlagCheck(
+	cmd *cobra.Command,
+) (pushActors, forcePushActors actorSlugs, settings pushRestrictionsSettings, err error) {
+	pushActors, forcePushActors, err = pushRestrictionsGetActorFlags(cmd)
+	if err != nil {
+		return pushActors, forcePushActors, settings, fmt.Errorf("%w", err)
+	}
+
+	for _, name := range []string{"push-teams", "push-users", "push-apps"} {
+		settings.pushActorsSet = settings.pushActorsSet || cmd.Flags().Changed(name)
+	}
+
+	for _, name := range []string{"force-push-teams", "force-push-users", "force-push-apps"} {
+		settings.forcePushActorsSet = settings.forcePushActorsSet || cmd.Flags().Changed(name)
+	}
+
+	restrictsPushes := pushRestrictionsFlag
+
+	switch {
+	case cmd.Flags().Changed("restrict-pushes"):
+		settings.restrictsPushes = &restrictsPushes
+	case pushActors.count() > 0:
+		restrictsPushes = true
+		settings.restrictsPushes = &restrictsPushes
+	}
+
+	if settings.restrictsPushes == nil && !settings.pushActorsSet && !settings.forcePushActorsSet {
+		return pushActors, forcePushActors, settings, errPushRestrictionsNothingToSet
+	}
+
+	if settings.restrictsPushes != nil && !*settings.restrictsPushes && pushActors.count() > 0 {
+		return pushActors, forcePushActors, settings, errPushActorsWithoutRestriction
+	}
+
+	settings.pushActorIDs = []string{}
+	settings.forcePushActorIDs = []string{}
+
+	return pushActors, forcePushActors, settings, nil
+}
+
+func pushRestrictionsGetActorFlags(cmd *cobra.Command) (pushActors, forcePushActors actorSlugs, err error) {
+	flags := map[string]*[]string{
+		"push-teams":       &pushActors.teams,
+		"push-users":       &pushActors.users,
+		"push-apps":        &pushActors.apps,
+		"force-push-teams": &forcePushActors.teams,
+		"force-push-users": &forcePushActors.users,
+		"force-push-apps":  &forcePushActors.apps,
+	}
+
+	for name, value := range flags {
+		if *value, err = cmd.Flags().GetStringSlice(name); err != nil {
+			return pushActors, forcePushActors, fmt.Errorf("%w", err)
+		}
+	}
+
+	return pushActors, forcePushActors, nil
+}
+
+func setPushRestrictionsArgs(settings pushRestrictionsSettings) (branchProtectionArgs []BranchProtectionArgs) {
+	if settings.restrictsPushes != nil {
+		branchProtectionArgs = append(branchProtectionArgs, BranchProtectionArgs{
+			Name:     "restrictsPushes",
+			DataType: "Boolean",
+			Value:    *settings.restrictsPushes,
+		})
+	}
+
+	if settings.pushActorsSet {
+		branchProtectionArgs = append(branchProtectionArgs, BranchProtectionArgs{
+			Name:     "pushActorIds",
+			DataType: "[ID!]",
+			Value:    settings.pushActorIDs,
+		})
+	}
+
+	if settings.forcePushActorsSet {
+		branchProtectionArgs = append(branchProtectionArgs, BranchProtectionArgs{
+			Name:     "bypassForcePushActorIds",
+			DataType: "[ID!]",
+			Value:    settings.forcePushActorIDs,
+		})
+	}
+
+	return branchProtectionArgs
+}
+
+func pushRestrictionsMatch(branchProtection BranchProtectionRulesNode) bool {
+	return (pushRestrictions.restrictsPushes == nil ||
+		branchProtection.RestrictsPushes == *pushRestrictions.restrictsPushes) &&
+		(!pushRestrictions.pushActorsSet ||
+			allowanceActorsMatch(pushRestrictions.pushActorIDs, branchProtection.PushAllowances)) &&
+		(!pushRestrictions.forcePushActorsSet ||
+			allowanceActorsMatch(pushRestrictions.forcePushActorIDs, branchProtection.BypassForcePushAllowances))
+}
+
+func allowanceActorsMatch(actorIDs []string, allowances BranchProtectionAllowances) bool {
+	if len(actorIDs) != len(allowances.Nodes) {
+		return false
+	}
+
+	wanted := make(map[string]bool, len(actorIDs))
+	for _, actorID := range actorIDs {
+		wanted[actorID] = true
+	}
+
+	for _, node := range allowances.Nodes {
+		if !wanted[node.Actor.ID] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// actorResolve returns the node IDs for the given team slugs, user logins and app slugs.
+func actorResolve(ctx context.Context, actors actorSlugs) ([]string, error) {
+	actorIDs := []string{}
+
+	validActorName := regexp.MustCompile("^[A-Za-z0-9_.-]+$")
+
+	for _, names := range [][]string{actors.teams, actors.users, actors.apps} {
+		for _, name := range names {
+			if !validActorName.MatchString(name) {
+				return actorIDs, fmt.Errorf("%w: %s", errInvalidActor, name)
+			}
+		}
+	}
+
+	if len(actors.teams)+len(actors.users) > 0 {
+		client := graphqlclient.NewClient()
+		req := reportRequest(actorLookupQuery(actors.teams, actors.users))
+
+		var respData map[string]*ActorLookupNode
+		if err := client.Run(ctx, req, &respData); err != nil {
+			return actorIDs, fmt.Errorf("graphql call: %w", err)
+		}
+
+		for key, team := range actors.teams {
+			node := respData[fmt.Sprintf("team%d", key)]
+			if node == nil || node.Team == nil {
+				return actorIDs, fmt.Errorf("%w: team %s", errActorNotFound, team)
+			}
+
+			actorIDs = append(actorIDs, node.Team.ID)
+		}
+
+		for key, user := range actors.users {
+			node := respData[fmt.Sprintf("user%d", key)]
+			if node == nil || node.ID == "" {
+				return actorIDs, fmt.Errorf("%w: user %s", errActorNotFound, user)
+			}
+
+			actorIDs = append(actorIDs, node.ID)
+		}
+	}
+
+	// Apps cannot be looked up by slug with GraphQL so use the REST node_id instead
+	for _, app := range actors.apps {
+		client := restclient.NewClient(fmt.Sprintf("/apps/%s", app), config.Token, http.MethodGet)
+
+		var response struct {
+			NodeID string `json:"node_id"` // nolint // this is from github
+		}
+
+		if err := client.Run(ctx, &response); err != nil {
+			return actorIDs, fmt.Errorf("looking up app %s: %w", app, err)
+		}
+
+		actorIDs = append(actorIDs, response.NodeID)
+	}
+
+	return actorIDs, nil
+}
+
+func actorLookupQuery(teams, users []string) string {
+	var query strings.Builder
+
+	query.WriteString("query ($org: String!) {")
+
+	for key, team := range teams {
+		query.WriteString(fmt.Sprintf("team%d: organization(login: $org) {", key))
+		query.WriteString(fmt.Sprintf("	team(slug: \"%s\") {", team))
+		query.WriteString("		id")
+		query.WriteString("	}")
+		query.WriteString("}")
+	}
+
+	for key, user := range users {
+		query.WriteString(fmt.Sprintf("user%d: user(login: \"%s\") {", key, user))
+		query.WriteString("	id")
+		query.WriteString("}")
+	}
+
+	query.WriteString("}")
+
+	return query.String()
+}
+
+// nolint // needed for cobra
+func init() {
+	pushRestrictionsCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	pushRestrictionsCmd.Flags().StringVarP(&pushRestrictionsBranchName, "branch", "b", "", "branch name to create or update the branch protection rule for")
+	pushRestrictionsCmd.Flags().BoolVarP(&pushRestrictionsFlag, "restrict-pushes", "p", false, "boolean indicating whether pushes to the branch are restricted to the push allowances, left unchanged when not set unless push actors are given")
+	pushRestrictionsCmd.Flags().StringSlice("push-teams", []string{}, "team slugs allowed to push to the branch")
+	pushRestrictionsCmd.Flags().StringSlice("push-users", []string{}, "user logins allowed to push to the branch")
+	pushRestrictionsCmd.Flags().StringSlice("push-apps", []string{}, "app slugs allowed to push to the branch")
+	pushRestrictionsCmd.Flags().StringSlice("force-push-teams", []string{}, "team slugs allowed to bypass the force push restriction")
+	pushRestrictionsCmd.Flags().StringSlice("force-push-users", []string{}, "user logins allowed to bypass the force push restriction")
+	pushRestrictionsCmd.Flags().StringSlice("force-push-apps", []string{}, "app slugs allowed to bypass the force push restriction")
+	pushRestrictionsCmd.MarkFlagRequired("repos")
+	pushRestrictionsCmd.MarkFlagRequired("branch")
+	pushRestrictionsCmd.Flags().SortFlags = false
+	rootCmd.AddCommand(pushRestrictionsCmd)
+}
@@ -0,0 +1,475 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_setPushRestrictionsArgs(t *testing.T) {
+	restrictsPushes := true
+
+	tests := []struct {
+		name                     string
+		settings                 pushRestrictionsSettings
+		wantBranchProtectionArgs []BranchProtectionArgs
+	}{
+		{
+			name: "setPushRestrictionsArgs returned values are as expected",
+			settings: pushRestrictionsSettings{
+				restrictsPushes:    &restrictsPushes,
+				pushActorIDs:       []string{"T_team-node-id"},
+				pushActorsSet:      true,
+				forcePushActorIDs:  []string{},
+				forcePushActorsSet: true,
+			},
+			wantBranchProtectionArgs: []BranchProtectionArgs{
+				{
+					Name:     "restrictsPushes",
+					DataType: "Boolean",
+					Value:    true,
+				},
+				{
+					Name:     "pushActorIds",
+					DataType: "[ID!]",
+					Value:    []string{"T_team-node-id"},
+				},
+				{
+					Name:     "bypassForcePushActorIds",
+					DataType: "[ID!]",
+					Value:    []string{},
+				},
+			},
+		},
+		{
+			name: "setPushRestrictionsArgs only force push actors",
+			settings: pushRestrictionsSettings{
+				pushActorIDs:       []string{},
+				forcePushActorIDs:  []string{"U_user-node-id"},
+				forcePushActorsSet: true,
+			},
+			wantBranchProtectionArgs: []BranchProtectionArgs{
+				{
+					Name:     "bypassForcePushActorIds",
+					DataType: "[ID!]",
+					Value:    []string{"U_user-node-id"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if gotBranchProtectionArgs := setPushRestrictionsArgs(
+				tt.settings,
+			); !reflect.DeepEqual(gotBranchProtectionArgs, tt.wantBranchProtectionArgs) {
+				t.Errorf("setPushRestrictionsArgs() = %v, want %v", gotBranchProtectionArgs, tt.wantBranchProtectionArgs)
+			}
+		})
+	}
+}
+
+func Test_pushRestrictionsFlagCheck(t *testing.T) {
+	newCmd := func(flags map[string]string) *cobra.Command {
+		cmd := &cobra.Command{Use: "push-restrictions"}
+		cmd.Flags().BoolVarP(&pushRestrictionsFlag, "restrict-pushes", "p", false, "restrict pushes flag")
+
+		for _, name := range []string{
+			"push-teams",
+			"push-users",
+			"push-apps",
+			"force-push-teams",
+			"force-push-users",
+			"force-push-apps",
+		} {
+			cmd.Flags().StringSlice(name, []string{}, "actor flag")
+		}
+
+		for name, value := range flags {
+			if err := cmd.Flags().Set(name, value); err != nil {
+				t.Fatalf("setting %s flag errors with error = %v", name, err)
+			}
+		}
+
+		return cmd
+	}
+
+	originalPushRestrictionsFlag := pushRestrictionsFlag
+
+	defer func() {
+		pushRestrictionsFlag = originalPushRestrictionsFlag
+	}()
+
+	tests := []struct {
+		name                   string
+		flags                  map[string]string
+		wantRestrictsPushes    string
+		wantPushActorsSet      bool
+		wantForcePushActorsSet bool
+		wantErr                error
+	}{
+		{
+			name:    "pushRestrictionsFlagCheck fails with nothing to set",
+			wantErr: errPushRestrictionsNothingToSet,
+		},
+		{
+			name:    "pushRestrictionsFlagCheck fails with push actors and no restriction",
+			flags:   map[string]string{"restrict-pushes": "false", "push-teams": "some-team"},
+			wantErr: errPushActorsWithoutRestriction,
+		},
+		{
+			name:                   "pushRestrictionsFlagCheck only force push actors leaves the rest unchanged",
+			flags:                  map[string]string{"force-push-users": "some-user"},
+			wantRestrictsPushes:    "unchanged",
+			wantForcePushActorsSet: true,
+		},
+		{
+			name:                "pushRestrictionsFlagCheck push actors restrict pushes",
+			flags:               map[string]string{"push-teams": "some-team"},
+			wantRestrictsPushes: "true",
+			wantPushActorsSet:   true,
+		},
+		{
+			name:                   "pushRestrictionsFlagCheck restricting pushes without push actors keeps the allowances",
+			flags:                  map[string]string{"restrict-pushes": "true", "force-push-teams": "some-team"},
+			wantRestrictsPushes:    "true",
+			wantForcePushActorsSet: true,
+		},
+		{
+			name:                "pushRestrictionsFlagCheck restricting pushes to admins only",
+			flags:               map[string]string{"restrict-pushes": "true", "push-teams": ""},
+			wantRestrictsPushes: "true",
+			wantPushActorsSet:   true,
+		},
+		{
+			name:                "pushRestrictionsFlagCheck clearing push actors leaves the restriction unchanged",
+			flags:               map[string]string{"push-teams": ""},
+			wantRestrictsPushes: "unchanged",
+			wantPushActorsSet:   true,
+		},
+		{
+			name:                "pushRestrictionsFlagCheck turning off the restriction clears push actors",
+			flags:               map[string]string{"restrict-pushes": "false", "push-teams": ""},
+			wantRestrictsPushes: "false",
+			wantPushActorsSet:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, gotSettings, err := pushRestrictionsFlagCheck(newCmd(tt.flags))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("pushRestrictionsFlagCheck() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			gotRestrictsPushes := "unchanged"
+			if gotSettings.restrictsPushes != nil {
+				gotRestrictsPushes = strconv.FormatBool(*gotSettings.restrictsPushes)
+			}
+
+			if gotRestrictsPushes != tt.wantRestrictsPushes {
+				t.Errorf("pushRestrictionsFlagCheck() restrictsPushes = %v, want %v", gotRestrictsPushes, tt.wantRestrictsPushes)
+			}
+
+			if gotSettings.pushActorsSet != tt.wantPushActorsSet {
+				t.Errorf("pushRestrictionsFlagCheck() pushActorsSet = %v, want %v", gotSettings.pushActorsSet, tt.wantPushActorsSet)
+			}
+
+			if gotSettings.forcePushActorsSet != tt.wantForcePushActorsSet {
+				t.Errorf(
+					"pushRestrictionsFlagCheck() forcePushActorsSet = %v, want %v",
+					gotSettings.forcePushActorsSet,
+					tt.wantForcePushActorsSet,
+				)
+			}
+		})
+	}
+}
+
+func Test_allowanceActorsMatch(t *testing.T) {
+	type args struct {
+		actorIDs   []string
+		allowances BranchProtectionAllowances
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "allowanceActorsMatch both empty",
+			args: args{
+				actorIDs: []string{},
+			},
+			want: true,
+		},
+		{
+			name: "allowanceActorsMatch same actors different order",
+			args: args{
+				actorIDs: []string{"U_user-node-id", "T_team-node-id"},
+				allowances: BranchProtectionAllowances{
+					Nodes: []BranchProtectionAllowancesNode{
+						{Actor: BranchProtectionActor{ID: "T_team-node-id"}},
+						{Actor: BranchProtectionActor{ID: "U_user-node-id"}},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "allowanceActorsMatch different actors",
+			args: args{
+				actorIDs: []string{"U_user-node-id"},
+				allowances: BranchProtectionAllowances{
+					Nodes: []BranchProtectionAllowancesNode{
+						{Actor: BranchProtectionActor{ID: "T_team-node-id"}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "allowanceActorsMatch different length",
+			args: args{
+				actorIDs: []string{},
+				allowances: BranchProtectionAllowances{
+					Nodes: []BranchProtectionAllowancesNode{
+						{Actor: BranchProtectionActor{ID: "T_team-node-id"}},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := allowanceActorsMatch(tt.args.actorIDs, tt.args.allowances); got != tt.want {
+				t.Errorf("allowanceActorsMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_actorLookupQuery(t *testing.T) {
+	type args struct {
+		teams []string
+		users []string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "actorLookupQuery with team and user",
+			args: args{
+				teams: []string{"some-team"},
+				users: []string{"some-user"},
+			},
+			want: "query ($org: String!) {" +
+				"team0: organization(login: $org) {	team(slug: \"some-team\") {		id	}}" +
+				"user0: user(login: \"some-user\") {	id}" +
+				"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := actorLookupQuery(tt.args.teams, tt.args.users); got != tt.want {
+				t.Errorf("actorLookupQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_actorResolve(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	type args struct {
+		actors actorSlugs
+	}
+
+	tests := []struct {
+		name               string
+		args               args
+		mockHTTPReturnFile string
+		want               []string
+		wantErr            bool
+	}{
+		{
+			name:    "actorResolve with no actors",
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name: "actorResolve fails on invalid name",
+			args: args{
+				actors: actorSlugs{teams: []string{"some team\""}},
+			},
+			want:    []string{},
+			wantErr: true,
+		},
+		{
+			name: "actorResolve fails on missing team",
+			args: args{
+				actors: actorSlugs{teams: []string{"some-team"}},
+			},
+			mockHTTPReturnFile: "testdata/mockActorLookupMissingTeamResponse.json",
+			want:               []string{},
+			wantErr:            true,
+		},
+		{
+			name: "actorResolve success",
+			args: args{
+				actors: actorSlugs{
+					teams: []string{"some-team"},
+					users: []string{"some-user"},
+					apps:  []string{"some-app"},
+				},
+			},
+			mockHTTPReturnFile: "testdata/mockActorLookupResponse.json",
+			want:               []string{"T_team-node-id", "U_user-node-id", "A_app-node-id"},
+			wantErr:            false,
+		},
+	}
+
+	mockHTTPResponder("GET", "/apps/some-app", "testdata/mockGetAppResponse.json", 200)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockHTTPReturnFile != "" {
+				mockHTTPResponder("POST", "https://api.github.com/graphql", tt.mockHTTPReturnFile, 200)
+			}
+
+			got, err := actorResolve(context.Background(), tt.args.actors)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("actorResolve() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("actorResolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_pushRestrictionsRun(t *testing.T) {
+	var (
+		mockDryRun     bool
+		mockRepos2File string
+	)
+
+	mockCmdWithDryRunOn := &cobra.Command{
+		Use: "push-restrictions",
+	}
+	mockCmdWithDryRunOn.Flags().BoolVarP(&mockDryRun, "dry-run", "d", true, "dry run flag")
+	mockCmdWithDryRunOn.Flags().StringVarP(
+		&mockRepos2File,
+		"repos",
+		"r",
+		"testdata/two_repo_list.txt",
+		"repos file",
+	)
+
+	for _, name := range []string{
+		"push-teams",
+		"push-users",
+		"push-apps",
+		"force-push-teams",
+		"force-push-users",
+		"force-push-apps",
+	} {
+		mockCmdWithDryRunOn.Flags().StringSlice(name, []string{}, "actor flag")
+	}
+
+	mockCmdWithDryRunOn.Flags().BoolVarP(&pushRestrictionsFlag, "restrict-pushes", "p", false, "restrict pushes flag")
+
+	mockCmdMissingActorFlags := &cobra.Command{
+		Use: "push-restrictions",
+	}
+
+	type args struct {
+		cmd  *cobra.Command
+		args []string
+	}
+
+	tests := []struct {
+		name            string
+		args            args
+		restrictsPushes bool
+		pushTeams       string
+		wantErr         bool
+	}{
+		{
+			name: "pushRestrictionsRun fails on missing actor flags",
+			args: args{
+				cmd: mockCmdMissingActorFlags,
+			},
+			wantErr: true,
+		},
+		{
+			name: "pushRestrictionsRun fails with push actors and no restriction",
+			args: args{
+				cmd: mockCmdWithDryRunOn,
+			},
+			restrictsPushes: false,
+			pushTeams:       "some-team",
+			wantErr:         true,
+		},
+		{
+			name: "pushRestrictionsRun dry run on",
+			args: args{
+				cmd: mockCmdWithDryRunOn,
+			},
+			restrictsPushes: true,
+			pushTeams:       "some-team",
+			wantErr:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.args.cmd.Flags().Lookup("restrict-pushes") != nil {
+				if err := tt.args.cmd.Flags().Set("restrict-pushes", strconv.FormatBool(tt.restrictsPushes)); err != nil {
+					t.Fatalf("setting restrict-pushes flag errors with error = %v", err)
+				}
+			}
+
+			if tt.pushTeams != "" {
+				if err := tt.args.cmd.Flags().Set("push-teams", tt.pushTeams); err != nil {
+					t.Fatalf("setting push-teams flag errors with error = %v", err)
+				}
+			}
+
+			if err := pushRestrictionsRun(tt.args.cmd, tt.args.args); (err != nil) != tt.wantErr {
+				t.Errorf("pushRestrictionsRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errRepoSettingsEmptyFlags   = errors.New("must set at least one repository setting to update")
+	errRepoSettingsInvalidValue = errors.New("invalid value for repository setting")
+	errRepoSettingsInvalidPair  = errors.New("invalid combination of commit title and message")
+	repoSettingsCmd             = &cobra.Command{ // nolint // needed for cobra
+		Use:   "repo-settings",
+		Short: "Set merge methods and repository features for repos in provided list",
+		RunE:  repoSettingsRun,
+	}
+)
+
+// repoSetting maps a command flag to the field name used by the repository REST API.
+type repoSetting struct {
+	flag          string
+	field         string
+	usage         string
+	allowedValues []string
+}
+
+var repoSettingsBoolFlags = []repoSetting{ // nolint // expected global
+	{flag: "merge-commit", field: "allow_merge_commit", usage: "allow merge commits"},
+	{flag: "squash-merge", field: "allow_squash_merge", usage: "allow squash merging"},
+	{flag: "rebase-merge", field: "allow_rebase_merge", usage: "allow rebase merging"},
+	{flag: "auto-merge", field: "allow_auto_merge", usage: "allow auto-merge on pull requests"},
+	{flag: "delete-branch-on-merge", field: "delete_branch_on_merge", usage: "delete head branches when pull requests are merged"},
+	{flag: "wiki", field: "has_wiki", usage: "enable the wiki"},
+	{flag: "issues", field: "has_issues", usage: "enable issues"},
+	{flag: "projects", field: "has_projects", usage: "enable projects"},
+	{flag: "discussions", field: "has_discussions", usage: "enable discussions"},
+	{flag: "web-commit-signoff", field: "web_commit_signoff_required", usage: "require sign off on web based commits"},
+}
+
+var repoSettingsStringFlags = []repoSetting{ // nolint // expected global
+	{
+		flag:          "squash-merge-commit-title",
+		field:         "squash_merge_commit_title",
+		usage:         "default title for squash merge commits, PR_TITLE or COMMIT_OR_PR_TITLE",
+		allowedValues: []string{"PR_TITLE", "COMMIT_OR_PR_TITLE"},
+	},
+	{
+		flag:          "squash-merge-commit-message",
+		field:         "squash_merge_commit_message",
+		usage:         "default message for squash merge commits, PR_BODY, COMMIT_MESSAGES or BLANK",
+		allowedValues: []string{"PR_BODY", "COMMIT_MESSAGES", "BLANK"},
+	},
+	{
+		flag:          "merge-commit-title",
+		field:         "merge_commit_title",
+		usage:         "default title for merge commits, PR_TITLE or MERGE_MESSAGE",
+		allowedValues: []string{"PR_TITLE", "MERGE_MESSAGE"},
+	},
+	{
+		flag:          "merge-commit-message",
+		field:         "merge_commit_message",
+		usage:         "default message for merge commits, PR_BODY, PR_TITLE or BLANK",
+		allowedValues: []string{"PR_BODY", "PR_TITLE", "BLANK"},
+	},
+}
+
+// repoSettingsPair is a commit title and message that GitHub only accepts in certain combinations, so both are
+// sent when either one changes.
+type repoSettingsPair struct {
+	title    string
+	message  string
+	messages map[string][]string
+}
+
+var repoSettingsPairs = []repoSettingsPair{ // nolint // expected global
+	{
+		title:   "squash_merge_commit_title",
+		message: "squash_merge_commit_message",
+		messages: map[string][]string{
+			"PR_TITLE":           {"PR_BODY", "COMMIT_MESSAGES", "BLANK"},
+			"COMMIT_OR_PR_TITLE": {"COMMIT_MESSAGES"},
+		},
+	},
+	{
+		title:   "merge_commit_title",
+		message: "merge_commit_message",
+		messages: map[string][]string{
+			"PR_TITLE":      {"PR_BODY", "BLANK"},
+			"MERGE_MESSAGE": {"PR_TITLE"},
+		},
+	},
+}
+
+func repoSettingsRun(cmd *cobra.Command, args []string) error {
+	err := repoSettingsCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+	)
+
+	return err
+}
+
+func repoSettingsCommand(cmd *cobra.Command, repo *repository) error {
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	reposFilePath, err := cmd.Flags().GetString("repos")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	desired, err := repoSettingsDesired(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	repositoryList, err := repo.reader.read(reposFilePath)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	log.SetFlags(0)
+
+	if dryRun {
+		log.Printf("This is a dry run, the run would process %d repositories", len(repositoryList))
+	}
+
+	ctx := context.Background()
+
+	for _, repositoryName := range repositoryList {
+		result, err := repoSettingsApply(ctx, repositoryName, desired, dryRun)
+		if err != nil {
+			log.Printf("Error (%s): %v", repositoryName, err)
+
+			continue
+		}
+
+		log.Print(result)
+	}
+
+	return nil
+}
+
+// repoSettingsDesired returns the settings for the flags that were set, keyed by REST API field name.
+func repoSettingsDesired(cmd *cobra.Command) (map[string]interface{}, error) {
+	desired := make(map[string]interface{})
+
+	for _, setting := range repoSettingsBoolFlags {
+		if !cmd.Flags().Changed(setting.flag) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetBool(setting.flag)
+		if err != nil {
+			return desired, fmt.Errorf("%w", err)
+		}
+
+		desired[setting.field] = value
+	}
+
+	for _, setting := range repoSettingsStringFlags {
+		if !cmd.Flags().Changed(setting.flag) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetString(setting.flag)
+		if err != nil {
+			return desired, fmt.Errorf("%w", err)
+		}
+
+		if !repoSettingsAllowed(value, setting.allowedValues) {
+			return desired, fmt.Errorf("%w: %s=%s", errRepoSettingsInvalidValue, setting.flag, value)
+		}
+
+		desired[setting.field] = value
+	}
+
+	if len(desired) == 0 {
+		return desired, errRepoSettingsEmptyFlags
+	}
+
+	for _, pair := range repoSettingsPairs {
+		if err := pair.check(desired); err != nil {
+			return desired, err
+		}
+	}
+
+	return desired, nil
+}
+
+// check returns an error when settings has both the title and message of the pair and GitHub would reject them.
+func (p repoSettingsPair) check(settings map[string]interface{}) error {
+	title, titleSet := settings[p.title].(string)
+	message, messageSet := settings[p.message].(string)
+
+	if !titleSet || !messageSet || repoSettingsAllowed(message, p.messages[title]) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s=%s with %s=%s", errRepoSettingsInvalidPair, p.title, title, p.message, message)
+}
+
+// repoSettingsApply updates the repository with any desired settings that differ from the current ones.
+func repoSettingsApply(
+	ctx context.Context,
+	repositoryName string,
+	desired map[string]interface{},
+	dryRun bool,
+) (string, error) {
+	path := fmt.Sprintf("/repos/%s/%s", config.Org, repositoryName)
+
+	client := restclient.NewClient(path, config.Token, http.MethodGet)
+
+	var current map[string]interface{}
+	if err := client.Run(ctx, &current); err != nil {
+		return "", fmt.Errorf("get repository: %w", err)
+	}
+
+	changes, err := repoSettingsChanges(current, desired)
+	if err != nil {
+		return "", err
+	}
+
+	if len(changes) == 0 {
+		return fmt.Sprintf("Repository settings already up to date for %s", repositoryName), nil
+	}
+
+	summary := repoSettingsSummary(current, changes)
+
+	if dryRun {
+		return fmt.Sprintf("Would update repository settings for %s: %s", repositoryName, summary), nil
+	}
+
+	client = restclient.NewClient(path, config.Token, http.MethodPatch)
+	if err := client.SetBody(changes); err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	var response interface{}
+	if err := client.Run(ctx, &response); err != nil {
+		return "", fmt.Errorf("update repository: %w", err)
+	}
+
+	return fmt.Sprintf("Updated repository settings for %s: %s", repositoryName, summary), nil
+}
+
+// repoSettingsChanges returns the desired settings that differ from the current ones, when one setting of a pair
+// changes the other is sent as well, filled from the desired or current value.
+func repoSettingsChanges(current, desired map[string]interface{}) (map[string]interface{}, error) {
+	changes := make(map[string]interface{})
+
+	for field, value := range desired {
+		if !reflect.DeepEqual(current[field], value) {
+			changes[field] = value
+		}
+	}
+
+	for _, pair := range repoSettingsPairs {
+		_, titleChanged := changes[pair.title]
+		_, messageChanged := changes[pair.message]
+
+		if !titleChanged && !messageChanged {
+			continue
+		}
+
+		for _, field := range []string{pair.title, pair.message} {
+			if _, ok := changes[field]; !ok && current[field] != nil {
+				changes[field] = current[field]
+			}
+		}
+
+		if err := pair.check(changes); err != nil {
+			return changes, err
+		}
+	}
+
+	return changes, nil
+}
+
+// repoSettingsSummary lists the changes in field order as "field: old -> new".
+func repoSettingsSummary(current, changes map[string]interface{}) string {
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	summary := make([]string, 0, len(fields))
+	for _, field := range fields {
+		summary = append(summary, fmt.Sprintf("%s: %v -> %v", field, current[field], changes[field]))
+	}
+
+	return strings.Join(summary, ", ")
+}
+
+func repoSettingsAllowed(value string, allowedValues []string) bool {
+	for _, allowedValue := range allowedValues {
+		if value == allowedValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nolint // needed for cobra
+func init() {
+	repoSettingsCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+
+	for _, setting := range repoSettingsBoolFlags {
+		repoSettingsCmd.Flags().Bool(setting.flag, false, setting.usage)
+	}
+
+	for _, setting := range repoSettingsStringFlags {
+		repoSettingsCmd.Flags().String(setting.flag, "", setting.usage)
+	}
+
+	repoSettingsCmd.MarkFlagRequired("repos")
+	repoSettingsCmd.Flags().SortFlags = false
+	rootCmd.AddCommand(repoSettingsCmd)
+}
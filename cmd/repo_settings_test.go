@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func repoSettingsTestFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", true, "dry run flag")
+	cmd.Flags().String("repos", "testdata/one_repo_list.txt", "repos file")
+
+	for _, setting := range repoSettingsBoolFlags {
+		cmd.Flags().Bool(setting.flag, false, setting.usage)
+	}
+
+	for _, setting := range repoSettingsStringFlags {
+		cmd.Flags().String(setting.flag, "", setting.usage)
+	}
+}
+
+func Test_repoSettingsDesired(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   []string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:    "repoSettingsDesired fails with no settings",
+			want:    map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name:    "repoSettingsDesired fails on invalid squash title",
+			flags:   []string{"squash-merge-commit-title", "BLANK"},
+			want:    map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name:  "repoSettingsDesired fails on invalid squash title and message",
+			flags: []string{"squash-merge-commit-title", "COMMIT_OR_PR_TITLE", "squash-merge-commit-message", "PR_BODY"},
+			want: map[string]interface{}{
+				"squash_merge_commit_title":   "COMMIT_OR_PR_TITLE",
+				"squash_merge_commit_message": "PR_BODY",
+			},
+			wantErr: true,
+		},
+		{
+			name:  "repoSettingsDesired merge title and message",
+			flags: []string{"merge-commit-title", "MERGE_MESSAGE", "merge-commit-message", "PR_TITLE"},
+			want: map[string]interface{}{
+				"merge_commit_title":   "MERGE_MESSAGE",
+				"merge_commit_message": "PR_TITLE",
+			},
+		},
+		{
+			name: "repoSettingsDesired only returns flags that were set",
+			flags: []string{
+				"merge-commit", "false",
+				"delete-branch-on-merge", "true",
+				"squash-merge-commit-message", "PR_BODY",
+			},
+			want: map[string]interface{}{
+				"allow_merge_commit":          false,
+				"delete_branch_on_merge":      true,
+				"squash_merge_commit_message": "PR_BODY",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repoSettingsDesired(mockFlagsCmd(repoSettingsTestFlags, tt.flags...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoSettingsDesired() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repoSettingsDesired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repoSettingsApply(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	repoURL := "https://api.github.com/repos/some-org/some-repo"
+
+	tests := []struct {
+		name          string
+		desired       map[string]interface{}
+		dryRun        bool
+		getFile       string
+		getStatus     int
+		wantResult    string
+		wantErr       bool
+		wantPatchCall int
+	}{
+		{
+			name:      "repoSettingsApply fails on get",
+			desired:   map[string]interface{}{"has_wiki": false},
+			getFile:   "testdata/mockRest404Response.json",
+			getStatus: 404,
+			wantErr:   true,
+		},
+		{
+			name:       "repoSettingsApply already up to date",
+			desired:    map[string]interface{}{"has_wiki": true, "squash_merge_commit_title": "COMMIT_OR_PR_TITLE"},
+			getFile:    "testdata/mockGetRepositoryResponse.json",
+			getStatus:  200,
+			wantResult: "Repository settings already up to date for some-repo",
+		},
+		{
+			name:       "repoSettingsApply dry run",
+			desired:    map[string]interface{}{"has_wiki": false, "allow_merge_commit": true},
+			dryRun:     true,
+			getFile:    "testdata/mockGetRepositoryResponse.json",
+			getStatus:  200,
+			wantResult: "Would update repository settings for some-repo: has_wiki: true -> false",
+		},
+		{
+			name: "repoSettingsApply update",
+			desired: map[string]interface{}{
+				"has_wiki":                  false,
+				"squash_merge_commit_title": "PR_TITLE",
+			},
+			getFile:       "testdata/mockGetRepositoryResponse.json",
+			getStatus:     200,
+			wantResult:    "Updated repository settings for some-repo: has_wiki: true -> false, squash_merge_commit_message: COMMIT_MESSAGES -> COMMIT_MESSAGES, squash_merge_commit_title: COMMIT_OR_PR_TITLE -> PR_TITLE", // nolint // long result
+			wantPatchCall: 1,
+		},
+		{
+			name:          "repoSettingsApply update sends the current title with the message",
+			desired:       map[string]interface{}{"merge_commit_title": "PR_TITLE", "merge_commit_message": "BLANK"},
+			getFile:       "testdata/mockGetRepositoryResponse.json",
+			getStatus:     200,
+			wantResult:    "Updated repository settings for some-repo: merge_commit_message: PR_TITLE -> BLANK, merge_commit_title: MERGE_MESSAGE -> PR_TITLE", // nolint // long result
+			wantPatchCall: 1,
+		},
+		{
+			name:      "repoSettingsApply fails on invalid combination with the current title",
+			desired:   map[string]interface{}{"squash_merge_commit_message": "PR_BODY"},
+			getFile:   "testdata/mockGetRepositoryResponse.json",
+			getStatus: 200,
+			wantErr:   true,
+		},
+		{
+			name:      "repoSettingsApply fails on invalid combination with the current message",
+			desired:   map[string]interface{}{"merge_commit_title": "PR_TITLE"},
+			getFile:   "testdata/mockGetRepositoryResponse.json",
+			getStatus: 200,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			mockHTTPResponder("GET", repoURL, tt.getFile, tt.getStatus)
+			mockHTTPResponder("PATCH", repoURL, "testdata/mockGetRepositoryResponse.json", 200)
+
+			got, err := repoSettingsApply(context.Background(), "some-repo", tt.desired, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repoSettingsApply() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got != tt.wantResult {
+				t.Errorf("repoSettingsApply() = %v, want %v", got, tt.wantResult)
+			}
+
+			if calls := httpmock.GetCallCountInfo()["PATCH "+repoURL]; calls != tt.wantPatchCall {
+				t.Errorf("repoSettingsApply() PATCH calls = %d, want %d", calls, tt.wantPatchCall)
+			}
+		})
+	}
+}
+
+func Test_repoSettingsCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		repo    *repository
+		wantErr bool
+	}{
+		{
+			name:    "repoSettingsCommand fails on missing flags",
+			cmd:     &cobra.Command{Use: "repo-settings"},
+			wantErr: true,
+		},
+		{
+			name:    "repoSettingsCommand fails with no settings",
+			cmd:     mockFlagsCmd(repoSettingsTestFlags),
+			wantErr: true,
+		},
+		{
+			name:    "repoSettingsCommand fails on repo read",
+			cmd:     mockFlagsCmd(repoSettingsTestFlags, "wiki", "false"),
+			repo:    &repository{reader: &mockRepositoryReader{readFail: true}},
+			wantErr: true,
+		},
+		{
+			name:    "repoSettingsCommand with no repos",
+			cmd:     mockFlagsCmd(repoSettingsTestFlags, "wiki", "false"),
+			repo:    &repository{reader: &mockRepositoryReader{}},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := repoSettingsCommand(tt.cmd, tt.repo); (err != nil) != tt.wantErr {
+				t.Errorf("repoSettingsCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
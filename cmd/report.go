@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github-admin-tool/graphqlclient"
 	"github-admin-tool/progressbar"
@@ -11,11 +12,15 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var reportCmd = &cobra.Command{ // nolint // needed for cobra
-	Use:   "report",
-	Short: "Run a report to generate a csv containing information on all organisation repos",
-	RunE:  reportRun,
-}
+var (
+	errReportTeamsDouble = errors.New("cannot set both teams and all-teams")
+	errRulesetsPage      = errors.New("no rulesets returned for repository")
+	reportCmd            = &cobra.Command{ // nolint // needed for cobra
+		Use:   "report",
+		Short: "Run a report to generate a csv containing information on all organisation repos",
+		RunE:  reportRun,
+	}
+)
 
 type report struct {
 	reportGetter reportGetter
@@ -28,7 +33,9 @@ type reportGetter interface {
 	getReport() ([]ReportResponse, error)
 }
 
-type reportGetterService struct{}
+type reportGetterService struct {
+	sender *githubRepositorySender
+}
 
 func reportRun(cmd *cobra.Command, args []string) error {
 	var err error
@@ -53,12 +60,19 @@ func reportRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("%w", err)
 	}
 
+	teams, allTeams, err := reportTeamsFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
 	return reportCreate(
 		&report{
-			reportGetter: &reportGetterService{},
+			reportGetter: &reportGetterService{
+				sender: &githubRepositorySender{sender: &repositorySenderService{}},
+			},
 			reportCSV:    &reportCSVService{},
 			reportJSON:   &reportJSONService{},
-			reportAccess: &reportAccessService{},
+			reportAccess: &reportAccessService{teams: teams, allTeams: allTeams},
 		},
 		dryRun,
 		ignoreArchived,
@@ -103,11 +117,37 @@ func reportCreate(r *report, dryRun, ignoreArchived bool, filePath, fileType str
 	return nil
 }
 
+// reportTeamsFlagCheck returns the team slugs to report permissions for, the team from config is used
+// when neither flag is set.
+func reportTeamsFlagCheck(cmd *cobra.Command) (teams []string, allTeams bool, err error) {
+	teams, err = cmd.Flags().GetStringSlice("teams")
+	if err != nil {
+		return teams, allTeams, fmt.Errorf("%w", err)
+	}
+
+	allTeams, err = cmd.Flags().GetBool("all-teams")
+	if err != nil {
+		return teams, allTeams, fmt.Errorf("%w", err)
+	}
+
+	if len(teams) > 0 && allTeams {
+		return teams, allTeams, errReportTeamsDouble
+	}
+
+	if len(teams) == 0 && !allTeams && config.Team != "" {
+		teams = []string{config.Team}
+	}
+
+	return teams, allTeams, nil
+}
+
 // nolint // needed for cobra
 func init() {
 	reportCmd.Flags().BoolVarP(&ignoreArchived, "ignore-archived", "i", true, "Ignore archived repositories")
 	reportCmd.Flags().StringVarP(&filePath, "file-path", "f", "report.csv", "file path for report to be created, must be .csv or .json")
 	reportCmd.Flags().StringVarP(&fileType, "file-type", "t", "csv", "file type, must be csv or json")
+	reportCmd.Flags().StringSlice("teams", []string{}, "team slugs to report repository permissions for, defaults to the team in config")
+	reportCmd.Flags().Bool("all-teams", false, "report repository permissions for every team in the organisation")
 	rootCmd.AddCommand(reportCmd)
 }
 
@@ -135,7 +175,11 @@ func reportQuery() string {
 	query.WriteString("					nameWithOwner")
 	query.WriteString("					rebaseMergeAllowed")
 	query.WriteString("					squashMergeAllowed")
-	query.WriteString("					branchProtectionRules(first: 100) {")
+	query.WriteString("					branchProtectionRules(first: 25) {")
+	query.WriteString("						pageInfo {")
+	query.WriteString("							endCursor")
+	query.WriteString("							hasNextPage")
+	query.WriteString("						}")
 	query.WriteString("						nodes {")
 	query.WriteString("							id")
 	query.WriteString("							isAdminEnforced")
@@ -150,9 +194,73 @@ func reportQuery() string {
 	query.WriteString("							requireLastPushApproval")
 	query.WriteString("							allowsForcePushes")
 	query.WriteString("							allowsDeletions")
+	query.WriteString("							blocksCreations")
+	query.WriteString("							lockAllowsFetchAndMerge")
+	query.WriteString("							lockBranch")
+	query.WriteString("							requiresConversationResolution")
+	query.WriteString("							requiresDeployments")
+	query.WriteString("							requiresLinearHistory")
+	query.WriteString("							restrictsReviewDismissals")
+	query.WriteString("							requiredDeploymentEnvironments")
+	query.WriteString("							requiredStatusChecks {")
+	query.WriteString("								context")
+	query.WriteString("								app {")
+	query.WriteString("									id")
+	query.WriteString("								}")
+	query.WriteString("							}")
 	query.WriteString("							pattern")
+	query.WriteString("							matchingRefs(first: 1) {")
+	query.WriteString("								totalCount")
+	query.WriteString("							}")
+	query.WriteString("							pushAllowances(first: 10) {")
+	query.WriteString("								totalCount")
+	query.WriteString("								nodes {")
+	query.WriteString("									actor {")
+	query.WriteString("										__typename")
+	query.WriteString("										... on App { id slug }")
+	query.WriteString("										... on Team { id slug }")
+	query.WriteString("										... on User { id login }")
+	query.WriteString("									}")
+	query.WriteString("								}")
+	query.WriteString("							}")
+	query.WriteString("							bypassForcePushAllowances(first: 10) {")
+	query.WriteString("								totalCount")
+	query.WriteString("								nodes {")
+	query.WriteString("									actor {")
+	query.WriteString("										__typename")
+	query.WriteString("										... on App { id slug }")
+	query.WriteString("										... on Team { id slug }")
+	query.WriteString("										... on User { id login }")
+	query.WriteString("									}")
+	query.WriteString("								}")
+	query.WriteString("							}")
+	query.WriteString("							bypassPullRequestAllowances(first: 10) {")
+	query.WriteString("								totalCount")
+	query.WriteString("								nodes {")
+	query.WriteString("									actor {")
+	query.WriteString("										__typename")
+	query.WriteString("										... on App { id slug }")
+	query.WriteString("										... on Team { id slug }")
+	query.WriteString("										... on User { id login }")
+	query.WriteString("									}")
+	query.WriteString("								}")
+	query.WriteString("							}")
+	query.WriteString("							reviewDismissalAllowances(first: 10) {")
+	query.WriteString("								totalCount")
+	query.WriteString("								nodes {")
+	query.WriteString("									actor {")
+	query.WriteString("										__typename")
+	query.WriteString("										... on App { id slug }")
+	query.WriteString("										... on Team { id slug }")
+	query.WriteString("										... on User { id login }")
+	query.WriteString("									}")
+	query.WriteString("								}")
+	query.WriteString("							}")
 	query.WriteString("						}")
 	query.WriteString("					}")
+	query.WriteString("					rulesets(first: 10, includeParents: true) {")
+	query.WriteString(rulesetsFields(10))
+	query.WriteString("					}")
 	query.WriteString("					defaultBranchRef {")
 	query.WriteString("						name")
 	query.WriteString("					}")
@@ -169,6 +277,119 @@ func reportQuery() string {
 	return query.String()
 }
 
+// rulesetsFields are the fields of a rulesets connection, rules and bypass actors are limited to first so the
+// report stays under the GraphQL node limit.
+func rulesetsFields(first int) string {
+	var query strings.Builder
+
+	query.WriteString("						totalCount")
+	query.WriteString("						pageInfo {")
+	query.WriteString("							endCursor")
+	query.WriteString("							hasNextPage")
+	query.WriteString("						}")
+	query.WriteString("						nodes {")
+	query.WriteString("							id")
+	query.WriteString("							databaseId")
+	query.WriteString("							name")
+	query.WriteString("							target")
+	query.WriteString("							enforcement")
+	query.WriteString("							source {")
+	query.WriteString("								__typename")
+	query.WriteString("								... on Organization { login }")
+	query.WriteString("								... on Repository { nameWithOwner }")
+	query.WriteString("							}")
+	query.WriteString("							conditions {")
+	query.WriteString("								refName { include exclude }")
+	query.WriteString("								repositoryName { include exclude }")
+	query.WriteString("							}")
+	query.WriteString(fmt.Sprintf("							rules(first: %d) {", first))
+	query.WriteString("								totalCount")
+	query.WriteString("								nodes {")
+	query.WriteString("									type")
+	query.WriteString("								}")
+	query.WriteString("							}")
+	query.WriteString(fmt.Sprintf("							bypassActors(first: %d) {", first))
+	query.WriteString("								totalCount")
+	query.WriteString("								nodes {")
+	query.WriteString("									actor {")
+	query.WriteString("										__typename")
+	query.WriteString("										... on App { id slug }")
+	query.WriteString("										... on Team { id slug }")
+	query.WriteString("									}")
+	query.WriteString("									bypassMode")
+	query.WriteString("									organizationAdmin")
+	query.WriteString("									repositoryRoleName")
+	query.WriteString("								}")
+	query.WriteString("							}")
+	query.WriteString("						}")
+
+	return query.String()
+}
+
+// rulesetsQuery gets a page of rulesets for a single repository, used when the report query truncated them.
+func rulesetsQuery() string {
+	var query strings.Builder
+
+	query.WriteString("query ($id: ID! $after: String) {")
+	query.WriteString("	repository: node(id: $id) {")
+	query.WriteString("		... on Repository {")
+	query.WriteString("			id")
+	query.WriteString("			nameWithOwner")
+	query.WriteString("			rulesets(first: 25, after: $after, includeParents: true) {")
+	query.WriteString(rulesetsFields(100))
+	query.WriteString("			}")
+	query.WriteString("		}")
+	query.WriteString("	}")
+	query.WriteString("}")
+
+	return query.String()
+}
+
+// rulesetsGetRemaining fetches the rulesets of the repository again, a page at a time, when the report query
+// truncated the rulesets, their rules or their bypass actors.
+func rulesetsGetRemaining(repository *RepositoriesNode, sender *githubRepositorySender) error {
+	if !rulesetsTruncated(repository.Rulesets) {
+		return nil
+	}
+
+	repository.Rulesets = Rulesets{PageInfo: PageInfo{HasNextPage: true}}
+
+	for repository.Rulesets.PageInfo.HasNextPage {
+		req := branchProtectionRulesRequest(rulesetsQuery(), repository.ID, repository.Rulesets.PageInfo.EndCursor)
+
+		response, err := sender.sender.send(req)
+		if err != nil {
+			return fmt.Errorf("rulesets for %s: %w", repository.NameWithOwner, err)
+		}
+
+		page, ok := response["repository"]
+		if !ok || page == nil {
+			return fmt.Errorf("%w: %s", errRulesetsPage, repository.NameWithOwner)
+		}
+
+		repository.Rulesets.Nodes = append(repository.Rulesets.Nodes, page.Rulesets.Nodes...)
+		repository.Rulesets.TotalCount = page.Rulesets.TotalCount
+		repository.Rulesets.PageInfo = page.Rulesets.PageInfo
+	}
+
+	return nil
+}
+
+func rulesetsTruncated(rulesets Rulesets) bool {
+	if rulesets.PageInfo.HasNextPage {
+		return true
+	}
+
+	for _, ruleset := range rulesets.Nodes {
+		if ruleset.Rules.TotalCount > len(ruleset.Rules.Nodes) ||
+			ruleset.BypassActors.TotalCount > len(ruleset.BypassActors.Nodes) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func reportRequest(queryString string) *graphqlclient.Request {
 	authStr := fmt.Sprintf("bearer %s", config.Token)
 
@@ -217,6 +438,22 @@ func (r *reportGetterService) getReport() ([]ReportResponse, error) {
 			return allResults, nil
 		}
 
+		for key := range respData.Organization.Repositories.Nodes {
+			if err := branchProtectionRulesGetRemaining(
+				&respData.Organization.Repositories.Nodes[key],
+				r.sender,
+			); err != nil {
+				return allResults, fmt.Errorf("%w", err)
+			}
+
+			if err := rulesetsGetRemaining(
+				&respData.Organization.Repositories.Nodes[key],
+				r.sender,
+			); err != nil {
+				return allResults, fmt.Errorf("%w", err)
+			}
+		}
+
 		if len(respData.Organization.Repositories.Nodes) > 0 {
 			allResults = append(allResults, respData)
 		}
@@ -2,19 +2,59 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github-admin-tool/graphqlclient"
 	"github-admin-tool/progressbar"
 	"strings"
 )
 
+var errTeamNotFound = errors.New("team not found")
+
+// reportAccess returns the repository permissions for each team, keyed by team slug and then repository name.
 type reportAccess interface {
-	getReport() (map[string]string, error)
+	getReport() (map[string]map[string]string, error)
+}
+
+type reportAccessService struct {
+	teams    []string
+	allTeams bool
 }
 
-type reportAccessService struct{}
+func (r *reportAccessService) getReport() (map[string]map[string]string, error) {
+	teamAccess := make(map[string]map[string]string)
+
+	if dryRun || (len(r.teams) == 0 && !r.allTeams) {
+		return teamAccess, nil
+	}
+
+	client := graphqlclient.NewClient()
+	ctx := context.Background()
+
+	teams := r.teams
+
+	if r.allTeams {
+		var err error
+
+		if teams, err = reportAccessAllTeams(ctx, client); err != nil {
+			return teamAccess, fmt.Errorf("%w", err)
+		}
+	}
+
+	for _, team := range teams {
+		permissions, err := reportAccessTeam(ctx, client, team)
+		if err != nil {
+			return teamAccess, fmt.Errorf("%w", err)
+		}
 
-func (r *reportAccessService) getReport() (map[string]string, error) {
+		teamAccess[team] = permissions
+	}
+
+	return teamAccess, nil
+}
+
+// reportAccessTeam returns the permission the team with exactly this slug has on each of its repositories.
+func reportAccessTeam(ctx context.Context, client *graphqlclient.Client, team string) (map[string]string, error) {
 	var (
 		cursor           *string
 		totalRecordCount int
@@ -22,35 +62,32 @@ func (r *reportAccessService) getReport() (map[string]string, error) {
 		bar              progressbar.Bar
 	)
 
-	client := graphqlclient.NewClient()
 	query := reportAccessQuery()
 	req := reportRequest(query)
-	ctx := context.Background()
 	iteration = 0
 
-	adminAccess := make(map[string]string)
-
-	if config.Team == "" || dryRun {
-		return adminAccess, nil
-	}
+	permissions := make(map[string]string)
 
 	for {
 		// Set new cursor on every loop to paginate through 100 at a time
 		req.Var("after", cursor)
-		req.Var("team", config.Team)
+		req.Var("team", team)
 
-		var respData OrganizationTeamsResponse
+		var respData OrganizationTeamResponse
 		if err := client.Run(ctx, req, &respData); err != nil {
-			return adminAccess, fmt.Errorf("graphql call: %w", err)
+			return permissions, fmt.Errorf("graphql call: %w", err)
 		}
 
-		teamNode := respData.OrganizationTeams.Teams.TeamNodes[0]
+		teamNode := respData.Organization.Team
+		if teamNode == nil {
+			return permissions, fmt.Errorf("%w: %s", errTeamNotFound, team)
+		}
 
 		cursor = &teamNode.TeamRepositories.PageInfo.EndCursor
 		totalRecordCount = teamNode.TeamRepositories.TotalCount
 
 		for _, value := range teamNode.TeamRepositories.Edges {
-			adminAccess[value.Node.Name] = value.Permission
+			permissions[value.Node.Name] = value.Permission
 		}
 
 		if iteration == 0 {
@@ -68,9 +105,41 @@ func (r *reportAccessService) getReport() (map[string]string, error) {
 		}
 	}
 
-	bar.Finish("Get team permissions")
+	bar.Finish(fmt.Sprintf("Get team permissions (%s)", team))
+
+	return permissions, nil
+}
+
+// reportAccessAllTeams returns the slugs of every team in the organisation.
+func reportAccessAllTeams(ctx context.Context, client *graphqlclient.Client) ([]string, error) {
+	var (
+		cursor *string
+		teams  []string
+	)
+
+	req := reportRequest(reportAccessTeamsQuery())
 
-	return adminAccess, nil
+	for {
+		// Set new cursor on every loop to paginate through 100 at a time
+		req.Var("after", cursor)
+
+		var respData OrganizationTeamsResponse
+		if err := client.Run(ctx, req, &respData); err != nil {
+			return teams, fmt.Errorf("graphql call: %w", err)
+		}
+
+		cursor = &respData.OrganizationTeams.Teams.PageInfo.EndCursor
+
+		for _, teamNode := range respData.OrganizationTeams.Teams.TeamNodes {
+			teams = append(teams, teamNode.Slug)
+		}
+
+		if !respData.OrganizationTeams.Teams.PageInfo.HasNextPage {
+			break
+		}
+	}
+
+	return teams, nil
 }
 
 func reportAccessQuery() string {
@@ -78,20 +147,19 @@ func reportAccessQuery() string {
 
 	query.WriteString("query ($org: String! $after: String $team: String!) {")
 	query.WriteString("		organization(login:$org) {")
-	query.WriteString("			teams(query:$team, first: 1) {")
-	query.WriteString("				nodes {")
-	query.WriteString("					repositories(first: 100, after: $after) {")
-	query.WriteString("						totalCount")
-	query.WriteString("						pageInfo {")
-	query.WriteString("							endCursor")
-	query.WriteString("							hasNextPage")
-	query.WriteString("						}")
-	query.WriteString("						edges {")
-	query.WriteString("							node {")
-	query.WriteString("								name")
-	query.WriteString("							}")
-	query.WriteString("							permission")
+	query.WriteString("			team(slug:$team) {")
+	query.WriteString("				slug")
+	query.WriteString("				repositories(first: 100, after: $after) {")
+	query.WriteString("					totalCount")
+	query.WriteString("					pageInfo {")
+	query.WriteString("						endCursor")
+	query.WriteString("						hasNextPage")
+	query.WriteString("					}")
+	query.WriteString("					edges {")
+	query.WriteString("						node {")
+	query.WriteString("							name")
 	query.WriteString("						}")
+	query.WriteString("						permission")
 	query.WriteString("					}")
 	query.WriteString("				}")
 	query.WriteString("			}")
@@ -100,3 +168,23 @@ func reportAccessQuery() string {
 
 	return query.String()
 }
+
+func reportAccessTeamsQuery() string {
+	var query strings.Builder
+
+	query.WriteString("query ($org: String! $after: String) {")
+	query.WriteString("		organization(login:$org) {")
+	query.WriteString("			teams(first: 100, after: $after, orderBy: {field: NAME, direction: ASC}) {")
+	query.WriteString("				pageInfo {")
+	query.WriteString("					endCursor")
+	query.WriteString("					hasNextPage")
+	query.WriteString("				}")
+	query.WriteString("				nodes {")
+	query.WriteString("					slug")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("		}")
+	query.WriteString("	}")
+
+	return query.String()
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"net/http"
+	"os"
 	"reflect"
 	"testing"
 
@@ -9,73 +11,104 @@ import (
 
 func Test_reportAccessService_getReport(t *testing.T) {
 	originalDryRun := dryRun
-	originalTeamValue := config.Team
 
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
 	defer func() {
 		dryRun = originalDryRun
-		config.Team = originalTeamValue
 	}()
 
+	mockFile := func(filePath string) string {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("failed to read test data: %v", err)
+		}
+
+		return string(content)
+	}
+
+	mockTeamAccess := map[string]string{
+		"some-repo-1": "ADMIN",
+		"some-repo-2": "WRITE",
+		"some-repo-3": "ADMIN",
+		"some-repo-4": "READ",
+	}
+
 	tests := []struct {
-		name               string
-		r                  *reportAccessService
-		want               map[string]string
-		wantErr            bool
-		dryRunValue        bool
-		teamValue          string
-		mockHTTPReturnFile string
-		mockHTTPStatus     int
+		name          string
+		r             *reportAccessService
+		want          map[string]map[string]string
+		wantErr       bool
+		dryRunValue   bool
+		mockResponses []string
+		mockHTTPCode  int
 	}{
 		{
-			name:        "getReport no config.team returns blank",
-			want:        make(map[string]string),
-			wantErr:     false,
-			dryRunValue: false,
+			name:    "getReport no teams returns blank",
+			r:       &reportAccessService{},
+			want:    make(map[string]map[string]string),
+			wantErr: false,
 		},
 		{
 			name:        "getReport dry run on",
-			want:        make(map[string]string),
+			r:           &reportAccessService{teams: []string{"some-team"}},
+			want:        make(map[string]map[string]string),
 			wantErr:     false,
 			dryRunValue: true,
 		},
 		{
-			name:               "getReport response error",
-			want:               make(map[string]string),
-			wantErr:            true,
-			teamValue:          "some-org",
-			dryRunValue:        false,
-			mockHTTPReturnFile: "testdata/mockAccessResponseError.json",
-			mockHTTPStatus:     400,
+			name:          "getReport response error",
+			r:             &reportAccessService{teams: []string{"some-team"}},
+			want:          make(map[string]map[string]string),
+			wantErr:       true,
+			mockResponses: []string{"testdata/mockAccessResponseError.json"},
+			mockHTTPCode:  http.StatusBadRequest,
 		},
 		{
-			name: "getReport response success",
-			want: map[string]string{
-				"some-repo-1": "ADMIN",
-				"some-repo-2": "WRITE",
-				"some-repo-3": "ADMIN",
-				"some-repo-4": "READ",
+			name:          "getReport team not found",
+			r:             &reportAccessService{teams: []string{"some-team"}},
+			want:          make(map[string]map[string]string),
+			wantErr:       true,
+			mockResponses: []string{"testdata/mockAccessTeamNotFoundResponse.json"},
+			mockHTTPCode:  http.StatusOK,
+		},
+		{
+			name:          "getReport response success",
+			r:             &reportAccessService{teams: []string{"some-team"}},
+			want:          map[string]map[string]string{"some-team": mockTeamAccess},
+			wantErr:       false,
+			mockResponses: []string{"testdata/mockAccessResponse.json"},
+			mockHTTPCode:  http.StatusOK,
+		},
+		{
+			name: "getReport all teams success",
+			r:    &reportAccessService{allTeams: true},
+			want: map[string]map[string]string{"some-team": mockTeamAccess},
+			mockResponses: []string{
+				"testdata/mockAccessTeamsResponse.json",
+				"testdata/mockAccessResponse.json",
 			},
-			wantErr:            false,
-			teamValue:          "some-org",
-			dryRunValue:        false,
-			mockHTTPReturnFile: "testdata/mockAccessResponse.json",
-			mockHTTPStatus:     200,
+			mockHTTPCode: http.StatusOK,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.mockHTTPReturnFile != "" {
-				mockHTTPResponder("POST", "https://api.github.com/graphql", tt.mockHTTPReturnFile, tt.mockHTTPStatus)
-			}
+			if len(tt.mockResponses) > 0 {
+				responses := make([]*http.Response, 0, len(tt.mockResponses))
+				for _, mockResponse := range tt.mockResponses {
+					responses = append(responses, httpmock.NewStringResponse(tt.mockHTTPCode, mockFile(mockResponse)))
+				}
 
-			r := &reportAccessService{}
+				httpmock.RegisterResponder(
+					"POST",
+					"https://api.github.com/graphql",
+					httpmock.ResponderFromMultipleResponses(responses),
+				)
+			}
 
 			dryRun = tt.dryRunValue
-			config.Team = tt.teamValue
-			got, err := r.getReport()
+			got, err := tt.r.getReport()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("reportAccessService.getReport() error = %v, wantErr %v", err, tt.wantErr)
 
@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/graphqlclient"
+	"github-admin-tool/progressbar"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	collaboratorTypeOutside    = "outside collaborator"
+	collaboratorTypeMember     = "direct member"
+	collaboratorTypeInvitation = "pending invitation"
+)
+
+var (
+	errReportFileType      = errors.New("file-type must be csv or json")
+	reportCollaboratorsCmd = &cobra.Command{ // nolint // needed for cobra
+		Use:   "report-collaborators",
+		Short: "Run a report to generate a csv containing users granted access to organisation repos outside of teams",
+		RunE:  reportCollaboratorsRun,
+	}
+)
+
+type reportCollaborators struct {
+	reportCollaboratorsGetter reportCollaboratorsGetter
+	reportCSV                 reportCSV
+	reportJSON                reportJSON
+	dryRun                    bool
+	ignoreArchived            bool
+	filePath                  string
+	fileType                  string
+}
+
+type reportCollaboratorsGetter interface {
+	getRepositoryList(*reportCollaborators) ([]string, error)
+	getCollaborators([]string) ([]Collaborators, error)
+}
+
+type reportCollaboratorsGetterService struct{}
+
+func reportCollaboratorsRun(cmd *cobra.Command, args []string) error {
+	report := &reportCollaborators{
+		reportCollaboratorsGetter: &reportCollaboratorsGetterService{},
+		reportCSV:                 &reportCSVService{},
+		reportJSON:                &reportJSONService{},
+	}
+
+	if err := reportCollaboratorsValidateFlags(report, cmd); err != nil {
+		return err
+	}
+
+	return reportCollaboratorsCreate(report)
+}
+
+func reportCollaboratorsValidateFlags(r *reportCollaborators, cmd *cobra.Command) error {
+	var err error
+
+	r.dryRun, err = cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.ignoreArchived, err = cmd.Flags().GetBool("ignore-archived")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.filePath, err = cmd.Flags().GetString("file-path")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.fileType, err = cmd.Flags().GetString("file-type")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if r.fileType != "csv" && r.fileType != "json" {
+		return fmt.Errorf("%w: %s", errReportFileType, r.fileType)
+	}
+
+	return nil
+}
+
+func reportCollaboratorsCreate(r *reportCollaborators) error {
+	repositoryList, err := r.reportCollaboratorsGetter.getRepositoryList(r)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if r.dryRun {
+		return nil
+	}
+
+	allCollaborators, err := r.reportCollaboratorsGetter.getCollaborators(repositoryList)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if r.fileType == "json" {
+		jsonReport, err := r.reportJSON.generateCollaborators(allCollaborators)
+		if err != nil {
+			return fmt.Errorf("generate json failed: %w", err)
+		}
+
+		if err := r.reportJSON.uploader(r.filePath, jsonReport); err != nil {
+			return fmt.Errorf("upload json failed: %w", err)
+		}
+
+		return nil
+	}
+
+	lines := reportCSVCollaboratorsGenerate(allCollaborators)
+	if err := reportCSVUpload(r.reportCSV, r.filePath, lines); err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reportCollaboratorsGetterService) getRepositoryList(report *reportCollaborators) ([]string, error) {
+	var (
+		cursor     *string
+		totalCount int
+		result     []string
+		iteration  int
+		bar        progressbar.Bar
+	)
+
+	client := graphqlclient.NewClient()
+	req := reportRequest(reportWebhookQuery())
+	ctx := context.Background()
+
+	for {
+		// Set new cursor on every loop to paginate through 100 at a time
+		req.Var("after", cursor)
+
+		var response WebhookRepositoryResponse
+		if err := client.Run(ctx, req, &response); err != nil {
+			return result, fmt.Errorf("graphql call: %w", err)
+		}
+
+		cursor = &response.Organization.Repositories.PageInfo.EndCursor
+		totalCount = response.Organization.Repositories.TotalCount
+
+		if report.dryRun {
+			log.Printf("This is a dry run, the report would process %d records\n", totalCount)
+
+			return result, nil
+		}
+
+		for _, node := range response.Organization.Repositories.Nodes {
+			if report.ignoreArchived && node.IsArchived {
+				continue
+			}
+
+			result = append(result, node.Name)
+		}
+
+		if iteration == 0 {
+			bar.NewOption(0, totalCount)
+		}
+
+		bar.Play(iteration)
+
+		iteration += IterationCount
+
+		if !response.Organization.Repositories.PageInfo.HasNextPage {
+			break
+		}
+	}
+
+	bar.Play(totalCount)
+	bar.Finish("Get repository data")
+
+	return result, nil
+}
+
+// getCollaborators returns the users with direct access and the pending invitations for each repository.
+// Direct collaborators that are not organisation members are reported as outside collaborators.
+func (r *reportCollaboratorsGetterService) getCollaborators(repositories []string) ([]Collaborators, error) {
+	var (
+		allResults []Collaborators
+		bar        progressbar.Bar
+	)
+
+	ctx := context.Background()
+
+	outsideCollaborators, err := reportCollaboratorsOutside(ctx)
+	if err != nil {
+		return allResults, err
+	}
+
+	bar.NewOption(0, len(repositories))
+
+	for iteration, repositoryName := range repositories {
+		bar.Play(iteration)
+
+		collaborators, err := reportCollaboratorsDirect(ctx, repositoryName, outsideCollaborators)
+		if err != nil {
+			return allResults, err
+		}
+
+		invitations, err := reportCollaboratorsInvitations(ctx, repositoryName)
+		if err != nil {
+			return allResults, err
+		}
+
+		allResults = append(
+			allResults,
+			Collaborators{RepositoryName: repositoryName, Collaborators: append(collaborators, invitations...)},
+		)
+	}
+
+	bar.Play(len(repositories))
+	bar.Finish("Get collaborator data")
+
+	return allResults, nil
+}
+
+// reportCollaboratorsOutside returns the logins of every outside collaborator in the organisation.
+func reportCollaboratorsOutside(ctx context.Context) (map[string]bool, error) {
+	outsideCollaborators := make(map[string]bool)
+
+	for page := 1; ; page++ {
+		client := restclient.NewClient(
+			fmt.Sprintf("/orgs/%s/outside_collaborators?per_page=100&page=%d", config.Org, page),
+			config.Token,
+			http.MethodGet,
+		)
+
+		var response []CollaboratorResponse
+		if err := client.Run(ctx, &response); err != nil {
+			return outsideCollaborators, fmt.Errorf("list outside collaborators: %w", err)
+		}
+
+		for _, collaborator := range response {
+			outsideCollaborators[collaborator.Login] = true
+		}
+
+		if len(response) < IterationCount {
+			return outsideCollaborators, nil
+		}
+	}
+}
+
+func reportCollaboratorsDirect(
+	ctx context.Context,
+	repositoryName string,
+	outsideCollaborators map[string]bool,
+) ([]Collaborator, error) {
+	var collaborators []Collaborator
+
+	for page := 1; ; page++ {
+		client := restclient.NewClient(
+			fmt.Sprintf(
+				"/repos/%s/%s/collaborators?affiliation=direct&per_page=100&page=%d",
+				config.Org,
+				repositoryName,
+				page,
+			),
+			config.Token,
+			http.MethodGet,
+		)
+
+		var response []CollaboratorResponse
+		if err := client.Run(ctx, &response); err != nil {
+			return collaborators, fmt.Errorf("list collaborators for %s: %w", repositoryName, err)
+		}
+
+		for _, collaborator := range response {
+			collaboratorType := collaboratorTypeMember
+			if outsideCollaborators[collaborator.Login] {
+				collaboratorType = collaboratorTypeOutside
+			}
+
+			collaborators = append(
+				collaborators,
+				Collaborator{Login: collaborator.Login, Type: collaboratorType, Permission: collaborator.RoleName},
+			)
+		}
+
+		if len(response) < IterationCount {
+			return collaborators, nil
+		}
+	}
+}
+
+func reportCollaboratorsInvitations(ctx context.Context, repositoryName string) ([]Collaborator, error) {
+	var invitations []Collaborator
+
+	for page := 1; ; page++ {
+		client := restclient.NewClient(
+			fmt.Sprintf("/repos/%s/%s/invitations?per_page=100&page=%d", config.Org, repositoryName, page),
+			config.Token,
+			http.MethodGet,
+		)
+
+		var response []InvitationResponse
+		if err := client.Run(ctx, &response); err != nil {
+			return invitations, fmt.Errorf("list invitations for %s: %w", repositoryName, err)
+		}
+
+		for _, invitation := range response {
+			invitations = append(
+				invitations,
+				Collaborator{
+					Login:      invitation.Invitee.Login,
+					Type:       collaboratorTypeInvitation,
+					Permission: invitation.Permissions,
+				},
+			)
+		}
+
+		if len(response) < IterationCount {
+			return invitations, nil
+		}
+	}
+}
+
+// nolint // needed for cobra
+func init() {
+	reportCollaboratorsCmd.Flags().BoolP("ignore-archived", "i", true, "Ignore archived repositories")
+	reportCollaboratorsCmd.Flags().StringP(
+		"file-path", "f", "collaborators.csv", "file path for report to be created, must be .csv or .json",
+	)
+	reportCollaboratorsCmd.Flags().StringP("file-type", "t", "csv", "file type, must be csv or json")
+	rootCmd.AddCommand(reportCollaboratorsCmd)
+}
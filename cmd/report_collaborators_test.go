@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_reportCollaboratorsValidateFlags(t *testing.T) {
+	cmdInvalidDryRun := &cobra.Command{Use: "report-collaborators"}
+
+	cmdInvalidFileType := &cobra.Command{Use: "report-collaborators"}
+	cmdInvalidFileType.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdInvalidFileType.Flags().BoolP("ignore-archived", "i", false, "ignore-archived flag")
+	cmdInvalidFileType.Flags().StringP("file-path", "f", "collaborators.csv", "file path")
+
+	cmdUnknownFileType := &cobra.Command{Use: "report-collaborators"}
+	cmdUnknownFileType.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdUnknownFileType.Flags().BoolP("ignore-archived", "i", false, "ignore-archived flag")
+	cmdUnknownFileType.Flags().StringP("file-path", "f", "collaborators.csv", "file path")
+	cmdUnknownFileType.Flags().StringP("file-type", "t", "xlsx", "file type")
+
+	cmdValid := &cobra.Command{Use: "report-collaborators"}
+	cmdValid.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdValid.Flags().BoolP("ignore-archived", "i", true, "ignore-archived flag")
+	cmdValid.Flags().StringP("file-path", "f", "collaborators.csv", "file path")
+	cmdValid.Flags().StringP("file-type", "t", "json", "file type")
+
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		want    *reportCollaborators
+		wantErr bool
+	}{
+		{
+			name:    "reportCollaboratorsValidateFlags dry run failure",
+			cmd:     cmdInvalidDryRun,
+			want:    &reportCollaborators{},
+			wantErr: true,
+		},
+		{
+			name:    "reportCollaboratorsValidateFlags file-type failure",
+			cmd:     cmdInvalidFileType,
+			want:    &reportCollaborators{filePath: "collaborators.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "reportCollaboratorsValidateFlags unknown file-type failure",
+			cmd:     cmdUnknownFileType,
+			want:    &reportCollaborators{filePath: "collaborators.csv", fileType: "xlsx"},
+			wantErr: true,
+		},
+		{
+			name: "reportCollaboratorsValidateFlags success",
+			cmd:  cmdValid,
+			want: &reportCollaborators{
+				ignoreArchived: true,
+				filePath:       "collaborators.csv",
+				fileType:       "json",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &reportCollaborators{}
+			if err := reportCollaboratorsValidateFlags(got, tt.cmd); (err != nil) != tt.wantErr {
+				t.Errorf("reportCollaboratorsValidateFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportCollaboratorsValidateFlags() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportCollaboratorsCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       *reportCollaborators
+		wantErr bool
+	}{
+		{
+			name: "reportCollaboratorsCreate fails to return repositories",
+			r: &reportCollaborators{
+				reportCollaboratorsGetter: &mockReportCollaboratorsGetterService{failRepoList: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportCollaboratorsCreate dry run success",
+			r: &reportCollaborators{
+				dryRun:                    true,
+				reportCollaboratorsGetter: &mockReportCollaboratorsGetterService{failCollaborators: true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportCollaboratorsCreate get collaborators fail",
+			r: &reportCollaborators{
+				reportCollaboratorsGetter: &mockReportCollaboratorsGetterService{failCollaborators: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportCollaboratorsCreate json generate fail",
+			r: &reportCollaborators{
+				reportCollaboratorsGetter: &mockReportCollaboratorsGetterService{},
+				reportJSON:                &mockReportJSON{failgenerate: true},
+				fileType:                  "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportCollaboratorsCreate json uploader fail",
+			r: &reportCollaborators{
+				reportCollaboratorsGetter: &mockReportCollaboratorsGetterService{},
+				reportJSON:                &mockReportJSON{failupload: true},
+				fileType:                  "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportCollaboratorsCreate json success",
+			r: &reportCollaborators{
+				reportCollaboratorsGetter: &mockReportCollaboratorsGetterService{},
+				reportJSON:                &mockReportJSON{},
+				fileType:                  "json",
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportCollaboratorsCreate csv upload fail",
+			r: &reportCollaborators{
+				reportCollaboratorsGetter: &mockReportCollaboratorsGetterService{},
+				reportCSV:                 &mockReportCSV{failOpen: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportCollaboratorsCreate csv success",
+			r: &reportCollaborators{
+				reportCollaboratorsGetter: &mockReportCollaboratorsGetterService{},
+				reportCSV:                 &mockReportCSV{},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reportCollaboratorsCreate(tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("reportCollaboratorsCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_reportCollaboratorsGetterService_getRepositoryList(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name               string
+		report             *reportCollaborators
+		mockHTTPReturnFile string
+		mockHTTPStatusCode int
+		want               []string
+		wantErr            bool
+	}{
+		{
+			name:               "getRepositoryList fails graphql call",
+			report:             &reportCollaborators{},
+			mockHTTPReturnFile: "testdata/mockEmptyResponse.json",
+			mockHTTPStatusCode: 401,
+			wantErr:            true,
+		},
+		{
+			name:               "getRepositoryList dry run",
+			report:             &reportCollaborators{dryRun: true},
+			mockHTTPReturnFile: "testdata/mockGraphqlWebhookRepoWithArchivedResponse.json",
+			mockHTTPStatusCode: 200,
+			wantErr:            false,
+		},
+		{
+			name:               "getRepositoryList with ignore archived",
+			report:             &reportCollaborators{ignoreArchived: true},
+			mockHTTPReturnFile: "testdata/mockGraphqlWebhookRepoWithArchivedResponse.json",
+			mockHTTPStatusCode: 200,
+			want:               []string{"repo2"},
+			wantErr:            false,
+		},
+		{
+			name:               "getRepositoryList including archived",
+			report:             &reportCollaborators{},
+			mockHTTPReturnFile: "testdata/mockGraphqlWebhookRepoWithArchivedResponse.json",
+			mockHTTPStatusCode: 200,
+			want:               []string{"repo1", "repo2"},
+			wantErr:            false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTPResponder("POST", "https://api.github.com/graphql", tt.mockHTTPReturnFile, tt.mockHTTPStatusCode)
+
+			r := &reportCollaboratorsGetterService{}
+			got, err := r.getRepositoryList(tt.report)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reportCollaboratorsGetterService.getRepositoryList() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportCollaboratorsGetterService.getRepositoryList() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportCollaboratorsGetterService_getCollaborators(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	outsideURL := "https://api.github.com/orgs/some-org/outside_collaborators?per_page=100&page=1"
+	collaboratorsURL := "https://api.github.com/repos/some-org/repo1/collaborators?affiliation=direct&per_page=100&page=1"
+	invitationsURL := "https://api.github.com/repos/some-org/repo1/invitations?per_page=100&page=1"
+
+	tests := []struct {
+		name              string
+		outsideStatus     int
+		collaboratorsFile string
+		invitationsStatus int
+		want              []Collaborators
+		wantErr           string
+	}{
+		{
+			name:          "getCollaborators is forbidden to list outside collaborators",
+			outsideStatus: 403,
+			wantErr: "list outside collaborators: forbidden status " +
+				"(You must be an organization owner to list outside collaborators.), " + outsideURL,
+		},
+		{
+			name:          "getCollaborators fails to list outside collaborators",
+			outsideStatus: 500,
+			wantErr: "list outside collaborators: incorrect status: returned a non-200 status code '500', " +
+				outsideURL,
+		},
+		{
+			name:              "getCollaborators fails to list invitations",
+			outsideStatus:     200,
+			collaboratorsFile: "testdata/mockCollaboratorsResponse.json",
+			invitationsStatus: 404,
+			wantErr:           "list invitations for repo1: not found status, " + invitationsURL,
+		},
+		{
+			name:              "getCollaborators success",
+			outsideStatus:     200,
+			collaboratorsFile: "testdata/mockCollaboratorsResponse.json",
+			invitationsStatus: 200,
+			want: []Collaborators{{
+				RepositoryName: "repo1",
+				Collaborators: []Collaborator{
+					{Login: "outside-user", Type: collaboratorTypeOutside, Permission: "write"},
+					{Login: "member-user", Type: collaboratorTypeMember, Permission: "admin"},
+					{Login: "invited-user", Type: collaboratorTypeInvitation, Permission: "read"},
+				},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			outsideFile := "testdata/mockRest404Response.json"
+
+			switch tt.outsideStatus {
+			case 200:
+				outsideFile = "testdata/mockOutsideCollaboratorsResponse.json"
+			case 403:
+				outsideFile = "testdata/mockOutsideCollaboratorsForbiddenResponse.json"
+			}
+
+			mockHTTPResponder("GET", outsideURL, outsideFile, tt.outsideStatus)
+
+			if tt.collaboratorsFile != "" {
+				mockHTTPResponder("GET", collaboratorsURL, tt.collaboratorsFile, 200)
+			}
+
+			if tt.invitationsStatus != 0 {
+				invitationsFile := "testdata/mockInvitationsResponse.json"
+				if tt.invitationsStatus != 200 {
+					invitationsFile = "testdata/mockRest404Response.json"
+				}
+
+				mockHTTPResponder("GET", invitationsURL, invitationsFile, tt.invitationsStatus)
+			}
+
+			r := &reportCollaboratorsGetterService{}
+			got, err := r.getCollaborators([]string{"repo1"})
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("reportCollaboratorsGetterService.getCollaborators() error = %v, wantErr %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("reportCollaboratorsGetterService.getCollaborators() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportCollaboratorsGetterService.getCollaborators() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -31,10 +32,10 @@ func (r *reportCSVService) writer(file *os.File, lines [][]string) error {
 	writer := csv.NewWriter(file)
 
 	if err := writer.WriteAll(lines); err != nil {
-		return fmt.Errorf("failed to write to file %s: %w", filePath, err)
+		return fmt.Errorf("failed to write to file %s: %w", file.Name(), err)
 	}
 
-	log.Printf("Report written to %s", filePath)
+	log.Printf("Report written to %s", file.Name())
 
 	return nil
 }
@@ -52,21 +53,31 @@ func reportCSVUpload(service reportCSV, filePath string, lines [][]string) error
 	return nil
 }
 
-func reportCSVGenerate(ignoreArchived bool, allResults []ReportResponse, teamAccess map[string]string) [][]string {
-	parsed := reportCSVParse(ignoreArchived, allResults, teamAccess)
-	lines := reportCSVLines(parsed)
+func reportCSVGenerate(
+	ignoreArchived bool,
+	allResults []ReportResponse,
+	teamAccess map[string]map[string]string,
+) [][]string {
+	teams := reportTeamSlugs(teamAccess)
+	parsed := reportCSVParse(ignoreArchived, allResults, teams, teamAccess)
+	lines := reportCSVLines(parsed, teams)
 
 	return lines
 }
 
-func reportCSVWebhookGenerate(webhooks []Webhooks) [][]string {
-	parsed := reportCSVWebhookParse(webhooks)
-	lines := reportCSVWebhookLines(parsed)
+func reportCSVWebhookGenerate(webhooks []Webhooks, deliveries bool) [][]string {
+	parsed := reportCSVWebhookParse(webhooks, deliveries)
+	lines := reportCSVWebhookLines(parsed, deliveries)
 
 	return lines
 }
 
-func reportCSVParse(ignoreArchived bool, allResults []ReportResponse, teamAccess map[string]string) [][]string {
+func reportCSVParse(
+	ignoreArchived bool,
+	allResults []ReportResponse,
+	teams []string,
+	teamAccess map[string]map[string]string,
+) [][]string {
 	var parsed [][]string
 
 	for _, allData := range allResults {
@@ -87,9 +98,15 @@ func reportCSVParse(ignoreArchived bool, allResults []ReportResponse, teamAccess
 				strconv.FormatBool(repo.MergeCommitAllowed),
 				strconv.FormatBool(repo.SquashMergeAllowed),
 				strconv.FormatBool(repo.RebaseMergeAllowed),
-				strings.TrimSpace(teamAccess[repo.Name]),
 			}
 
+			// One column per team, in the same order as the header
+			for _, team := range teams {
+				repoSlice = append(repoSlice, strings.TrimSpace(teamAccess[team][repo.Name]))
+			}
+
+			repoSlice = append(repoSlice, reportCSVRulesets(repo.Rulesets))
+
 			for _, protection := range repo.BranchProtectionRules.Nodes {
 				repoSlice = append(repoSlice,
 					strconv.FormatBool(protection.IsAdminEnforced),
@@ -114,53 +131,83 @@ func reportCSVParse(ignoreArchived bool, allResults []ReportResponse, teamAccess
 	return parsed
 }
 
-func reportCSVLines(parsed [][]string) [][]string {
-	lines := [][]string{
-		{
-			"Repo Name",
-			"Default Branch Name",
-			"Is Archived",
-			"Is Private",
-			"Is Empty",
-			"Is Fork",
-			"Has Wiki Enabled",
-			"Parent Repo Name",
-			"Merge Commit Allowed",
-			"Squash Merge Allowed",
-			"Rebase Merge Allowed",
-			"Team Permissions",
-			"(BP1) IsAdminEnforced",
-			"(BP1) RequiresCommitSignatures",
-			"(BP1) RestrictsPushes",
-			"(BP1) RequiresApprovingReviews",
-			"(BP1) RequiresStatusChecks",
-			"(BP1) RequiresCodeOwnerReviews",
-			"(BP1) DismissesStaleReviews",
-			"(BP1) RequiresStrictStatusChecks",
-			"(BP1) RequiredApprovingReviewCount",
-			"(BP1) AllowsForcePushes",
-			"(BP1) AllowsDeletions",
-			"(BP1) Branch Protection Pattern",
-			"(BP2) IsAdminEnforced",
-			"(BP2) RequiresCommitSignatures",
-			"(BP2) RestrictsPushes",
-			"(BP2) RequiresApprovingReviews",
-			"(BP2) RequiresStatusChecks",
-			"(BP2) RequiresCodeOwnerReviews",
-			"(BP2) DismissesStaleReviews",
-			"(BP2) RequiresStrictStatusChecks",
-			"(BP2) RequiredApprovingReviewCount",
-			"(BP2) AllowsForcePushes",
-			"(BP2) AllowsDeletions",
-			"(BP2) Branch Protection Pattern",
-		},
+func reportCSVRulesets(rulesets Rulesets) string {
+	names := make([]string, 0, len(rulesets.Nodes))
+
+	for _, ruleset := range rulesets.Nodes {
+		names = append(names, fmt.Sprintf("%s (%s)", strings.TrimSpace(ruleset.Name), ruleset.Enforcement))
 	}
+
+	return strings.Join(names, "; ")
+}
+
+func reportCSVLines(parsed [][]string, teams []string) [][]string {
+	header := []string{
+		"Repo Name",
+		"Default Branch Name",
+		"Is Archived",
+		"Is Private",
+		"Is Empty",
+		"Is Fork",
+		"Has Wiki Enabled",
+		"Parent Repo Name",
+		"Merge Commit Allowed",
+		"Squash Merge Allowed",
+		"Rebase Merge Allowed",
+	}
+
+	for _, team := range teams {
+		header = append(header, fmt.Sprintf("Team Permission (%s)", team))
+	}
+
+	header = append(
+		header,
+		"Rulesets",
+		"(BP1) IsAdminEnforced",
+		"(BP1) RequiresCommitSignatures",
+		"(BP1) RestrictsPushes",
+		"(BP1) RequiresApprovingReviews",
+		"(BP1) RequiresStatusChecks",
+		"(BP1) RequiresCodeOwnerReviews",
+		"(BP1) DismissesStaleReviews",
+		"(BP1) RequiresStrictStatusChecks",
+		"(BP1) RequiredApprovingReviewCount",
+		"(BP1) AllowsForcePushes",
+		"(BP1) AllowsDeletions",
+		"(BP1) Branch Protection Pattern",
+		"(BP2) IsAdminEnforced",
+		"(BP2) RequiresCommitSignatures",
+		"(BP2) RestrictsPushes",
+		"(BP2) RequiresApprovingReviews",
+		"(BP2) RequiresStatusChecks",
+		"(BP2) RequiresCodeOwnerReviews",
+		"(BP2) DismissesStaleReviews",
+		"(BP2) RequiresStrictStatusChecks",
+		"(BP2) RequiredApprovingReviewCount",
+		"(BP2) AllowsForcePushes",
+		"(BP2) AllowsDeletions",
+		"(BP2) Branch Protection Pattern",
+	)
+
+	lines := [][]string{header}
 	lines = append(lines, parsed...)
 
 	return lines
 }
 
-func reportCSVWebhookParse(allResults []Webhooks) [][]string {
+// reportTeamSlugs returns the teams in the report sorted so the columns are in a stable order.
+func reportTeamSlugs(teamAccess map[string]map[string]string) []string {
+	teams := make([]string, 0, len(teamAccess))
+	for team := range teamAccess {
+		teams = append(teams, team)
+	}
+
+	sort.Strings(teams)
+
+	return teams
+}
+
+func reportCSVWebhookParse(allResults []Webhooks, deliveries bool) [][]string {
 	var parsed [][]string
 
 	for _, webhooks := range allResults {
@@ -172,6 +219,11 @@ func reportCSVWebhookParse(allResults []Webhooks) [][]string {
 				strconv.FormatBool(webhook.Active),
 				strconv.Itoa(webhook.Config.InsecureURL),
 				fmt.Sprintf("%+v", webhook.Events),
+				webhooks.Scope,
+			}
+
+			if deliveries {
+				repoSlice = append(repoSlice, reportCSVWebhookDeliveryHealth(webhook.Deliveries)...)
 			}
 
 			parsed = append(parsed, repoSlice)
@@ -181,18 +233,251 @@ func reportCSVWebhookParse(allResults []Webhooks) [][]string {
 	return parsed
 }
 
-func reportCSVWebhookLines(parsed [][]string) [][]string {
+// reportCSVWebhookDeliveryHealth leaves the columns blank when the deliveries could not be fetched or there are none.
+func reportCSVWebhookDeliveryHealth(health *WebhookDeliveryHealth) []string {
+	if health == nil || health.Deliveries == 0 {
+		return []string{"", "", ""}
+	}
+
+	return []string{
+		strconv.Itoa(health.LastStatusCode),
+		health.LastSuccessAt,
+		strconv.FormatFloat(health.FailureRate, 'f', 2, 64),
+	}
+}
+
+func reportCSVWebhookLines(parsed [][]string, deliveries bool) [][]string {
+	header := []string{
+		"Repo Name",
+		"Webhook ID",
+		"Webhook URL",
+		"Is Active",
+		"Insecure URL",
+		"Events",
+		"Scope",
+	}
+
+	if deliveries {
+		header = append(header, "Last Delivery Status", "Last Success", "Failure Rate")
+	}
+
+	lines := [][]string{header}
+	lines = append(lines, parsed...)
+
+	return lines
+}
+
+func reportCSVWebhookFindingsGenerate(findings []WebhookFinding) [][]string {
 	lines := [][]string{
 		{
 			"Repo Name",
+			"Scope",
 			"Webhook ID",
 			"Webhook URL",
-			"Is Active",
-			"Insecure URL",
-			"Events",
+			"Finding",
 		},
 	}
-	lines = append(lines, parsed...)
+
+	for _, finding := range findings {
+		lines = append(lines, []string{
+			strings.TrimSpace(finding.RepositoryName),
+			finding.Scope,
+			strconv.Itoa(finding.WebhookID),
+			strings.TrimSpace(finding.URL),
+			finding.Finding,
+		})
+	}
+
+	return lines
+}
+
+func reportCSVCollaboratorsGenerate(allResults []Collaborators) [][]string {
+	lines := [][]string{
+		{
+			"Repo Name",
+			"Login",
+			"Type",
+			"Permission",
+		},
+	}
+
+	for _, collaborators := range allResults {
+		for _, collaborator := range collaborators.Collaborators {
+			lines = append(lines, []string{
+				strings.TrimSpace(collaborators.RepositoryName),
+				strings.TrimSpace(collaborator.Login),
+				collaborator.Type,
+				collaborator.Permission,
+			})
+		}
+	}
+
+	return lines
+}
+
+func reportCSVMembersGenerate(members []Member) [][]string {
+	lines := [][]string{
+		{
+			"Login",
+			"Name",
+			"Role",
+			"2FA Enabled",
+			"Teams",
+			"SAML Identity",
+		},
+	}
+
+	for _, member := range members {
+		// 2FA status is only returned to organisation owners
+		twoFactorEnabled := "unknown"
+		if member.TwoFactorEnabled != nil {
+			twoFactorEnabled = strconv.FormatBool(*member.TwoFactorEnabled)
+		}
+
+		lines = append(lines, []string{
+			strings.TrimSpace(member.Login),
+			strings.TrimSpace(member.Name),
+			member.Role,
+			twoFactorEnabled,
+			strings.Join(member.Teams, "; "),
+			strings.TrimSpace(member.SAMLIdentity),
+		})
+	}
+
+	return lines
+}
+
+func reportCSVSecurityGenerate(allStatus []SecurityStatus) [][]string {
+	lines := [][]string{
+		{
+			"Repo Name",
+			"Vulnerability Alerts",
+			"Automated Security Fixes",
+			"Secret Scanning",
+			"Push Protection",
+			"Code Scanning Default Setup",
+			"Private Vulnerability Reporting",
+			"Error",
+		},
+	}
+
+	for _, status := range allStatus {
+		lines = append(lines, []string{
+			strings.TrimSpace(status.RepositoryName),
+			status.VulnerabilityAlerts,
+			status.AutomatedSecurityFixes,
+			status.SecretScanning,
+			status.PushProtection,
+			status.CodeScanningDefaultSetup,
+			status.PrivateVulnerabilityReporting,
+			status.Error,
+		})
+	}
+
+	return lines
+}
+
+func reportCSVDependabotAlertsGenerate(summaries []DependabotAlertSummary) [][]string {
+	// The severity columns are in the order of dependabotAlertSeverities
+	lines := [][]string{
+		{
+			"Repo Name",
+			"Open Alerts",
+			"Critical",
+			"High",
+			"Medium",
+			"Low",
+			"Ecosystems",
+			"Oldest Critical Age (days)",
+		},
+	}
+
+	for _, summary := range summaries {
+		line := []string{strings.TrimSpace(summary.RepositoryName), strconv.Itoa(summary.OpenAlerts)}
+		for _, severity := range dependabotAlertSeverities {
+			line = append(line, strconv.Itoa(summary.Severities[severity]))
+		}
+
+		ecosystems := make([]string, 0, len(summary.Ecosystems))
+		for ecosystem, count := range summary.Ecosystems {
+			ecosystems = append(ecosystems, fmt.Sprintf("%s: %d", ecosystem, count))
+		}
+
+		sort.Strings(ecosystems)
+
+		oldestCriticalAge := ""
+		if summary.OldestCriticalAgeDays != nil {
+			oldestCriticalAge = strconv.Itoa(*summary.OldestCriticalAgeDays)
+		}
+
+		lines = append(lines, append(line, strings.Join(ecosystems, "; "), oldestCriticalAge))
+	}
+
+	return lines
+}
+
+func reportCSVDependabotAlertDetailsGenerate(details []DependabotAlertDetail) [][]string {
+	lines := [][]string{
+		{
+			"Repo Name",
+			"Alert Number",
+			"Severity",
+			"Ecosystem",
+			"Package",
+			"Manifest Path",
+			"GHSA ID",
+			"CVE ID",
+			"Created At",
+			"Age (days)",
+			"URL",
+		},
+	}
+
+	for _, detail := range details {
+		lines = append(lines, []string{
+			strings.TrimSpace(detail.RepositoryName),
+			strconv.Itoa(detail.Number),
+			detail.Severity,
+			detail.Ecosystem,
+			detail.Package,
+			detail.ManifestPath,
+			detail.GHSAID,
+			detail.CVEID,
+			detail.CreatedAt,
+			strconv.Itoa(detail.AgeDays),
+			detail.URL,
+		})
+	}
+
+	return lines
+}
+
+func reportCSVSecretAlertsGenerate(alerts []SecretScanningAlert) [][]string {
+	lines := [][]string{
+		{
+			"Repo Name",
+			"Alert Number",
+			"Secret Type",
+			"Secret Name",
+			"Created At",
+			"Push Protection Bypassed",
+			"Resolution",
+			"URL",
+		},
+	}
+
+	for _, alert := range alerts {
+		lines = append(lines, []string{
+			strings.TrimSpace(alert.RepositoryName),
+			strconv.Itoa(alert.Number),
+			alert.SecretType,
+			alert.SecretTypeDisplayName,
+			alert.CreatedAt,
+			strconv.FormatBool(alert.PushProtectionBypassed),
+			alert.Resolution,
+			alert.URL,
+		})
+	}
 
 	return lines
 }
@@ -15,11 +15,13 @@ func Test_reportCSVParse(t *testing.T) {
 	type args struct {
 		ignoreArchived bool
 		allResults     []ReportResponse
-		teamAccess     map[string]string
+		teamAccess     map[string]map[string]string
 	}
 
-	teamAccess := make(map[string]string, 1)
-	teamAccess["REPONAME2"] = "ADMIN"
+	teamAccess := map[string]map[string]string{
+		"platform": {"REPONAME2": "ADMIN"},
+		"security": {},
+	}
 
 	tests := []struct {
 		name string
@@ -88,7 +90,32 @@ func Test_reportCSVParse(t *testing.T) {
 
 			want: [][]string{{
 				"org/REPONAME2", "", "false", "false", "false", "false",
-				"false", "", "false", "false", "false", "ADMIN",
+				"false", "", "false", "false", "false", "ADMIN", "", "",
+			}},
+		},
+		{
+			name: "reportCSVParse with rulesets",
+			args: args{
+				ignoreArchived: true,
+				allResults: []ReportResponse{{
+					Organization{
+						Repositories{
+							Nodes: []RepositoriesNode{{
+								NameWithOwner: "org/REPONAME3",
+								Rulesets: Rulesets{
+									Nodes: []RulesetsNode{
+										{Name: "main protection", Enforcement: "ACTIVE"},
+										{Name: "tags", Enforcement: "EVALUATE"},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+			want: [][]string{{
+				"org/REPONAME3", "", "false", "false", "false", "false",
+				"false", "", "false", "false", "false", "main protection (ACTIVE); tags (EVALUATE)",
 			}},
 		},
 	}
@@ -98,6 +125,7 @@ func Test_reportCSVParse(t *testing.T) {
 			if got := reportCSVParse(
 				tt.args.ignoreArchived,
 				tt.args.allResults,
+				reportTeamSlugs(tt.args.teamAccess),
 				tt.args.teamAccess,
 			); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("reportCSVParse() = %v, want %v", got, tt.want)
@@ -143,7 +171,7 @@ func Test_reportCSVLines(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := reportCSVLines(tt.args.parsed); !reflect.DeepEqual(got, tt.want) {
+			if got := reportCSVLines(tt.args.parsed, nil); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("reportCSVLines() = %v, want %v", got, tt.want)
 			}
 		})
@@ -247,9 +275,13 @@ func Test_reportCSVGenerate(t *testing.T) {
 	type args struct {
 		ignoreArchived bool
 		allResults     []ReportResponse
-		teamAccess     map[string]string
+		teamAccess     map[string]map[string]string
 	}
 
+	mockTeamsHeader := append([]string{}, mockEmptyCSVReportRows[0][:11]...)
+	mockTeamsHeader = append(mockTeamsHeader, "Team Permission (platform)", "Team Permission (security)")
+	mockTeamsHeader = append(mockTeamsHeader, mockEmptyCSVReportRows[0][11:]...)
+
 	tests := []struct {
 		name string
 		r    *reportCSVService
@@ -260,6 +292,16 @@ func Test_reportCSVGenerate(t *testing.T) {
 			name: "generator returns lines",
 			want: mockEmptyCSVReportRows,
 		},
+		{
+			name: "generator returns a column per team",
+			args: args{
+				teamAccess: map[string]map[string]string{
+					"security": {},
+					"platform": {},
+				},
+			},
+			want: [][]string{mockTeamsHeader},
+		},
 	}
 
 	for _, tt := range tests {
@@ -277,7 +319,8 @@ func Test_reportCSVGenerate(t *testing.T) {
 
 func Test_reportCSVWebhookGenerate(t *testing.T) {
 	type args struct {
-		webhooks []Webhooks
+		webhooks   []Webhooks
+		deliveries bool
 	}
 
 	tests := []struct {
@@ -290,6 +333,7 @@ func Test_reportCSVWebhookGenerate(t *testing.T) {
 			args: args{
 				webhooks: []Webhooks{{
 					RepositoryName: "repo1",
+					Scope:          "repo",
 					Webhooks: []WebhookResponse{{
 						Config: WebhookResponseConfig{
 							URL: "some_url", InsecureURL: 0,
@@ -301,21 +345,348 @@ func Test_reportCSVWebhookGenerate(t *testing.T) {
 				}},
 			},
 			want: [][]string{
-				{"Repo Name", "Webhook ID", "Webhook URL", "Is Active", "Insecure URL", "Events"},
-				{"repo1", "0", "some_url", "false", "0", "[an_event]"},
+				{"Repo Name", "Webhook ID", "Webhook URL", "Is Active", "Insecure URL", "Events", "Scope"},
+				{"repo1", "0", "some_url", "false", "0", "[an_event]", "repo"},
+			},
+		},
+		{
+			name: "reportCSVWebhookGenerate with deliveries",
+			args: args{
+				webhooks: []Webhooks{{
+					RepositoryName: "repo1",
+					Scope:          "repo",
+					Webhooks: []WebhookResponse{
+						{
+							ID:     1,
+							Config: WebhookResponseConfig{URL: "some_url"},
+							Active: true,
+							Events: []string{"push"},
+							Deliveries: &WebhookDeliveryHealth{
+								Deliveries:     4,
+								LastStatusCode: 502,
+								LastSuccessAt:  "2026-10-01T09:00:00Z",
+								FailureRate:    0.25,
+							},
+						},
+						{
+							ID:     2,
+							Config: WebhookResponseConfig{URL: "other_url"},
+							Events: []string{"push"},
+						},
+					},
+				}},
+				deliveries: true,
+			},
+			want: [][]string{
+				{
+					"Repo Name", "Webhook ID", "Webhook URL", "Is Active", "Insecure URL", "Events", "Scope",
+					"Last Delivery Status", "Last Success", "Failure Rate",
+				},
+				{"repo1", "1", "some_url", "true", "0", "[push]", "repo", "502", "2026-10-01T09:00:00Z", "0.25"},
+				{"repo1", "2", "other_url", "false", "0", "[push]", "repo", "", "", ""},
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := reportCSVWebhookGenerate(tt.args.webhooks); !reflect.DeepEqual(got, tt.want) {
+			if got := reportCSVWebhookGenerate(tt.args.webhooks, tt.args.deliveries); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("reportCSVWebhookGenerate() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func Test_reportCSVCollaboratorsGenerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		allResults []Collaborators
+		want       [][]string
+	}{
+		{
+			name: "reportCSVCollaboratorsGenerate no collaborators",
+			allResults: []Collaborators{{
+				RepositoryName: "repo1",
+			}},
+			want: [][]string{
+				{"Repo Name", "Login", "Type", "Permission"},
+			},
+		},
+		{
+			name: "reportCSVCollaboratorsGenerate",
+			allResults: []Collaborators{{
+				RepositoryName: "repo1",
+				Collaborators: []Collaborator{
+					{Login: "outside-user", Type: collaboratorTypeOutside, Permission: "write"},
+					{Login: "invited-user", Type: collaboratorTypeInvitation, Permission: "read"},
+				},
+			}},
+			want: [][]string{
+				{"Repo Name", "Login", "Type", "Permission"},
+				{"repo1", "outside-user", "outside collaborator", "write"},
+				{"repo1", "invited-user", "pending invitation", "read"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportCSVCollaboratorsGenerate(tt.allResults); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportCSVCollaboratorsGenerate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportCSVMembersGenerate(t *testing.T) {
+	twoFactorEnabled := true
+
+	tests := []struct {
+		name    string
+		members []Member
+		want    [][]string
+	}{
+		{
+			name: "reportCSVMembersGenerate",
+			members: []Member{
+				{
+					Login:            "admin-user",
+					Name:             "Admin User",
+					Role:             "admin",
+					TwoFactorEnabled: &twoFactorEnabled,
+					Teams:            []string{"some-team", "other-team"},
+					SAMLIdentity:     "admin.user@example.com",
+				},
+				{
+					Login: "member-user",
+					Role:  "member",
+				},
+			},
+			want: [][]string{
+				{"Login", "Name", "Role", "2FA Enabled", "Teams", "SAML Identity"},
+				{"admin-user", "Admin User", "admin", "true", "some-team; other-team", "admin.user@example.com"},
+				{"member-user", "", "member", "unknown", "", ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportCSVMembersGenerate(tt.members); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportCSVMembersGenerate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportCSVSecurityGenerate(t *testing.T) {
+	tests := []struct {
+		name      string
+		allStatus []SecurityStatus
+		want      [][]string
+	}{
+		{
+			name: "reportCSVSecurityGenerate",
+			allStatus: []SecurityStatus{{
+				RepositoryName:                "repo1",
+				VulnerabilityAlerts:           "enabled",
+				AutomatedSecurityFixes:        "disabled",
+				SecretScanning:                "enabled",
+				PushProtection:                "unavailable",
+				CodeScanningDefaultSetup:      "not-configured",
+				PrivateVulnerabilityReporting: "disabled",
+			}},
+			want: [][]string{
+				{
+					"Repo Name",
+					"Vulnerability Alerts",
+					"Automated Security Fixes",
+					"Secret Scanning",
+					"Push Protection",
+					"Code Scanning Default Setup",
+					"Private Vulnerability Reporting",
+					"Error",
+				},
+				{"repo1", "enabled", "disabled", "enabled", "unavailable", "not-configured", "disabled", ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportCSVSecurityGenerate(tt.allStatus); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportCSVSecurityGenerate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportCSVDependabotAlertsGenerate(t *testing.T) {
+	oldestCriticalAge := 60
+
+	header := []string{
+		"Repo Name",
+		"Open Alerts",
+		"Critical",
+		"High",
+		"Medium",
+		"Low",
+		"Ecosystems",
+		"Oldest Critical Age (days)",
+	}
+
+	tests := []struct {
+		name      string
+		summaries []DependabotAlertSummary
+		want      [][]string
+	}{
+		{
+			name: "reportCSVDependabotAlertsGenerate",
+			summaries: []DependabotAlertSummary{
+				{
+					RepositoryName: "a-repo",
+					OpenAlerts:     1,
+					Severities:     map[string]int{"low": 1},
+					Ecosystems:     map[string]int{"npm": 1},
+				},
+				{
+					RepositoryName:        "repo1",
+					OpenAlerts:            3,
+					Severities:            map[string]int{"critical": 2, "high": 1},
+					Ecosystems:            map[string]int{"pip": 2, "npm": 1},
+					OldestCriticalAgeDays: &oldestCriticalAge,
+				},
+			},
+			want: [][]string{
+				header,
+				{"a-repo", "1", "0", "0", "0", "1", "npm: 1", ""},
+				{"repo1", "3", "2", "1", "0", "0", "npm: 1; pip: 2", "60"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportCSVDependabotAlertsGenerate(tt.summaries); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportCSVDependabotAlertsGenerate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportCSVDependabotAlertDetailsGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		details []DependabotAlertDetail
+		want    [][]string
+	}{
+		{
+			name: "reportCSVDependabotAlertDetailsGenerate",
+			details: []DependabotAlertDetail{{
+				RepositoryName: "repo1",
+				Number:         2,
+				Severity:       "critical",
+				Ecosystem:      "pip",
+				Package:        "django",
+				ManifestPath:   "requirements.txt",
+				GHSAID:         "GHSA-rf4j-j272-fj86",
+				CVEID:          "CVE-2018-6188",
+				CreatedAt:      "2026-10-01T07:43:03Z",
+				AgeDays:        18,
+				URL:            "https://github.com/some-org/repo1/security/dependabot/2",
+			}},
+			want: [][]string{
+				{
+					"Repo Name",
+					"Alert Number",
+					"Severity",
+					"Ecosystem",
+					"Package",
+					"Manifest Path",
+					"GHSA ID",
+					"CVE ID",
+					"Created At",
+					"Age (days)",
+					"URL",
+				},
+				{
+					"repo1",
+					"2",
+					"critical",
+					"pip",
+					"django",
+					"requirements.txt",
+					"GHSA-rf4j-j272-fj86",
+					"CVE-2018-6188",
+					"2026-10-01T07:43:03Z",
+					"18",
+					"https://github.com/some-org/repo1/security/dependabot/2",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportCSVDependabotAlertDetailsGenerate(tt.details); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportCSVDependabotAlertDetailsGenerate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportCSVSecretAlertsGenerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		alerts []SecretScanningAlert
+		want   [][]string
+	}{
+		{
+			name: "reportCSVSecretAlertsGenerate",
+			alerts: []SecretScanningAlert{{
+				RepositoryName:         "repo1",
+				Number:                 5,
+				SecretType:             "github_personal_access_token",
+				SecretTypeDisplayName:  "GitHub Personal Access Token",
+				CreatedAt:              "2026-08-20T07:43:03Z",
+				PushProtectionBypassed: true,
+				Resolution:             "revoked",
+				URL:                    "https://github.com/some-org/repo1/security/secret-scanning/5",
+			}},
+			want: [][]string{
+				{
+					"Repo Name",
+					"Alert Number",
+					"Secret Type",
+					"Secret Name",
+					"Created At",
+					"Push Protection Bypassed",
+					"Resolution",
+					"URL",
+				},
+				{
+					"repo1",
+					"5",
+					"github_personal_access_token",
+					"GitHub Personal Access Token",
+					"2026-08-20T07:43:03Z",
+					"true",
+					"revoked",
+					"https://github.com/some-org/repo1/security/secret-scanning/5",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportCSVSecretAlertsGenerate(tt.alerts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportCSVSecretAlertsGenerate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_reportCSVUpload(t *testing.T) {
 	type args struct {
 		service  reportCSV
@@ -363,3 +734,41 @@ func Test_reportCSVUpload(t *testing.T) {
 		})
 	}
 }
+
+func Test_reportCSVWebhookFindingsGenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		findings []WebhookFinding
+		want     [][]string
+	}{
+		{
+			name: "reportCSVWebhookFindingsGenerate",
+			findings: []WebhookFinding{{
+				RepositoryName: "repo1",
+				Scope:          webhookScopeRepo,
+				WebhookID:      1,
+				URL:            "http://hooks.example.com/push",
+				Finding:        "plain http url",
+			}, {
+				RepositoryName: "some-org",
+				Scope:          webhookScopeOrg,
+				WebhookID:      2,
+				URL:            "https://hooks.example.com/org",
+				Finding:        "no secret",
+			}},
+			want: [][]string{
+				{"Repo Name", "Scope", "Webhook ID", "Webhook URL", "Finding"},
+				{"repo1", webhookScopeRepo, "1", "http://hooks.example.com/push", "plain http url"},
+				{"some-org", webhookScopeOrg, "2", "https://hooks.example.com/org", "no secret"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportCSVWebhookFindingsGenerate(tt.findings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportCSVWebhookFindingsGenerate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
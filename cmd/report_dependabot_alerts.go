@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var reportDependabotAlertsCmd = &cobra.Command{ // nolint // needed for cobra
+	Use:   "report-dependabot-alerts",
+	Short: "Run a report to generate a csv containing open dependabot alerts per organisation repo by severity",
+	RunE:  reportDependabotAlertsRun,
+}
+
+// dependabotAlertSeverities are the severities reported, in the order of the CSV columns.
+var dependabotAlertSeverities = []string{"critical", "high", "medium", "low"} // nolint // expected global
+
+type reportDependabotAlerts struct {
+	reportDependabotAlertsGetter reportDependabotAlertsGetter
+	reportCSV                    reportCSV
+	reportJSON                   reportJSON
+	dryRun                       bool
+	filePath                     string
+	fileType                     string
+	detailFilePath               string
+	now                          time.Time
+}
+
+type reportDependabotAlertsGetter interface {
+	getAlerts() ([]DependabotAlertResponse, error)
+}
+
+type reportDependabotAlertsGetterService struct{}
+
+func reportDependabotAlertsRun(cmd *cobra.Command, args []string) error {
+	report := &reportDependabotAlerts{
+		reportDependabotAlertsGetter: &reportDependabotAlertsGetterService{},
+		reportCSV:                    &reportCSVService{},
+		reportJSON:                   &reportJSONService{},
+		now:                          time.Now(),
+	}
+
+	if err := reportDependabotAlertsValidateFlags(report, cmd); err != nil {
+		return err
+	}
+
+	return reportDependabotAlertsCreate(report)
+}
+
+func reportDependabotAlertsValidateFlags(r *reportDependabotAlerts, cmd *cobra.Command) error {
+	var err error
+
+	r.dryRun, err = cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.filePath, err = cmd.Flags().GetString("file-path")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.fileType, err = cmd.Flags().GetString("file-type")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.detailFilePath, err = cmd.Flags().GetString("detail-file-path")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+func reportDependabotAlertsCreate(r *reportDependabotAlerts) error {
+	alerts, err := r.reportDependabotAlertsGetter.getAlerts()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if r.dryRun {
+		log.Printf("This is a dry run, the report would process %d records\n", len(alerts))
+
+		return nil
+	}
+
+	summaries := dependabotAlertsSummarise(alerts, r.now)
+
+	if err := reportDependabotAlertsWrite(r, r.filePath, summaries, nil); err != nil {
+		return err
+	}
+
+	if r.detailFilePath == "" {
+		return nil
+	}
+
+	return reportDependabotAlertsWrite(r, r.detailFilePath, nil, dependabotAlertsDetail(alerts, r.now))
+}
+
+// reportDependabotAlertsWrite writes either the summaries or the alert details in the report file type.
+func reportDependabotAlertsWrite(
+	r *reportDependabotAlerts,
+	filePath string,
+	summaries []DependabotAlertSummary,
+	details []DependabotAlertDetail,
+) error {
+	if r.fileType == "json" {
+		var (
+			jsonReport []byte
+			err        error
+		)
+
+		if details != nil {
+			jsonReport, err = r.reportJSON.generateDependabotAlertDetails(details)
+		} else {
+			jsonReport, err = r.reportJSON.generateDependabotAlerts(summaries)
+		}
+
+		if err != nil {
+			return fmt.Errorf("generate json failed: %w", err)
+		}
+
+		if err := r.reportJSON.uploader(filePath, jsonReport); err != nil {
+			return fmt.Errorf("upload json failed: %w", err)
+		}
+
+		return nil
+	}
+
+	lines := reportCSVDependabotAlertsGenerate(summaries)
+	if details != nil {
+		lines = reportCSVDependabotAlertDetailsGenerate(details)
+	}
+
+	if err := reportCSVUpload(r.reportCSV, filePath, lines); err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+
+	return nil
+}
+
+// getAlerts returns every open dependabot alert in the organisation, following the cursor in the Link header.
+func (r *reportDependabotAlertsGetterService) getAlerts() ([]DependabotAlertResponse, error) {
+	var alerts []DependabotAlertResponse
+
+	ctx := context.Background()
+	path := fmt.Sprintf("/orgs/%s/dependabot/alerts?state=open&per_page=100", config.Org)
+
+	for path != "" {
+		client := restclient.NewClient(path, config.Token, http.MethodGet)
+
+		var response []DependabotAlertResponse
+		if err := client.Run(ctx, &response); err != nil {
+			return alerts, fmt.Errorf("list dependabot alerts: %w", err)
+		}
+
+		alerts = append(alerts, response...)
+		path = client.NextPath()
+	}
+
+	return alerts, nil
+}
+
+// dependabotAlertsSummarise counts the alerts for each repository by severity and ecosystem, sorted by repository.
+func dependabotAlertsSummarise(alerts []DependabotAlertResponse, now time.Time) []DependabotAlertSummary {
+	byRepository := make(map[string]*DependabotAlertSummary)
+
+	for _, alert := range alerts {
+		summary, ok := byRepository[alert.Repository.Name]
+		if !ok {
+			summary = &DependabotAlertSummary{
+				RepositoryName: alert.Repository.Name,
+				Severities:     make(map[string]int),
+				Ecosystems:     make(map[string]int),
+			}
+			byRepository[alert.Repository.Name] = summary
+		}
+
+		summary.OpenAlerts++
+		summary.Severities[alert.SecurityAdvisory.Severity]++
+		summary.Ecosystems[alert.Dependency.Package.Ecosystem]++
+
+		if alert.SecurityAdvisory.Severity != "critical" {
+			continue
+		}
+
+		age := dependabotAlertAgeDays(alert.CreatedAt, now)
+		if summary.OldestCriticalAgeDays == nil || age > *summary.OldestCriticalAgeDays {
+			summary.OldestCriticalAgeDays = &age
+		}
+	}
+
+	summaries := make([]DependabotAlertSummary, 0, len(byRepository))
+	for _, summary := range byRepository {
+		summaries = append(summaries, *summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].RepositoryName < summaries[j].RepositoryName
+	})
+
+	return summaries
+}
+
+func dependabotAlertsDetail(alerts []DependabotAlertResponse, now time.Time) []DependabotAlertDetail {
+	details := make([]DependabotAlertDetail, 0, len(alerts))
+
+	for _, alert := range alerts {
+		details = append(details, DependabotAlertDetail{
+			RepositoryName: alert.Repository.Name,
+			Number:         alert.Number,
+			Severity:       alert.SecurityAdvisory.Severity,
+			Ecosystem:      alert.Dependency.Package.Ecosystem,
+			Package:        alert.Dependency.Package.Name,
+			ManifestPath:   alert.Dependency.ManifestPath,
+			GHSAID:         alert.SecurityAdvisory.GHSAID,
+			CVEID:          alert.SecurityAdvisory.CVEID,
+			CreatedAt:      alert.CreatedAt,
+			AgeDays:        dependabotAlertAgeDays(alert.CreatedAt, now),
+			URL:            alert.HTMLURL,
+		})
+	}
+
+	return details
+}
+
+// dependabotAlertAgeDays returns the whole days since the alert was created, 0 if the date cannot be read.
+func dependabotAlertAgeDays(createdAt string, now time.Time) int {
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return 0
+	}
+
+	return int(now.Sub(created).Hours() / 24) // nolint // hours in a day
+}
+
+// nolint // needed for cobra
+func init() {
+	reportDependabotAlertsCmd.Flags().StringP(
+		"file-path", "f", "dependabot_alerts.csv", "file path for report to be created, must be .csv or .json",
+	)
+	reportDependabotAlertsCmd.Flags().StringP("file-type", "t", "csv", "file type, must be csv or json")
+	reportDependabotAlertsCmd.Flags().String(
+		"detail-file-path", "", "optional file path for a report with one line per alert, in the same file type",
+	)
+	rootCmd.AddCommand(reportDependabotAlertsCmd)
+}
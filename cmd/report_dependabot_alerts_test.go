@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func mockDependabotAlerts(t *testing.T, files ...string) []DependabotAlertResponse {
+	t.Helper()
+
+	var alerts []DependabotAlertResponse
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read test data: %v", err)
+		}
+
+		var page []DependabotAlertResponse
+		if err := json.Unmarshal(content, &page); err != nil {
+			t.Fatalf("failed to unmarshal test data: %v", err)
+		}
+
+		alerts = append(alerts, page...)
+	}
+
+	return alerts
+}
+
+func Test_reportDependabotAlertsValidateFlags(t *testing.T) {
+	cmdInvalidDryRun := &cobra.Command{Use: "report-dependabot-alerts"}
+
+	cmdInvalidDetailFilePath := &cobra.Command{Use: "report-dependabot-alerts"}
+	cmdInvalidDetailFilePath.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdInvalidDetailFilePath.Flags().StringP("file-path", "f", "dependabot_alerts.csv", "file path")
+	cmdInvalidDetailFilePath.Flags().StringP("file-type", "t", "csv", "file type")
+
+	cmdValid := &cobra.Command{Use: "report-dependabot-alerts"}
+	cmdValid.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdValid.Flags().StringP("file-path", "f", "dependabot_alerts.csv", "file path")
+	cmdValid.Flags().StringP("file-type", "t", "csv", "file type")
+	cmdValid.Flags().String("detail-file-path", "alerts.csv", "detail file path")
+
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		want    *reportDependabotAlerts
+		wantErr bool
+	}{
+		{
+			name:    "reportDependabotAlertsValidateFlags dry run failure",
+			cmd:     cmdInvalidDryRun,
+			want:    &reportDependabotAlerts{},
+			wantErr: true,
+		},
+		{
+			name:    "reportDependabotAlertsValidateFlags detail-file-path failure",
+			cmd:     cmdInvalidDetailFilePath,
+			want:    &reportDependabotAlerts{filePath: "dependabot_alerts.csv", fileType: "csv"},
+			wantErr: true,
+		},
+		{
+			name: "reportDependabotAlertsValidateFlags success",
+			cmd:  cmdValid,
+			want: &reportDependabotAlerts{
+				filePath:       "dependabot_alerts.csv",
+				fileType:       "csv",
+				detailFilePath: "alerts.csv",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &reportDependabotAlerts{}
+			if err := reportDependabotAlertsValidateFlags(got, tt.cmd); (err != nil) != tt.wantErr {
+				t.Errorf("reportDependabotAlertsValidateFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportDependabotAlertsValidateFlags() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportDependabotAlertsCreate(t *testing.T) {
+	alerts := mockDependabotAlerts(t, "testdata/mockDependabotAlertsResponse.json")
+
+	tests := []struct {
+		name    string
+		r       *reportDependabotAlerts
+		wantErr bool
+	}{
+		{
+			name: "reportDependabotAlertsCreate fails to return alerts",
+			r: &reportDependabotAlerts{
+				reportDependabotAlertsGetter: &mockReportDependabotAlertsGetterService{fail: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportDependabotAlertsCreate dry run success",
+			r: &reportDependabotAlerts{
+				dryRun:                       true,
+				reportDependabotAlertsGetter: &mockReportDependabotAlertsGetterService{returnAlerts: alerts},
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportDependabotAlertsCreate json generate fail",
+			r: &reportDependabotAlerts{
+				reportDependabotAlertsGetter: &mockReportDependabotAlertsGetterService{returnAlerts: alerts},
+				reportJSON:                   &mockReportJSON{failgenerate: true},
+				fileType:                     "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportDependabotAlertsCreate json uploader fail",
+			r: &reportDependabotAlerts{
+				reportDependabotAlertsGetter: &mockReportDependabotAlertsGetterService{returnAlerts: alerts},
+				reportJSON:                   &mockReportJSON{failupload: true},
+				fileType:                     "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportDependabotAlertsCreate json with detail success",
+			r: &reportDependabotAlerts{
+				reportDependabotAlertsGetter: &mockReportDependabotAlertsGetterService{returnAlerts: alerts},
+				reportJSON:                   &mockReportJSON{},
+				fileType:                     "json",
+				detailFilePath:               "alerts.json",
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportDependabotAlertsCreate csv upload fail",
+			r: &reportDependabotAlerts{
+				reportDependabotAlertsGetter: &mockReportDependabotAlertsGetterService{returnAlerts: alerts},
+				reportCSV:                    &mockReportCSV{failOpen: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportDependabotAlertsCreate csv with detail success",
+			r: &reportDependabotAlerts{
+				reportDependabotAlertsGetter: &mockReportDependabotAlertsGetterService{returnAlerts: alerts},
+				reportCSV:                    &mockReportCSV{},
+				detailFilePath:               "alerts.csv",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reportDependabotAlertsCreate(tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("reportDependabotAlertsCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_reportDependabotAlertsGetterService_getAlerts(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	firstPageURL := "https://api.github.com/orgs/some-org/dependabot/alerts?state=open&per_page=100"
+	secondPageURL := firstPageURL + "&after=some-cursor"
+
+	mockFile := func(filePath string) string {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("failed to read test data: %v", err)
+		}
+
+		return string(content)
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		want       []DependabotAlertResponse
+		wantErr    bool
+	}{
+		{
+			name:       "getAlerts fails",
+			statusCode: http.StatusForbidden,
+			wantErr:    true,
+		},
+		{
+			name:       "getAlerts follows next page",
+			statusCode: http.StatusOK,
+			want: mockDependabotAlerts(
+				t,
+				"testdata/mockDependabotAlertsResponse.json",
+				"testdata/mockDependabotAlertsPage2Response.json",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			firstPage := httpmock.NewStringResponse(tt.statusCode, mockFile("testdata/mockDependabotAlertsResponse.json"))
+			firstPage.Header.Set("Link", "<"+secondPageURL+`>; rel="next"`)
+
+			httpmock.RegisterResponder("GET", firstPageURL, httpmock.ResponderFromResponse(firstPage))
+			mockHTTPResponder("GET", secondPageURL, "testdata/mockDependabotAlertsPage2Response.json", http.StatusOK)
+
+			r := &reportDependabotAlertsGetterService{}
+			got, err := r.getAlerts()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reportDependabotAlertsGetterService.getAlerts() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportDependabotAlertsGetterService.getAlerts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dependabotAlertsSummarise(t *testing.T) {
+	now := time.Date(2026, 10, 19, 7, 43, 3, 0, time.UTC)
+	oldestCriticalAge := 60
+
+	tests := []struct {
+		name   string
+		alerts []DependabotAlertResponse
+		want   []DependabotAlertSummary
+	}{
+		{
+			name: "dependabotAlertsSummarise no alerts",
+			want: []DependabotAlertSummary{},
+		},
+		{
+			name: "dependabotAlertsSummarise",
+			alerts: append(
+				mockDependabotAlerts(
+					t,
+					"testdata/mockDependabotAlertsResponse.json",
+					"testdata/mockDependabotAlertsPage2Response.json",
+				),
+				DependabotAlertResponse{
+					SecurityAdvisory: DependabotAlertAdvisory{Severity: "low"},
+					Repository: struct {
+						Name string `json:"name"`
+					}{Name: "a-repo"},
+				},
+			),
+			want: []DependabotAlertSummary{
+				{
+					RepositoryName: "a-repo",
+					OpenAlerts:     1,
+					Severities:     map[string]int{"low": 1},
+					Ecosystems:     map[string]int{"": 1},
+				},
+				{
+					RepositoryName:        "repo1",
+					OpenAlerts:            3,
+					Severities:            map[string]int{"critical": 2, "high": 1},
+					Ecosystems:            map[string]int{"pip": 2, "npm": 1},
+					OldestCriticalAgeDays: &oldestCriticalAge,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dependabotAlertsSummarise(tt.alerts, now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dependabotAlertsSummarise() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dependabotAlertsDetail(t *testing.T) {
+	now := time.Date(2026, 10, 19, 7, 43, 3, 0, time.UTC)
+
+	want := []DependabotAlertDetail{
+		{
+			RepositoryName: "repo1",
+			Number:         2,
+			Severity:       "critical",
+			Ecosystem:      "pip",
+			Package:        "django",
+			ManifestPath:   "requirements.txt",
+			GHSAID:         "GHSA-rf4j-j272-fj86",
+			CVEID:          "CVE-2018-6188",
+			CreatedAt:      "2026-10-01T07:43:03Z",
+			AgeDays:        18,
+			URL:            "https://github.com/some-org/repo1/security/dependabot/2",
+		},
+		{
+			RepositoryName: "repo1",
+			Number:         1,
+			Severity:       "high",
+			Ecosystem:      "npm",
+			Package:        "lodash",
+			ManifestPath:   "package-lock.json",
+			GHSAID:         "GHSA-35jh-r3h4-6jhm",
+			CVEID:          "CVE-2021-23337",
+			CreatedAt:      "2026-09-19T07:43:03Z",
+			AgeDays:        30,
+			URL:            "https://github.com/some-org/repo1/security/dependabot/1",
+		},
+	}
+
+	got := dependabotAlertsDetail(mockDependabotAlerts(t, "testdata/mockDependabotAlertsResponse.json"), now)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dependabotAlertsDetail() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_dependabotAlertAgeDays(t *testing.T) {
+	now := time.Date(2026, 10, 19, 7, 43, 3, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		createdAt string
+		want      int
+	}{
+		{
+			name:      "dependabotAlertAgeDays invalid date",
+			createdAt: "not-a-date",
+			want:      0,
+		},
+		{
+			name:      "dependabotAlertAgeDays part day is not counted",
+			createdAt: "2026-10-17T08:00:00Z",
+			want:      1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dependabotAlertAgeDays(tt.createdAt, now); got != tt.want {
+				t.Errorf("dependabotAlertAgeDays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
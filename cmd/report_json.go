@@ -8,8 +8,15 @@ import (
 )
 
 type reportJSON interface {
-	generate(bool, []ReportResponse, map[string]string) ([]byte, error)
+	generate(bool, []ReportResponse, map[string]map[string]string) ([]byte, error)
 	generateWebhook([]Webhooks) ([]byte, error)
+	generateCollaborators([]Collaborators) ([]byte, error)
+	generateMembers([]Member) ([]byte, error)
+	generateSecurity([]SecurityStatus) ([]byte, error)
+	generateDependabotAlerts([]DependabotAlertSummary) ([]byte, error)
+	generateDependabotAlertDetails([]DependabotAlertDetail) ([]byte, error)
+	generateSecretAlerts([]SecretScanningAlert) ([]byte, error)
+	generateWebhookFindings([]WebhookFinding) ([]byte, error)
 	uploader(string, []byte) error
 }
 
@@ -28,7 +35,7 @@ func (r *reportJSONService) uploader(filePath string, reportJSON []byte) error {
 func (r *reportJSONService) generate(
 	ignoreArchived bool,
 	allResults []ReportResponse,
-	teamAccess map[string]string,
+	teamAccess map[string]map[string]string,
 ) ([]byte, error) {
 	var repos []RepositoriesNode
 
@@ -38,7 +45,7 @@ func (r *reportJSONService) generate(
 				continue
 			}
 
-			repo.TeamPermissions = teamAccess[repo.Name]
+			repo.TeamPermissions = reportJSONTeamPermissions(repo.Name, teamAccess)
 			repos = append(repos, repo)
 		}
 	}
@@ -52,6 +59,26 @@ func (r *reportJSONService) generate(
 	return reportJSON, nil
 }
 
+// reportJSONTeamPermissions returns the permission of each team with access to the repository.
+func reportJSONTeamPermissions(repositoryName string, teamAccess map[string]map[string]string) map[string]string {
+	var permissions map[string]string
+
+	for team, access := range teamAccess {
+		permission, ok := access[repositoryName]
+		if !ok {
+			continue
+		}
+
+		if permissions == nil {
+			permissions = make(map[string]string)
+		}
+
+		permissions[team] = permission
+	}
+
+	return permissions
+}
+
 func (r *reportJSONService) generateWebhook(allResults []Webhooks) ([]byte, error) {
 	reportJSON, err := json.Marshal(allResults)
 
@@ -61,3 +88,92 @@ func (r *reportJSONService) generateWebhook(allResults []Webhooks) ([]byte, erro
 
 	return reportJSON, nil
 }
+
+// generateWebhookFindings allows an empty report, an audit with no findings is a valid result.
+func (r *reportJSONService) generateWebhookFindings(findings []WebhookFinding) ([]byte, error) {
+	if findings == nil {
+		findings = []WebhookFinding{}
+	}
+
+	reportJSON, err := json.Marshal(findings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	return reportJSON, nil
+}
+
+// generateCollaborators allows an empty report, no access outside of teams is a valid result.
+func (r *reportJSONService) generateCollaborators(allResults []Collaborators) ([]byte, error) {
+	if allResults == nil {
+		allResults = []Collaborators{}
+	}
+
+	reportJSON, err := json.Marshal(allResults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	return reportJSON, nil
+}
+
+func (r *reportJSONService) generateMembers(members []Member) ([]byte, error) {
+	reportJSON, err := json.Marshal(members)
+
+	if err != nil || len(members) == 0 {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	return reportJSON, nil
+}
+
+func (r *reportJSONService) generateSecurity(allStatus []SecurityStatus) ([]byte, error) {
+	reportJSON, err := json.Marshal(allStatus)
+
+	if err != nil || len(allStatus) == 0 {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	return reportJSON, nil
+}
+
+// generateDependabotAlerts allows an empty report, having no open alerts is a valid result.
+func (r *reportJSONService) generateDependabotAlerts(summaries []DependabotAlertSummary) ([]byte, error) {
+	if summaries == nil {
+		summaries = []DependabotAlertSummary{}
+	}
+
+	reportJSON, err := json.Marshal(summaries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	return reportJSON, nil
+}
+
+func (r *reportJSONService) generateDependabotAlertDetails(details []DependabotAlertDetail) ([]byte, error) {
+	if details == nil {
+		details = []DependabotAlertDetail{}
+	}
+
+	reportJSON, err := json.Marshal(details)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	return reportJSON, nil
+}
+
+// generateSecretAlerts allows an empty report, having no alerts is a valid result.
+func (r *reportJSONService) generateSecretAlerts(alerts []SecretScanningAlert) ([]byte, error) {
+	if alerts == nil {
+		alerts = []SecretScanningAlert{}
+	}
+
+	reportJSON, err := json.Marshal(alerts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	return reportJSON, nil
+}
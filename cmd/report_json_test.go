@@ -53,7 +53,7 @@ func Test_reportJSONService_generate(t *testing.T) {
 	type args struct {
 		ignoreArchived bool
 		allResults     []ReportResponse
-		teamAccess     map[string]string
+		teamAccess     map[string]map[string]string
 	}
 
 	tests := []struct {
@@ -85,6 +85,28 @@ func Test_reportJSONService_generate(t *testing.T) {
 			},
 			wantFile: "testdata/generate_one_repo.json",
 		},
+		{
+			name: "reportJSONService_generate is success with team permissions",
+			args: args{
+				allResults: []ReportResponse{{
+					Organization{
+						Repositories{
+							Nodes: []RepositoriesNode{{
+								IsArchived:    false,
+								Name:          "REPONAME2",
+								NameWithOwner: "org/REPONAME2",
+							}},
+						},
+					},
+				}},
+				teamAccess: map[string]map[string]string{
+					"platform": {"REPONAME2": "ADMIN"},
+					"security": {"REPONAME2": "READ"},
+					"other":    {"REPONAME3": "WRITE"},
+				},
+			},
+			wantFile: "testdata/generate_one_repo_with_teams.json",
+		},
 		{
 			name: "reportJSONService_generate is success with one archived",
 			args: args{
@@ -169,6 +191,7 @@ func Test_reportJSONService_generateWebhook(t *testing.T) {
 			args: args{
 				allResults: []Webhooks{{
 					RepositoryName: "repo1",
+					Scope:          "repo",
 					Webhooks: []WebhookResponse{{
 						Config: WebhookResponseConfig{
 							URL: "some_url", InsecureURL: 0,
@@ -206,3 +229,304 @@ func Test_reportJSONService_generateWebhook(t *testing.T) {
 		})
 	}
 }
+
+func Test_reportJSONService_generateCollaborators(t *testing.T) {
+	tests := []struct {
+		name       string
+		allResults []Collaborators
+		want       string
+		wantErr    bool
+	}{
+		{
+			name: "reportJSONService_generateCollaborators no collaborators",
+			want: `[]`,
+		},
+		{
+			name: "reportJSONService_generateCollaborators is success",
+			allResults: []Collaborators{{
+				RepositoryName: "repo1",
+				Collaborators: []Collaborator{
+					{Login: "outside-user", Type: collaboratorTypeOutside, Permission: "write"},
+				},
+			}},
+			want: `[{"RepositoryName":"repo1","Collaborators":` +
+				`[{"login":"outside-user","type":"outside collaborator","permission":"write"}]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &reportJSONService{}
+			got, err := r.generateCollaborators(tt.allResults)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reportJSONService.generateCollaborators() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("reportJSONService.generateCollaborators() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportJSONService_generateMembers(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []Member
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "reportJSONService_generateMembers error",
+			wantErr: true,
+		},
+		{
+			name:    "reportJSONService_generateMembers is success",
+			members: []Member{{Login: "member-user", Role: "member", Teams: []string{"some-team"}}},
+			want: `[{"login":"member-user","name":"","role":"member","twoFactorEnabled":null,` +
+				`"teams":["some-team"],"samlIdentity":""}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &reportJSONService{}
+			got, err := r.generateMembers(tt.members)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reportJSONService.generateMembers() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("reportJSONService.generateMembers() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportJSONService_generateSecurity(t *testing.T) {
+	tests := []struct {
+		name      string
+		allStatus []SecurityStatus
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:    "reportJSONService_generateSecurity error",
+			wantErr: true,
+		},
+		{
+			name: "reportJSONService_generateSecurity is success",
+			allStatus: []SecurityStatus{{
+				RepositoryName:                "repo1",
+				VulnerabilityAlerts:           "enabled",
+				AutomatedSecurityFixes:        "enabled",
+				SecretScanning:                "disabled",
+				PushProtection:                "disabled",
+				CodeScanningDefaultSetup:      "configured",
+				PrivateVulnerabilityReporting: "enabled",
+			}},
+			want: `[{"repositoryName":"repo1","vulnerabilityAlerts":"enabled","automatedSecurityFixes":"enabled",` +
+				`"secretScanning":"disabled","pushProtection":"disabled","codeScanningDefaultSetup":"configured",` +
+				`"privateVulnerabilityReporting":"enabled","error":""}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &reportJSONService{}
+			got, err := r.generateSecurity(tt.allStatus)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reportJSONService.generateSecurity() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("reportJSONService.generateSecurity() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportJSONService_generateDependabotAlerts(t *testing.T) {
+	oldestCriticalAge := 60
+
+	tests := []struct {
+		name      string
+		summaries []DependabotAlertSummary
+		want      string
+	}{
+		{
+			name: "reportJSONService_generateDependabotAlerts no alerts",
+			want: `[]`,
+		},
+		{
+			name: "reportJSONService_generateDependabotAlerts is success",
+			summaries: []DependabotAlertSummary{{
+				RepositoryName:        "repo1",
+				OpenAlerts:            1,
+				Severities:            map[string]int{"critical": 1},
+				Ecosystems:            map[string]int{"pip": 1},
+				OldestCriticalAgeDays: &oldestCriticalAge,
+			}},
+			want: `[{"repositoryName":"repo1","openAlerts":1,"severities":{"critical":1},"ecosystems":{"pip":1},` +
+				`"oldestCriticalAgeDays":60}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &reportJSONService{}
+			got, err := r.generateDependabotAlerts(tt.summaries)
+			if err != nil {
+				t.Errorf("reportJSONService.generateDependabotAlerts() error = %v", err)
+
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("reportJSONService.generateDependabotAlerts() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportJSONService_generateDependabotAlertDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		details []DependabotAlertDetail
+		want    string
+	}{
+		{
+			name: "reportJSONService_generateDependabotAlertDetails no alerts",
+			want: `[]`,
+		},
+		{
+			name: "reportJSONService_generateDependabotAlertDetails is success",
+			details: []DependabotAlertDetail{{
+				RepositoryName: "repo1",
+				Number:         2,
+				Severity:       "critical",
+				Ecosystem:      "pip",
+				Package:        "django",
+				ManifestPath:   "requirements.txt",
+				GHSAID:         "GHSA-rf4j-j272-fj86",
+				CVEID:          "CVE-2018-6188",
+				CreatedAt:      "2026-10-01T07:43:03Z",
+				AgeDays:        18,
+				URL:            "https://github.com/some-org/repo1/security/dependabot/2",
+			}},
+			want: `[{"repositoryName":"repo1","number":2,"severity":"critical","ecosystem":"pip","package":"django",` +
+				`"manifestPath":"requirements.txt","ghsaId":"GHSA-rf4j-j272-fj86","cveId":"CVE-2018-6188",` +
+				`"createdAt":"2026-10-01T07:43:03Z","ageDays":18,` +
+				`"url":"https://github.com/some-org/repo1/security/dependabot/2"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &reportJSONService{}
+			got, err := r.generateDependabotAlertDetails(tt.details)
+			if err != nil {
+				t.Errorf("reportJSONService.generateDependabotAlertDetails() error = %v", err)
+
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("reportJSONService.generateDependabotAlertDetails() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportJSONService_generateSecretAlerts(t *testing.T) {
+	tests := []struct {
+		name   string
+		alerts []SecretScanningAlert
+		want   string
+	}{
+		{
+			name: "reportJSONService_generateSecretAlerts no alerts",
+			want: `[]`,
+		},
+		{
+			name: "reportJSONService_generateSecretAlerts is success",
+			alerts: []SecretScanningAlert{{
+				RepositoryName:        "repo1",
+				Number:                5,
+				SecretType:            "github_personal_access_token",
+				SecretTypeDisplayName: "GitHub Personal Access Token",
+				CreatedAt:             "2026-08-20T07:43:03Z",
+				Resolution:            "revoked",
+				URL:                   "https://github.com/some-org/repo1/security/secret-scanning/5",
+			}},
+			want: `[{"repositoryName":"repo1","number":5,"secretType":"github_personal_access_token",` +
+				`"secretTypeDisplayName":"GitHub Personal Access Token","createdAt":"2026-08-20T07:43:03Z",` +
+				`"pushProtectionBypassed":false,"resolution":"revoked",` +
+				`"url":"https://github.com/some-org/repo1/security/secret-scanning/5"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &reportJSONService{}
+			got, err := r.generateSecretAlerts(tt.alerts)
+			if err != nil {
+				t.Errorf("reportJSONService.generateSecretAlerts() error = %v", err)
+
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("reportJSONService.generateSecretAlerts() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportJSONService_generateWebhookFindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		findings []WebhookFinding
+		want     string
+	}{
+		{
+			name: "reportJSONService_generateWebhookFindings no findings",
+			want: `[]`,
+		},
+		{
+			name: "reportJSONService_generateWebhookFindings is success",
+			findings: []WebhookFinding{{
+				RepositoryName: "repo1",
+				Scope:          webhookScopeRepo,
+				WebhookID:      1,
+				URL:            "http://hooks.example.com/push",
+				Finding:        "plain http url",
+			}},
+			want: `[{"repositoryName":"repo1","scope":"` + webhookScopeRepo + `","webhookId":1,` +
+				`"url":"http://hooks.example.com/push",` +
+				`"finding":"plain http url"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &reportJSONService{}
+			got, err := r.generateWebhookFindings(tt.findings)
+			if err != nil {
+				t.Errorf("reportJSONService.generateWebhookFindings() error = %v", err)
+
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("reportJSONService.generateWebhookFindings() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github-admin-tool/graphqlclient"
+	"github-admin-tool/progressbar"
+	"log"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var reportMembersCmd = &cobra.Command{ // nolint // needed for cobra
+	Use:   "report-members",
+	Short: "Run a report to generate a csv containing organisation members with role, 2FA status, teams and SAML identity",
+	RunE:  reportMembersRun,
+}
+
+type reportMembers struct {
+	reportMembersGetter reportMembersGetter
+	reportCSV           reportCSV
+	reportJSON          reportJSON
+	dryRun              bool
+	filePath            string
+	fileType            string
+}
+
+type reportMembersGetter interface {
+	getMembers(*reportMembers) ([]Member, error)
+}
+
+type reportMembersGetterService struct{}
+
+func reportMembersRun(cmd *cobra.Command, args []string) error {
+	report := &reportMembers{
+		reportMembersGetter: &reportMembersGetterService{},
+		reportCSV:           &reportCSVService{},
+		reportJSON:          &reportJSONService{},
+	}
+
+	if err := reportMembersValidateFlags(report, cmd); err != nil {
+		return err
+	}
+
+	return reportMembersCreate(report)
+}
+
+func reportMembersValidateFlags(r *reportMembers, cmd *cobra.Command) error {
+	var err error
+
+	r.dryRun, err = cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.filePath, err = cmd.Flags().GetString("file-path")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.fileType, err = cmd.Flags().GetString("file-type")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if r.fileType != "csv" && r.fileType != "json" {
+		return fmt.Errorf("%w: %s", errReportFileType, r.fileType)
+	}
+
+	return nil
+}
+
+func reportMembersCreate(r *reportMembers) error {
+	members, err := r.reportMembersGetter.getMembers(r)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if r.dryRun {
+		return nil
+	}
+
+	if r.fileType == "json" {
+		jsonReport, err := r.reportJSON.generateMembers(members)
+		if err != nil {
+			return fmt.Errorf("generate json failed: %w", err)
+		}
+
+		if err := r.reportJSON.uploader(r.filePath, jsonReport); err != nil {
+			return fmt.Errorf("upload json failed: %w", err)
+		}
+
+		return nil
+	}
+
+	lines := reportCSVMembersGenerate(members)
+	if err := reportCSVUpload(r.reportCSV, r.filePath, lines); err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+
+	return nil
+}
+
+// getMembers returns every organisation member with their role and 2FA status, along with the teams they
+// belong to and their SAML identity when single sign-on is configured.
+func (r *reportMembersGetterService) getMembers(report *reportMembers) ([]Member, error) {
+	var (
+		cursor           *string
+		totalRecordCount int
+		members          []Member
+		iteration        int
+		bar              progressbar.Bar
+	)
+
+	client := graphqlclient.NewClient()
+	req := reportRequest(reportMembersQuery())
+	ctx := context.Background()
+
+	for {
+		// Set new cursor on every loop to paginate through 100 at a time
+		req.Var("after", cursor)
+
+		var respData MembersResponse
+		if err := client.Run(ctx, req, &respData); err != nil {
+			return members, fmt.Errorf("graphql call: %w", err)
+		}
+
+		cursor = &respData.Organization.MembersWithRole.PageInfo.EndCursor
+		totalRecordCount = respData.Organization.MembersWithRole.TotalCount
+
+		if report.dryRun {
+			log.Printf("This is a dry run, the report would process %d records\n", totalRecordCount)
+
+			return members, nil
+		}
+
+		for _, edge := range respData.Organization.MembersWithRole.Edges {
+			members = append(members, Member{
+				Login:            edge.Node.Login,
+				Name:             edge.Node.Name,
+				Role:             strings.ToLower(edge.Role),
+				TwoFactorEnabled: edge.HasTwoFactorEnabled,
+			})
+		}
+
+		if iteration == 0 {
+			bar.NewOption(0, totalRecordCount)
+		}
+
+		bar.Play(iteration)
+
+		iteration += IterationCount
+
+		if !respData.Organization.MembersWithRole.PageInfo.HasNextPage {
+			bar.Play(totalRecordCount)
+
+			break
+		}
+	}
+
+	bar.Finish("Get member data")
+
+	memberTeams, err := reportMembersTeams(ctx, client)
+	if err != nil {
+		return members, err
+	}
+
+	samlIdentities, err := reportMembersSAMLIdentities(ctx, client)
+	if err != nil {
+		return members, err
+	}
+
+	for key := range members {
+		members[key].Teams = memberTeams[members[key].Login]
+		members[key].SAMLIdentity = samlIdentities[members[key].Login]
+	}
+
+	return members, nil
+}
+
+// reportMembersTeams returns the slugs of the teams each member belongs to directly, keyed by login. Membership
+// inherited from a child team is left out as the child team is listed itself.
+func reportMembersTeams(ctx context.Context, client *graphqlclient.Client) (map[string][]string, error) {
+	memberTeams := make(map[string][]string)
+
+	teams, err := reportAccessAllTeams(ctx, client)
+	if err != nil {
+		return memberTeams, fmt.Errorf("%w", err)
+	}
+
+	req := reportRequest(reportMembersTeamQuery())
+
+	for _, team := range teams {
+		var cursor *string
+
+		for {
+			// Set new cursor on every loop to paginate through 100 at a time
+			req.Var("after", cursor)
+			req.Var("team", team)
+
+			var respData TeamMembersResponse
+			if err := client.Run(ctx, req, &respData); err != nil {
+				return memberTeams, fmt.Errorf("graphql call: %w", err)
+			}
+
+			if respData.Organization.Team == nil {
+				return memberTeams, fmt.Errorf("%w: %s", errTeamNotFound, team)
+			}
+
+			members := respData.Organization.Team.Members
+
+			for _, node := range members.Nodes {
+				memberTeams[node.Login] = append(memberTeams[node.Login], team)
+			}
+
+			cursor = &members.PageInfo.EndCursor
+
+			if !members.PageInfo.HasNextPage {
+				break
+			}
+		}
+	}
+
+	return memberTeams, nil
+}
+
+// reportMembersSAMLIdentities returns the SAML name ID linked to each login, it is empty when the
+// organisation does not have single sign-on configured.
+func reportMembersSAMLIdentities(ctx context.Context, client *graphqlclient.Client) (map[string]string, error) {
+	var cursor *string
+
+	identities := make(map[string]string)
+	req := reportRequest(reportMembersSAMLQuery())
+
+	for {
+		// Set new cursor on every loop to paginate through 100 at a time
+		req.Var("after", cursor)
+
+		var respData SAMLIdentitiesResponse
+		if err := client.Run(ctx, req, &respData); err != nil {
+			return identities, fmt.Errorf("graphql call: %w", err)
+		}
+
+		provider := respData.Organization.SAMLIdentityProvider
+		if provider == nil {
+			return identities, nil
+		}
+
+		for _, node := range provider.ExternalIdentities.Nodes {
+			if node.User == nil {
+				continue
+			}
+
+			identities[node.User.Login] = node.SAMLIdentity.NameID
+		}
+
+		cursor = &provider.ExternalIdentities.PageInfo.EndCursor
+
+		if !provider.ExternalIdentities.PageInfo.HasNextPage {
+			return identities, nil
+		}
+	}
+}
+
+func reportMembersQuery() string {
+	var query strings.Builder
+
+	query.WriteString("query ($org: String! $after: String) {")
+	query.WriteString("		organization(login:$org) {")
+	query.WriteString("			membersWithRole(first: 100, after: $after) {")
+	query.WriteString("				totalCount")
+	query.WriteString("				pageInfo {")
+	query.WriteString("					endCursor")
+	query.WriteString("					hasNextPage")
+	query.WriteString("				}")
+	query.WriteString("				edges {")
+	query.WriteString("					role")
+	query.WriteString("					hasTwoFactorEnabled")
+	query.WriteString("					node {")
+	query.WriteString("						login")
+	query.WriteString("						name")
+	query.WriteString("					}")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("		}")
+	query.WriteString("	}")
+
+	return query.String()
+}
+
+func reportMembersTeamQuery() string {
+	var query strings.Builder
+
+	query.WriteString("query ($org: String! $after: String $team: String!) {")
+	query.WriteString("		organization(login:$org) {")
+	query.WriteString("			team(slug:$team) {")
+	query.WriteString("				members(first: 100, after: $after, membership: IMMEDIATE) {")
+	query.WriteString("					pageInfo {")
+	query.WriteString("						endCursor")
+	query.WriteString("						hasNextPage")
+	query.WriteString("					}")
+	query.WriteString("					nodes {")
+	query.WriteString("						login")
+	query.WriteString("					}")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("		}")
+	query.WriteString("	}")
+
+	return query.String()
+}
+
+func reportMembersSAMLQuery() string {
+	var query strings.Builder
+
+	query.WriteString("query ($org: String! $after: String) {")
+	query.WriteString("		organization(login:$org) {")
+	query.WriteString("			samlIdentityProvider {")
+	query.WriteString("				externalIdentities(first: 100, after: $after) {")
+	query.WriteString("					pageInfo {")
+	query.WriteString("						endCursor")
+	query.WriteString("						hasNextPage")
+	query.WriteString("					}")
+	query.WriteString("					nodes {")
+	query.WriteString("						samlIdentity {")
+	query.WriteString("							nameId")
+	query.WriteString("						}")
+	query.WriteString("						user {")
+	query.WriteString("							login")
+	query.WriteString("						}")
+	query.WriteString("					}")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("		}")
+	query.WriteString("	}")
+
+	return query.String()
+}
+
+// nolint // needed for cobra
+func init() {
+	reportMembersCmd.Flags().StringP(
+		"file-path", "f", "members.csv", "file path for report to be created, must be .csv or .json",
+	)
+	reportMembersCmd.Flags().StringP("file-type", "t", "csv", "file type, must be csv or json")
+	rootCmd.AddCommand(reportMembersCmd)
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_reportMembersValidateFlags(t *testing.T) {
+	cmdInvalidDryRun := &cobra.Command{Use: "report-members"}
+
+	cmdInvalidFileType := &cobra.Command{Use: "report-members"}
+	cmdInvalidFileType.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdInvalidFileType.Flags().StringP("file-path", "f", "members.csv", "file path")
+
+	cmdUnknownFileType := &cobra.Command{Use: "report-members"}
+	cmdUnknownFileType.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdUnknownFileType.Flags().StringP("file-path", "f", "members.xml", "file path")
+	cmdUnknownFileType.Flags().StringP("file-type", "t", "xml", "file type")
+
+	cmdValid := &cobra.Command{Use: "report-members"}
+	cmdValid.Flags().BoolP("dry-run", "d", true, "dry run flag")
+	cmdValid.Flags().StringP("file-path", "f", "members.csv", "file path")
+	cmdValid.Flags().StringP("file-type", "t", "csv", "file type")
+
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		want    *reportMembers
+		wantErr bool
+	}{
+		{
+			name:    "reportMembersValidateFlags dry run failure",
+			cmd:     cmdInvalidDryRun,
+			want:    &reportMembers{},
+			wantErr: true,
+		},
+		{
+			name:    "reportMembersValidateFlags file-type failure",
+			cmd:     cmdInvalidFileType,
+			want:    &reportMembers{filePath: "members.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "reportMembersValidateFlags unknown file-type failure",
+			cmd:     cmdUnknownFileType,
+			want:    &reportMembers{filePath: "members.xml", fileType: "xml"},
+			wantErr: true,
+		},
+		{
+			name:    "reportMembersValidateFlags success",
+			cmd:     cmdValid,
+			want:    &reportMembers{dryRun: true, filePath: "members.csv", fileType: "csv"},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &reportMembers{}
+			if err := reportMembersValidateFlags(got, tt.cmd); (err != nil) != tt.wantErr {
+				t.Errorf("reportMembersValidateFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportMembersValidateFlags() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportMembersCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       *reportMembers
+		wantErr bool
+	}{
+		{
+			name:    "reportMembersCreate fails to return members",
+			r:       &reportMembers{reportMembersGetter: &mockReportMembersGetterService{fail: true}},
+			wantErr: true,
+		},
+		{
+			name: "reportMembersCreate dry run success",
+			r: &reportMembers{
+				dryRun:              true,
+				reportMembersGetter: &mockReportMembersGetterService{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportMembersCreate json generate fail",
+			r: &reportMembers{
+				reportMembersGetter: &mockReportMembersGetterService{},
+				reportJSON:          &mockReportJSON{failgenerate: true},
+				fileType:            "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportMembersCreate json uploader fail",
+			r: &reportMembers{
+				reportMembersGetter: &mockReportMembersGetterService{},
+				reportJSON:          &mockReportJSON{failupload: true},
+				fileType:            "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportMembersCreate json success",
+			r: &reportMembers{
+				reportMembersGetter: &mockReportMembersGetterService{},
+				reportJSON:          &mockReportJSON{},
+				fileType:            "json",
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportMembersCreate csv upload fail",
+			r: &reportMembers{
+				reportMembersGetter: &mockReportMembersGetterService{},
+				reportCSV:           &mockReportCSV{failOpen: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportMembersCreate csv success",
+			r: &reportMembers{
+				reportMembersGetter: &mockReportMembersGetterService{},
+				reportCSV:           &mockReportCSV{},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reportMembersCreate(tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("reportMembersCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_reportMembersGetterService_getMembers(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mockFile := func(filePath string) string {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("failed to read test data: %v", err)
+		}
+
+		return string(content)
+	}
+
+	twoFactorDisabled := false
+
+	tests := []struct {
+		name          string
+		report        *reportMembers
+		mockResponses []string
+		mockHTTPCode  int
+		want          []Member
+		wantErr       bool
+	}{
+		{
+			name:          "getMembers graphql call fails",
+			report:        &reportMembers{},
+			mockResponses: []string{"testdata/mockAccessResponseError.json"},
+			mockHTTPCode:  http.StatusBadRequest,
+			wantErr:       true,
+		},
+		{
+			name:          "getMembers dry run",
+			report:        &reportMembers{dryRun: true},
+			mockResponses: []string{"testdata/mockMembersResponse.json"},
+			mockHTTPCode:  http.StatusOK,
+		},
+		{
+			name:   "getMembers team not found",
+			report: &reportMembers{},
+			mockResponses: []string{
+				"testdata/mockMembersResponse.json",
+				"testdata/mockAccessTeamsResponse.json",
+				"testdata/mockAccessTeamNotFoundResponse.json",
+			},
+			mockHTTPCode: http.StatusOK,
+			wantErr:      true,
+		},
+		{
+			name:   "getMembers with SAML identities",
+			report: &reportMembers{},
+			mockResponses: []string{
+				"testdata/mockMembersResponse.json",
+				"testdata/mockAccessTeamsResponse.json",
+				"testdata/mockTeamMembersResponse.json",
+				"testdata/mockSAMLIdentitiesResponse.json",
+			},
+			mockHTTPCode: http.StatusOK,
+			want: []Member{
+				{
+					Login:            "admin-user",
+					Name:             "Admin User",
+					Role:             "admin",
+					TwoFactorEnabled: &twoFactorDisabled,
+					Teams:            []string{"some-team"},
+					SAMLIdentity:     "admin.user@example.com",
+				},
+				{
+					Login: "member-user",
+					Role:  "member",
+				},
+			},
+		},
+		{
+			name:   "getMembers without SSO configured",
+			report: &reportMembers{},
+			mockResponses: []string{
+				"testdata/mockMembersResponse.json",
+				"testdata/mockAccessTeamsResponse.json",
+				"testdata/mockTeamMembersResponse.json",
+				"testdata/mockSAMLNotConfiguredResponse.json",
+			},
+			mockHTTPCode: http.StatusOK,
+			want: []Member{
+				{
+					Login:            "admin-user",
+					Name:             "Admin User",
+					Role:             "admin",
+					TwoFactorEnabled: &twoFactorDisabled,
+					Teams:            []string{"some-team"},
+				},
+				{
+					Login: "member-user",
+					Role:  "member",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responses := make([]*http.Response, 0, len(tt.mockResponses))
+			for _, mockResponse := range tt.mockResponses {
+				responses = append(responses, httpmock.NewStringResponse(tt.mockHTTPCode, mockFile(mockResponse)))
+			}
+
+			httpmock.RegisterResponder(
+				"POST",
+				"https://api.github.com/graphql",
+				httpmock.ResponderFromMultipleResponses(responses),
+			)
+
+			r := &reportMembersGetterService{}
+			got, err := r.getMembers(tt.report)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reportMembersGetterService.getMembers() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportMembersGetterService.getMembers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
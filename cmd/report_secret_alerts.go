@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errSecretAlertsState  = errors.New("state must be open or resolved")
+	reportSecretAlertsCmd = &cobra.Command{ // nolint // needed for cobra
+		Use:   "report-secret-alerts",
+		Short: "Run a report to generate a csv containing the secret scanning alerts for organisation repos",
+		RunE:  reportSecretAlertsRun,
+	}
+)
+
+type reportSecretAlerts struct {
+	reportSecretAlertsGetter reportSecretAlertsGetter
+	reportCSV                reportCSV
+	reportJSON               reportJSON
+	dryRun                   bool
+	filePath                 string
+	fileType                 string
+	state                    string
+	secretTypes              []string
+}
+
+type reportSecretAlertsGetter interface {
+	getAlerts(*reportSecretAlerts) ([]SecretScanningAlert, error)
+}
+
+type reportSecretAlertsGetterService struct{}
+
+func reportSecretAlertsRun(cmd *cobra.Command, args []string) error {
+	report := &reportSecretAlerts{
+		reportSecretAlertsGetter: &reportSecretAlertsGetterService{},
+		reportCSV:                &reportCSVService{},
+		reportJSON:               &reportJSONService{},
+	}
+
+	if err := reportSecretAlertsValidateFlags(report, cmd); err != nil {
+		return err
+	}
+
+	return reportSecretAlertsCreate(report)
+}
+
+func reportSecretAlertsValidateFlags(r *reportSecretAlerts, cmd *cobra.Command) error {
+	var err error
+
+	r.dryRun, err = cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.filePath, err = cmd.Flags().GetString("file-path")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.fileType, err = cmd.Flags().GetString("file-type")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.state, err = cmd.Flags().GetString("state")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if r.state != "open" && r.state != "resolved" {
+		return fmt.Errorf("%w: %s", errSecretAlertsState, r.state)
+	}
+
+	r.secretTypes, err = cmd.Flags().GetStringSlice("secret-type")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+func reportSecretAlertsCreate(r *reportSecretAlerts) error {
+	alerts, err := r.reportSecretAlertsGetter.getAlerts(r)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if r.dryRun {
+		log.Printf("This is a dry run, the report would process %d records\n", len(alerts))
+
+		return nil
+	}
+
+	if r.fileType == "json" {
+		jsonReport, err := r.reportJSON.generateSecretAlerts(alerts)
+		if err != nil {
+			return fmt.Errorf("generate json failed: %w", err)
+		}
+
+		if err := r.reportJSON.uploader(r.filePath, jsonReport); err != nil {
+			return fmt.Errorf("upload json failed: %w", err)
+		}
+
+		return nil
+	}
+
+	lines := reportCSVSecretAlertsGenerate(alerts)
+	if err := reportCSVUpload(r.reportCSV, r.filePath, lines); err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+
+	return nil
+}
+
+// getAlerts returns the organisation secret scanning alerts in the report state, GitHub filters by secret type.
+func (r *reportSecretAlertsGetterService) getAlerts(report *reportSecretAlerts) ([]SecretScanningAlert, error) {
+	var alerts []SecretScanningAlert
+
+	query := url.Values{}
+	query.Set("state", report.state)
+	query.Set("per_page", "100")
+
+	if len(report.secretTypes) > 0 {
+		query.Set("secret_type", strings.Join(report.secretTypes, ","))
+	}
+
+	ctx := context.Background()
+	path := fmt.Sprintf("/orgs/%s/secret-scanning/alerts?%s", config.Org, query.Encode())
+
+	for path != "" {
+		client := restclient.NewClient(path, config.Token, http.MethodGet)
+
+		var response []SecretScanningAlertResponse
+		if err := client.Run(ctx, &response); err != nil {
+			return alerts, fmt.Errorf("list secret scanning alerts: %w", err)
+		}
+
+		for _, alert := range response {
+			alerts = append(alerts, SecretScanningAlert{
+				RepositoryName:         alert.Repository.Name,
+				Number:                 alert.Number,
+				SecretType:             alert.SecretType,
+				SecretTypeDisplayName:  alert.SecretTypeDisplayName,
+				CreatedAt:              alert.CreatedAt,
+				PushProtectionBypassed: alert.PushProtectionBypassed,
+				Resolution:             alert.Resolution,
+				URL:                    alert.HTMLURL,
+			})
+		}
+
+		path = client.NextPath()
+	}
+
+	return alerts, nil
+}
+
+// nolint // needed for cobra
+func init() {
+	reportSecretAlertsCmd.Flags().StringP(
+		"file-path", "f", "secret_alerts.csv", "file path for report to be created, must be .csv or .json",
+	)
+	reportSecretAlertsCmd.Flags().StringP("file-type", "t", "csv", "file type, must be csv or json")
+	reportSecretAlertsCmd.Flags().String("state", "open", "state of the alerts to report, must be open or resolved")
+	reportSecretAlertsCmd.Flags().StringSlice(
+		"secret-type", []string{}, "only report these secret types, e.g. github_personal_access_token, can be repeated",
+	)
+	rootCmd.AddCommand(reportSecretAlertsCmd)
+}
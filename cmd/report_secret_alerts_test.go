@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_reportSecretAlertsValidateFlags(t *testing.T) {
+	mockCmd := func(state string) *cobra.Command {
+		cmd := &cobra.Command{Use: "report-secret-alerts"}
+		cmd.Flags().BoolP("dry-run", "d", false, "dry run flag")
+		cmd.Flags().StringP("file-path", "f", "secret_alerts.csv", "file path")
+		cmd.Flags().StringP("file-type", "t", "csv", "file type")
+		cmd.Flags().String("state", state, "state")
+		cmd.Flags().StringSlice("secret-type", []string{"slack_api_token"}, "secret type")
+
+		return cmd
+	}
+
+	cmdInvalidFileType := &cobra.Command{Use: "report-secret-alerts"}
+	cmdInvalidFileType.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdInvalidFileType.Flags().StringP("file-path", "f", "secret_alerts.csv", "file path")
+
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		want    *reportSecretAlerts
+		wantErr bool
+	}{
+		{
+			name:    "reportSecretAlertsValidateFlags dry run failure",
+			cmd:     &cobra.Command{Use: "report-secret-alerts"},
+			want:    &reportSecretAlerts{},
+			wantErr: true,
+		},
+		{
+			name:    "reportSecretAlertsValidateFlags file-type failure",
+			cmd:     cmdInvalidFileType,
+			want:    &reportSecretAlerts{filePath: "secret_alerts.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "reportSecretAlertsValidateFlags invalid state",
+			cmd:     mockCmd("dismissed"),
+			want:    &reportSecretAlerts{filePath: "secret_alerts.csv", fileType: "csv", state: "dismissed"},
+			wantErr: true,
+		},
+		{
+			name: "reportSecretAlertsValidateFlags success",
+			cmd:  mockCmd("open"),
+			want: &reportSecretAlerts{
+				filePath:    "secret_alerts.csv",
+				fileType:    "csv",
+				state:       "open",
+				secretTypes: []string{"slack_api_token"},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &reportSecretAlerts{}
+			if err := reportSecretAlertsValidateFlags(got, tt.cmd); (err != nil) != tt.wantErr {
+				t.Errorf("reportSecretAlertsValidateFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportSecretAlertsValidateFlags() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportSecretAlertsCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       *reportSecretAlerts
+		wantErr bool
+	}{
+		{
+			name:    "reportSecretAlertsCreate fails to return alerts",
+			r:       &reportSecretAlerts{reportSecretAlertsGetter: &mockReportSecretAlertsGetterService{fail: true}},
+			wantErr: true,
+		},
+		{
+			name: "reportSecretAlertsCreate dry run success",
+			r: &reportSecretAlerts{
+				dryRun:                   true,
+				reportSecretAlertsGetter: &mockReportSecretAlertsGetterService{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportSecretAlertsCreate json generate fail",
+			r: &reportSecretAlerts{
+				reportSecretAlertsGetter: &mockReportSecretAlertsGetterService{},
+				reportJSON:               &mockReportJSON{failgenerate: true},
+				fileType:                 "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportSecretAlertsCreate json uploader fail",
+			r: &reportSecretAlerts{
+				reportSecretAlertsGetter: &mockReportSecretAlertsGetterService{},
+				reportJSON:               &mockReportJSON{failupload: true},
+				fileType:                 "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportSecretAlertsCreate json success",
+			r: &reportSecretAlerts{
+				reportSecretAlertsGetter: &mockReportSecretAlertsGetterService{},
+				reportJSON:               &mockReportJSON{},
+				fileType:                 "json",
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportSecretAlertsCreate csv upload fail",
+			r: &reportSecretAlerts{
+				reportSecretAlertsGetter: &mockReportSecretAlertsGetterService{},
+				reportCSV:                &mockReportCSV{failOpen: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportSecretAlertsCreate csv success",
+			r: &reportSecretAlerts{
+				reportSecretAlertsGetter: &mockReportSecretAlertsGetterService{},
+				reportCSV:                &mockReportCSV{},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reportSecretAlertsCreate(tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("reportSecretAlertsCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_reportSecretAlertsGetterService_getAlerts(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	alertsURL := "https://api.github.com/orgs/some-org/secret-scanning/alerts"
+
+	mockFile := func(filePath string) string {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("failed to read test data: %v", err)
+		}
+
+		return string(content)
+	}
+
+	tests := []struct {
+		name       string
+		report     *reportSecretAlerts
+		firstURL   string
+		statusCode int
+		nextURL    string
+		want       []SecretScanningAlert
+		wantErr    bool
+	}{
+		{
+			name:       "getAlerts fails",
+			report:     &reportSecretAlerts{state: "open"},
+			firstURL:   alertsURL + "?per_page=100&state=open",
+			statusCode: http.StatusNotFound,
+			wantErr:    true,
+		},
+		{
+			name:       "getAlerts filtered by secret type",
+			report:     &reportSecretAlerts{state: "open", secretTypes: []string{"github_personal_access_token"}},
+			firstURL:   alertsURL + "?per_page=100&secret_type=github_personal_access_token&state=open",
+			statusCode: http.StatusOK,
+			want: []SecretScanningAlert{
+				{
+					RepositoryName:         "repo1",
+					Number:                 2,
+					SecretType:             "github_personal_access_token",
+					SecretTypeDisplayName:  "GitHub Personal Access Token",
+					CreatedAt:              "2026-10-01T07:43:03Z",
+					PushProtectionBypassed: true,
+					URL:                    "https://github.com/some-org/repo1/security/secret-scanning/2",
+				},
+				{
+					RepositoryName:        "repo2",
+					Number:                1,
+					SecretType:            "slack_api_token",
+					SecretTypeDisplayName: "Slack API Token",
+					CreatedAt:             "2026-09-19T07:43:03Z",
+					URL:                   "https://github.com/some-org/repo2/security/secret-scanning/1",
+				},
+			},
+		},
+		{
+			name:       "getAlerts follows next page",
+			report:     &reportSecretAlerts{state: "resolved"},
+			firstURL:   alertsURL + "?per_page=100&state=resolved",
+			statusCode: http.StatusOK,
+			nextURL:    alertsURL + "?per_page=100&state=resolved&page=2",
+			want: []SecretScanningAlert{
+				{
+					RepositoryName:         "repo1",
+					Number:                 2,
+					SecretType:             "github_personal_access_token",
+					SecretTypeDisplayName:  "GitHub Personal Access Token",
+					CreatedAt:              "2026-10-01T07:43:03Z",
+					PushProtectionBypassed: true,
+					URL:                    "https://github.com/some-org/repo1/security/secret-scanning/2",
+				},
+				{
+					RepositoryName:        "repo2",
+					Number:                1,
+					SecretType:            "slack_api_token",
+					SecretTypeDisplayName: "Slack API Token",
+					CreatedAt:             "2026-09-19T07:43:03Z",
+					URL:                   "https://github.com/some-org/repo2/security/secret-scanning/1",
+				},
+				{
+					RepositoryName:        "repo1",
+					Number:                5,
+					SecretType:            "github_personal_access_token",
+					SecretTypeDisplayName: "GitHub Personal Access Token",
+					CreatedAt:             "2026-08-20T07:43:03Z",
+					Resolution:            "revoked",
+					URL:                   "https://github.com/some-org/repo1/security/secret-scanning/5",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			firstPage := httpmock.NewStringResponse(tt.statusCode, mockFile("testdata/mockSecretAlertsResponse.json"))
+			if tt.nextURL != "" {
+				firstPage.Header.Set("Link", "<"+tt.nextURL+`>; rel="next"`)
+				mockHTTPResponder("GET", tt.nextURL, "testdata/mockSecretAlertsPage2Response.json", http.StatusOK)
+			}
+
+			httpmock.RegisterResponder("GET", tt.firstURL, httpmock.ResponderFromResponse(firstPage))
+
+			r := &reportSecretAlertsGetterService{}
+			got, err := r.getAlerts(tt.report)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reportSecretAlertsGetterService.getAlerts() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportSecretAlertsGetterService.getAlerts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/graphqlclient"
+	"github-admin-tool/progressbar"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	securityStatusEnabled     = "enabled"
+	securityStatusDisabled    = "disabled"
+	securityStatusUnavailable = "unavailable"
+)
+
+var reportSecurityCmd = &cobra.Command{ // nolint // needed for cobra
+	Use:   "report-security",
+	Short: "Run a report to generate a csv containing the security feature status of organisation repos",
+	RunE:  reportSecurityRun,
+}
+
+type reportSecurity struct {
+	reportSecurityGetter reportSecurityGetter
+	reportCSV            reportCSV
+	reportJSON           reportJSON
+	dryRun               bool
+	ignoreArchived       bool
+	filePath             string
+	fileType             string
+}
+
+type reportSecurityGetter interface {
+	getRepositoryList(*reportSecurity) ([]SecurityStatus, error)
+	getSecurityStatus([]SecurityStatus) ([]SecurityStatus, error)
+}
+
+type reportSecurityGetterService struct{}
+
+func reportSecurityRun(cmd *cobra.Command, args []string) error {
+	report := &reportSecurity{
+		reportSecurityGetter: &reportSecurityGetterService{},
+		reportCSV:            &reportCSVService{},
+		reportJSON:           &reportJSONService{},
+	}
+
+	if err := reportSecurityValidateFlags(report, cmd); err != nil {
+		return err
+	}
+
+	return reportSecurityCreate(report)
+}
+
+func reportSecurityValidateFlags(r *reportSecurity, cmd *cobra.Command) error {
+	var err error
+
+	r.dryRun, err = cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.ignoreArchived, err = cmd.Flags().GetBool("ignore-archived")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.filePath, err = cmd.Flags().GetString("file-path")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.fileType, err = cmd.Flags().GetString("file-type")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+func reportSecurityCreate(r *reportSecurity) error {
+	repositories, err := r.reportSecurityGetter.getRepositoryList(r)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if r.dryRun {
+		return nil
+	}
+
+	allStatus, err := r.reportSecurityGetter.getSecurityStatus(repositories)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if r.fileType == "json" {
+		jsonReport, err := r.reportJSON.generateSecurity(allStatus)
+		if err != nil {
+			return fmt.Errorf("generate json failed: %w", err)
+		}
+
+		if err := r.reportJSON.uploader(r.filePath, jsonReport); err != nil {
+			return fmt.Errorf("upload json failed: %w", err)
+		}
+
+		return nil
+	}
+
+	lines := reportCSVSecurityGenerate(allStatus)
+	if err := reportCSVUpload(r.reportCSV, r.filePath, lines); err != nil {
+		return fmt.Errorf("upload failed: %w", err)
+	}
+
+	return nil
+}
+
+// getRepositoryList returns the repositories with their vulnerability alert status, the remaining features
+// are only available from REST.
+func (r *reportSecurityGetterService) getRepositoryList(report *reportSecurity) ([]SecurityStatus, error) {
+	var (
+		cursor     *string
+		totalCount int
+		result     []SecurityStatus
+		iteration  int
+		bar        progressbar.Bar
+	)
+
+	client := graphqlclient.NewClient()
+	req := reportRequest(reportSecurityQuery())
+	ctx := context.Background()
+
+	for {
+		// Set new cursor on every loop to paginate through 100 at a time
+		req.Var("after", cursor)
+
+		var response SecurityRepositoryResponse
+		if err := client.Run(ctx, req, &response); err != nil {
+			return result, fmt.Errorf("graphql call: %w", err)
+		}
+
+		cursor = &response.Organization.Repositories.PageInfo.EndCursor
+		totalCount = response.Organization.Repositories.TotalCount
+
+		if report.dryRun {
+			log.Printf("This is a dry run, the report would process %d records\n", totalCount)
+
+			return result, nil
+		}
+
+		for _, node := range response.Organization.Repositories.Nodes {
+			if report.ignoreArchived && node.IsArchived {
+				continue
+			}
+
+			vulnerabilityAlerts := securityStatusDisabled
+			if node.HasVulnerabilityAlertsEnabled {
+				vulnerabilityAlerts = securityStatusEnabled
+			}
+
+			result = append(result, SecurityStatus{RepositoryName: node.Name, VulnerabilityAlerts: vulnerabilityAlerts})
+		}
+
+		if iteration == 0 {
+			bar.NewOption(0, totalCount)
+		}
+
+		bar.Play(iteration)
+
+		iteration += IterationCount
+
+		if !response.Organization.Repositories.PageInfo.HasNextPage {
+			break
+		}
+	}
+
+	bar.Play(totalCount)
+	bar.Finish("Get repository data")
+
+	return result, nil
+}
+
+// getSecurityStatus adds the security_and_analysis settings, code scanning default setup and private
+// vulnerability reporting state to each repository. A repository that fails is kept with the error so it is not
+// mistaken for one with the features turned off.
+func (r *reportSecurityGetterService) getSecurityStatus(repositories []SecurityStatus) ([]SecurityStatus, error) {
+	var (
+		allResults []SecurityStatus
+		bar        progressbar.Bar
+	)
+
+	ctx := context.Background()
+
+	bar.NewOption(0, len(repositories))
+
+	for iteration, repository := range repositories {
+		bar.Play(iteration)
+
+		if err := reportSecurityRepository(ctx, &repository); err != nil {
+			log.Printf("Error (%s): %v", repository.RepositoryName, err)
+
+			repository.Error = err.Error()
+		}
+
+		allResults = append(allResults, repository)
+	}
+
+	bar.Play(len(repositories))
+	bar.Finish("Get security data")
+
+	return allResults, nil
+}
+
+func reportSecurityRepository(ctx context.Context, repository *SecurityStatus) error {
+	client := restclient.NewClient(
+		fmt.Sprintf("/repos/%s/%s", config.Org, repository.RepositoryName),
+		config.Token,
+		http.MethodGet,
+	)
+
+	var response struct {
+		SecurityAndAnalysis SecurityAndAnalysis `json:"security_and_analysis"` // nolint // this is from github
+	}
+	if err := client.Run(ctx, &response); err != nil {
+		return fmt.Errorf("get repository: %w", err)
+	}
+
+	repository.AutomatedSecurityFixes = securityAndAnalysisStatus(response.SecurityAndAnalysis.DependabotSecurityUpdates)
+	repository.SecretScanning = securityAndAnalysisStatus(response.SecurityAndAnalysis.SecretScanning)
+	repository.PushProtection = securityAndAnalysisStatus(response.SecurityAndAnalysis.SecretScanningPushProtection)
+
+	codeScanningDefaultSetup, err := reportSecurityCodeScanningDefaultSetup(ctx, repository.RepositoryName)
+	if err != nil {
+		return err
+	}
+
+	repository.CodeScanningDefaultSetup = codeScanningDefaultSetup
+
+	privateVulnerabilityReporting, err := reportSecurityPrivateVulnerabilityReporting(ctx, repository.RepositoryName)
+	if err != nil {
+		return err
+	}
+
+	repository.PrivateVulnerabilityReporting = privateVulnerabilityReporting
+
+	return nil
+}
+
+// reportSecurityCodeScanningDefaultSetup returns configured or not-configured, or unavailable when code scanning
+// cannot be used on the repository, e.g. a private repository without Advanced Security.
+func reportSecurityCodeScanningDefaultSetup(ctx context.Context, repositoryName string) (string, error) {
+	client := restclient.NewClient(
+		fmt.Sprintf("/repos/%s/%s/code-scanning/default-setup", config.Org, repositoryName),
+		config.Token,
+		http.MethodGet,
+	)
+
+	var response struct {
+		State string `json:"state"`
+	}
+	if err := client.Run(ctx, &response); err != nil {
+		if errors.Is(err, restclient.ErrNotFound) || errors.Is(err, restclient.ErrForbidden) {
+			return securityStatusUnavailable, nil
+		}
+
+		return "", fmt.Errorf("get code scanning default setup: %w", err)
+	}
+
+	return response.State, nil
+}
+
+func reportSecurityPrivateVulnerabilityReporting(ctx context.Context, repositoryName string) (string, error) {
+	client := restclient.NewClient(
+		fmt.Sprintf("/repos/%s/%s/private-vulnerability-reporting", config.Org, repositoryName),
+		config.Token,
+		http.MethodGet,
+	)
+
+	var response struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := client.Run(ctx, &response); err != nil {
+		if errors.Is(err, restclient.ErrNotFound) {
+			return securityStatusUnavailable, nil
+		}
+
+		return "", fmt.Errorf("get private vulnerability reporting: %w", err)
+	}
+
+	if response.Enabled {
+		return securityStatusEnabled, nil
+	}
+
+	return securityStatusDisabled, nil
+}
+
+// securityAndAnalysisStatus returns the status of the feature, a missing feature is not available to the repository.
+func securityAndAnalysisStatus(status *SecurityAndAnalysisStatus) string {
+	if status == nil || status.Status == "" {
+		return securityStatusUnavailable
+	}
+
+	return status.Status
+}
+
+func reportSecurityQuery() string {
+	var query strings.Builder
+
+	query.WriteString("query ($org: String! $after: String) {")
+	query.WriteString("		organization(login:$org) {")
+	query.WriteString("			repositories(first: 100, after: $after, orderBy: {field: NAME, direction: ASC}) {")
+	query.WriteString("				totalCount")
+	query.WriteString("				pageInfo {")
+	query.WriteString("					endCursor")
+	query.WriteString("					hasNextPage")
+	query.WriteString("				}")
+	query.WriteString("				nodes {")
+	query.WriteString("					name")
+	query.WriteString("					isArchived")
+	query.WriteString("					hasVulnerabilityAlertsEnabled")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("		}")
+	query.WriteString("}")
+
+	return query.String()
+}
+
+// nolint // needed for cobra
+func init() {
+	reportSecurityCmd.Flags().BoolP("ignore-archived", "i", true, "Ignore archived repositories")
+	reportSecurityCmd.Flags().StringP(
+		"file-path", "f", "security.csv", "file path for report to be created, must be .csv or .json",
+	)
+	reportSecurityCmd.Flags().StringP("file-type", "t", "csv", "file type, must be csv or json")
+	rootCmd.AddCommand(reportSecurityCmd)
+}
@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_reportSecurityValidateFlags(t *testing.T) {
+	cmdInvalidDryRun := &cobra.Command{Use: "report-security"}
+
+	cmdInvalidFileType := &cobra.Command{Use: "report-security"}
+	cmdInvalidFileType.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdInvalidFileType.Flags().BoolP("ignore-archived", "i", false, "ignore-archived flag")
+	cmdInvalidFileType.Flags().StringP("file-path", "f", "security.csv", "file path")
+
+	cmdValid := &cobra.Command{Use: "report-security"}
+	cmdValid.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdValid.Flags().BoolP("ignore-archived", "i", true, "ignore-archived flag")
+	cmdValid.Flags().StringP("file-path", "f", "security.csv", "file path")
+	cmdValid.Flags().StringP("file-type", "t", "csv", "file type")
+
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		want    *reportSecurity
+		wantErr bool
+	}{
+		{
+			name:    "reportSecurityValidateFlags dry run failure",
+			cmd:     cmdInvalidDryRun,
+			want:    &reportSecurity{},
+			wantErr: true,
+		},
+		{
+			name:    "reportSecurityValidateFlags file-type failure",
+			cmd:     cmdInvalidFileType,
+			want:    &reportSecurity{filePath: "security.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "reportSecurityValidateFlags success",
+			cmd:     cmdValid,
+			want:    &reportSecurity{ignoreArchived: true, filePath: "security.csv", fileType: "csv"},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &reportSecurity{}
+			if err := reportSecurityValidateFlags(got, tt.cmd); (err != nil) != tt.wantErr {
+				t.Errorf("reportSecurityValidateFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportSecurityValidateFlags() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportSecurityCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       *reportSecurity
+		wantErr bool
+	}{
+		{
+			name:    "reportSecurityCreate fails to return repositories",
+			r:       &reportSecurity{reportSecurityGetter: &mockReportSecurityGetterService{failRepoList: true}},
+			wantErr: true,
+		},
+		{
+			name: "reportSecurityCreate dry run success",
+			r: &reportSecurity{
+				dryRun:               true,
+				reportSecurityGetter: &mockReportSecurityGetterService{failStatus: true},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "reportSecurityCreate get status fail",
+			r:       &reportSecurity{reportSecurityGetter: &mockReportSecurityGetterService{failStatus: true}},
+			wantErr: true,
+		},
+		{
+			name: "reportSecurityCreate json generate fail",
+			r: &reportSecurity{
+				reportSecurityGetter: &mockReportSecurityGetterService{},
+				reportJSON:           &mockReportJSON{failgenerate: true},
+				fileType:             "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportSecurityCreate json uploader fail",
+			r: &reportSecurity{
+				reportSecurityGetter: &mockReportSecurityGetterService{},
+				reportJSON:           &mockReportJSON{failupload: true},
+				fileType:             "json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportSecurityCreate json success",
+			r: &reportSecurity{
+				reportSecurityGetter: &mockReportSecurityGetterService{},
+				reportJSON:           &mockReportJSON{},
+				fileType:             "json",
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportSecurityCreate csv upload fail",
+			r: &reportSecurity{
+				reportSecurityGetter: &mockReportSecurityGetterService{},
+				reportCSV:            &mockReportCSV{failOpen: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportSecurityCreate csv success",
+			r: &reportSecurity{
+				reportSecurityGetter: &mockReportSecurityGetterService{},
+				reportCSV:            &mockReportCSV{},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reportSecurityCreate(tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("reportSecurityCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_reportSecurityGetterService_getRepositoryList(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	tests := []struct {
+		name               string
+		report             *reportSecurity
+		mockHTTPReturnFile string
+		mockHTTPStatusCode int
+		want               []SecurityStatus
+		wantErr            bool
+	}{
+		{
+			name:               "getRepositoryList fails graphql call",
+			report:             &reportSecurity{},
+			mockHTTPReturnFile: "testdata/mockEmptyResponse.json",
+			mockHTTPStatusCode: 401,
+			wantErr:            true,
+		},
+		{
+			name:               "getRepositoryList dry run",
+			report:             &reportSecurity{dryRun: true},
+			mockHTTPReturnFile: "testdata/mockGraphqlSecurityRepoResponse.json",
+			mockHTTPStatusCode: 200,
+			wantErr:            false,
+		},
+		{
+			name:               "getRepositoryList with ignore archived",
+			report:             &reportSecurity{ignoreArchived: true},
+			mockHTTPReturnFile: "testdata/mockGraphqlSecurityRepoResponse.json",
+			mockHTTPStatusCode: 200,
+			want:               []SecurityStatus{{RepositoryName: "repo2", VulnerabilityAlerts: "enabled"}},
+			wantErr:            false,
+		},
+		{
+			name:               "getRepositoryList including archived",
+			report:             &reportSecurity{},
+			mockHTTPReturnFile: "testdata/mockGraphqlSecurityRepoResponse.json",
+			mockHTTPStatusCode: 200,
+			want: []SecurityStatus{
+				{RepositoryName: "repo1", VulnerabilityAlerts: "disabled"},
+				{RepositoryName: "repo2", VulnerabilityAlerts: "enabled"},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTPResponder("POST", "https://api.github.com/graphql", tt.mockHTTPReturnFile, tt.mockHTTPStatusCode)
+
+			r := &reportSecurityGetterService{}
+			got, err := r.getRepositoryList(tt.report)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reportSecurityGetterService.getRepositoryList() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportSecurityGetterService.getRepositoryList() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportSecurityGetterService_getSecurityStatus(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	repoURL := "https://api.github.com/repos/some-org/repo1"
+
+	tests := []struct {
+		name             string
+		repositoryFile   string
+		repositoryStatus int
+		defaultSetupFile string
+		defaultSetupCode int
+		reportingFile    string
+		reportingCode    int
+		want             []SecurityStatus
+	}{
+		{
+			name:             "getSecurityStatus repository fails and is kept with the error",
+			repositoryFile:   "testdata/mockRest404Response.json",
+			repositoryStatus: 404,
+			want: []SecurityStatus{{
+				RepositoryName:      "repo1",
+				VulnerabilityAlerts: "enabled",
+				Error:               "get repository: not found status, " + repoURL,
+			}},
+		},
+		{
+			name:             "getSecurityStatus default setup fails and is kept with the error",
+			repositoryFile:   "testdata/mockGetRepositorySecurityResponse.json",
+			repositoryStatus: 200,
+			defaultSetupFile: "testdata/mockRest404Response.json",
+			defaultSetupCode: 500,
+			want: []SecurityStatus{{
+				RepositoryName:         "repo1",
+				VulnerabilityAlerts:    "enabled",
+				AutomatedSecurityFixes: "enabled",
+				SecretScanning:         "enabled",
+				PushProtection:         "disabled",
+				Error: "get code scanning default setup: incorrect status: returned a non-200 status code '500', " +
+					repoURL + "/code-scanning/default-setup",
+			}},
+		},
+		{
+			name:             "getSecurityStatus code scanning unavailable",
+			repositoryFile:   "testdata/mockGetRepositorySecurityResponse.json",
+			repositoryStatus: 200,
+			defaultSetupFile: "testdata/mockRest403Response.json",
+			defaultSetupCode: 403,
+			reportingFile:    "testdata/mockRest404Response.json",
+			reportingCode:    404,
+			want: []SecurityStatus{{
+				RepositoryName:                "repo1",
+				VulnerabilityAlerts:           "enabled",
+				AutomatedSecurityFixes:        "enabled",
+				SecretScanning:                "enabled",
+				PushProtection:                "disabled",
+				CodeScanningDefaultSetup:      "unavailable",
+				PrivateVulnerabilityReporting: "unavailable",
+			}},
+		},
+		{
+			name:             "getSecurityStatus success",
+			repositoryFile:   "testdata/mockGetRepositorySecurityResponse.json",
+			repositoryStatus: 200,
+			defaultSetupFile: "testdata/mockCodeScanningDefaultSetupResponse.json",
+			defaultSetupCode: 200,
+			reportingFile:    "testdata/mockPrivateVulnerabilityReportingResponse.json",
+			reportingCode:    200,
+			want: []SecurityStatus{{
+				RepositoryName:                "repo1",
+				VulnerabilityAlerts:           "enabled",
+				AutomatedSecurityFixes:        "enabled",
+				SecretScanning:                "enabled",
+				PushProtection:                "disabled",
+				CodeScanningDefaultSetup:      "configured",
+				PrivateVulnerabilityReporting: "enabled",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			mockHTTPResponder("GET", repoURL, tt.repositoryFile, tt.repositoryStatus)
+
+			if tt.defaultSetupFile != "" {
+				mockHTTPResponder("GET", repoURL+"/code-scanning/default-setup", tt.defaultSetupFile, tt.defaultSetupCode)
+			}
+
+			if tt.reportingFile != "" {
+				mockHTTPResponder("GET", repoURL+"/private-vulnerability-reporting", tt.reportingFile, tt.reportingCode)
+			}
+
+			r := &reportSecurityGetterService{}
+			got, err := r.getSecurityStatus([]SecurityStatus{{RepositoryName: "repo1", VulnerabilityAlerts: "enabled"}})
+			if err != nil {
+				t.Errorf("reportSecurityGetterService.getSecurityStatus() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportSecurityGetterService.getSecurityStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_securityAndAnalysisStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *SecurityAndAnalysisStatus
+		want   string
+	}{
+		{
+			name: "securityAndAnalysisStatus missing feature",
+			want: "unavailable",
+		},
+		{
+			name:   "securityAndAnalysisStatus enabled",
+			status: &SecurityAndAnalysisStatus{Status: "enabled"},
+			want:   "enabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := securityAndAnalysisStatus(tt.status); got != tt.want {
+				t.Errorf("securityAndAnalysisStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,25 +21,30 @@ func Test_reportGetterService_getReport(t *testing.T) {
 
 	tests := []struct {
 		name               string
-		r                  *reportGetterService
+		sender             *mockRepositorySender
 		mockHTTPReturnFile string
 		want               []ReportResponse
+		wantErr            bool
 		dryRunValue        bool
 	}{
 		{
 			name:               "getReport returns empty",
+			sender:             &mockRepositorySender{},
 			mockHTTPReturnFile: "testdata/mockEmptyResponse.json",
 			want:               nil,
+			wantErr:            true,
 			dryRunValue:        false,
 		},
 		{
 			name:               "getReport dry run true",
+			sender:             &mockRepositorySender{},
 			mockHTTPReturnFile: "testdata/mockRepoNodesJsonResponse.json",
 			want:               mockEmptyResult,
 			dryRunValue:        true,
 		},
 		{
 			name:               "getReport returns one",
+			sender:             &mockRepositorySender{sendFail: true},
 			mockHTTPReturnFile: "testdata/mockRepoNodesJsonResponse.json",
 			want: []ReportResponse{{Organization{Repositories{
 				TotalCount: 1,
@@ -52,6 +57,35 @@ func Test_reportGetterService_getReport(t *testing.T) {
 			}}}},
 			dryRunValue: false,
 		},
+		{
+			name:               "getReport fails on remaining branch protection rules",
+			sender:             &mockRepositorySender{sendFail: true},
+			mockHTTPReturnFile: "testdata/mockRepoNodesTruncatedJsonResponse.json",
+			wantErr:            true,
+		},
+		{
+			name: "getReport adds remaining branch protection rules",
+			sender: &mockRepositorySender{
+				returnValue: map[string]*RepositoriesNode{"repository": {
+					BranchProtectionRules: BranchProtectionRules{
+						Nodes: []BranchProtectionRulesNode{{Pattern: "main"}},
+					},
+				}},
+			},
+			mockHTTPReturnFile: "testdata/mockRepoNodesTruncatedJsonResponse.json",
+			want: []ReportResponse{{Organization{Repositories{
+				TotalCount: 1,
+				Nodes: []RepositoriesNode{{
+					Name:          "repo-name",
+					NameWithOwner: "org-name/repo-name",
+					BranchProtectionRules: BranchProtectionRules{
+						Nodes: []BranchProtectionRulesNode{{Pattern: "main"}},
+					},
+					DefaultBranchRef:   DefaultBranchRef{"main"},
+					SquashMergeAllowed: true,
+				}},
+			}}}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -59,16 +93,114 @@ func Test_reportGetterService_getReport(t *testing.T) {
 
 			dryRun = tt.dryRunValue
 
-			if got, err := tt.r.getReport(); !reflect.DeepEqual(got, tt.want) {
-				if err != nil {
-					t.Fatalf("failed to run reportGet %v", err)
-				}
+			r := &reportGetterService{sender: &githubRepositorySender{sender: tt.sender}}
+
+			got, err := r.getReport()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getReport() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("getReport() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func Test_rulesetsGetRemaining(t *testing.T) {
+	newRepository := func(rules RulesetRules, hasNextPage bool) *RepositoriesNode {
+		return &RepositoriesNode{
+			ID:            "repoIdTEST",
+			NameWithOwner: "org/some-repo-name",
+			Rulesets: Rulesets{
+				PageInfo: PageInfo{EndCursor: "cursor1", HasNextPage: hasNextPage},
+				Nodes:    []RulesetsNode{{Name: "main", Rules: rules}},
+			},
+		}
+	}
+
+	truncatedRules := RulesetRules{TotalCount: 11, Nodes: make([]RulesetRulesNode, 10)}
+
+	type args struct {
+		repository *RepositoriesNode
+		sender     *githubRepositorySender
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name: "rulesetsGetRemaining not truncated",
+			args: args{
+				repository: newRepository(RulesetRules{TotalCount: 1, Nodes: make([]RulesetRulesNode, 1)}, false),
+				sender: &githubRepositorySender{
+					sender: &mockRepositorySender{sendFail: true},
+				},
+			},
+			wantNames: []string{"main"},
+		},
+		{
+			name: "rulesetsGetRemaining fails on send",
+			args: args{
+				repository: newRepository(truncatedRules, false),
+				sender: &githubRepositorySender{
+					sender: &mockRepositorySender{sendFail: true},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rulesetsGetRemaining fails on missing repository",
+			args: args{
+				repository: newRepository(RulesetRules{}, true),
+				sender: &githubRepositorySender{
+					sender: &mockRepositorySender{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rulesetsGetRemaining fetches rulesets again when rules are truncated",
+			args: args{
+				repository: newRepository(truncatedRules, false),
+				sender: &githubRepositorySender{
+					sender: &mockRepositorySender{
+						returnValue: map[string]*RepositoriesNode{"repository": {
+							Rulesets: Rulesets{
+								Nodes: []RulesetsNode{{Name: "main"}, {Name: "release"}},
+							},
+						}},
+					},
+				},
+			},
+			wantNames: []string{"main", "release"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rulesetsGetRemaining(tt.args.repository, tt.args.sender)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rulesetsGetRemaining() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var gotNames []string
+			for _, ruleset := range tt.args.repository.Rulesets.Nodes {
+				gotNames = append(gotNames, ruleset.Name)
+			}
+
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("rulesetsGetRemaining() names = %v, want %v", gotNames, tt.wantNames)
+			}
+		})
+	}
+}
+
 func Test_reportRun(t *testing.T) {
 	type args struct {
 		cmd  *cobra.Command
@@ -104,6 +236,16 @@ func Test_reportRun(t *testing.T) {
 	mockCmdFileTypeMissing.Flags().BoolVarP(&mockIgnoreArchived, "ignore-archived", "i", true, "ignore flag")
 	mockCmdFileTypeMissing.Flags().StringVarP(&mockFilePath, "file-path", "f", "report.csv", "file path flag")
 
+	mockCmdBothTeamFlags := &cobra.Command{
+		Use: "report",
+	}
+	mockCmdBothTeamFlags.Flags().BoolVarP(&mockDryRun, "dry-run", "d", true, "dry run flag")
+	mockCmdBothTeamFlags.Flags().BoolVarP(&mockIgnoreArchived, "ignore-archived", "i", true, "ignore flag")
+	mockCmdBothTeamFlags.Flags().StringVarP(&mockFilePath, "file-path", "f", "report.csv", "file path flag")
+	mockCmdBothTeamFlags.Flags().StringVarP(&mockFileType, "file-type", "t", "csv", "file type flag")
+	mockCmdBothTeamFlags.Flags().StringSlice("teams", []string{"some-team"}, "teams flag")
+	mockCmdBothTeamFlags.Flags().Bool("all-teams", true, "all teams flag")
+
 	mockCmdAllFlagsSet := &cobra.Command{
 		Use: "report",
 	}
@@ -111,6 +253,8 @@ func Test_reportRun(t *testing.T) {
 	mockCmdAllFlagsSet.Flags().BoolVarP(&mockIgnoreArchived, "ignore-archived", "i", true, "ignore flag")
 	mockCmdAllFlagsSet.Flags().StringVarP(&mockFilePath, "file-path", "f", "report.csv", "file path flag")
 	mockCmdAllFlagsSet.Flags().StringVarP(&mockFileType, "file-type", "t", "csv", "file type flag")
+	mockCmdAllFlagsSet.Flags().StringSlice("teams", []string{}, "teams flag")
+	mockCmdAllFlagsSet.Flags().Bool("all-teams", false, "all teams flag")
 
 	tests := []struct {
 		name       string
@@ -150,6 +294,14 @@ func Test_reportRun(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "flag accessed but not defined: file-type",
 		},
+		{
+			name: "reportRun teams and all-teams error",
+			args: args{
+				cmd: mockCmdBothTeamFlags,
+			},
+			wantErr:    true,
+			wantErrMsg: "cannot set both teams and all-teams",
+		},
 		{
 			name: "reportRun success",
 			args: args{
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github-admin-tool/graphqlclient"
 	"github-admin-tool/progressbar"
@@ -10,6 +12,8 @@ import (
 	"github-admin-tool/restclient"
 	"log"
 	"net/http"
+	"net/url"
+	"os"
 	"strings"
 	"time"
 
@@ -17,9 +21,10 @@ import (
 )
 
 var (
-	jsonMarshal           = json.Marshal     // nolint // expected global
-	reportWebhookResponse WebhookCmdResponse // nolint // expected global
-	reportWebhookCmd      = &cobra.Command{  // nolint // expected global
+	errWebhookAuditFindings = errors.New("webhook audit findings")
+	jsonMarshal             = json.Marshal     // nolint // expected global
+	reportWebhookResponse   WebhookCmdResponse // nolint // expected global
+	reportWebhookCmd        = &cobra.Command{  // nolint // expected global
 		Use:   "report-webhook",
 		Short: "Run a report to generate a csv containing webhooks for organisation repos",
 		Long: `Webhook report can often run over 15 minutes depending on large number of repositories in your org.  
@@ -40,6 +45,15 @@ func init() { // nolint // needed for cobra
 	reportWebhookCmd.Flags().IntP(
 		"timeout", "o", 60, "Timeout for script (in minutes), useful when calling from Lambdas",
 	)
+	reportWebhookCmd.Flags().Bool(
+		"deliveries", false, "Include the last status code, last success and failure rate of recent webhook deliveries",
+	)
+	reportWebhookCmd.Flags().Bool(
+		"audit", false, "Report webhook findings instead of webhooks, exits with an error when there are findings",
+	)
+	reportWebhookCmd.Flags().String(
+		"allow-list", "", "File of allowed webhook hostnames (one per line, *.example.com allows subdomains), used with audit",
+	)
 	rootCmd.AddCommand(reportWebhookCmd)
 }
 
@@ -66,11 +80,15 @@ type reportWebhook struct {
 	fileType            string
 	startCursor         string
 	timeout             int
+	deliveries          bool
+	audit               bool
+	allowedHosts        []string
 }
 
 type reportWebhookGetter interface {
 	getRepositoryList(*reportWebhook) ([]repositoryCursorList, error)
 	getWebhooks(*reportWebhook, []repositoryCursorList) ([]Webhooks, error)
+	getOrgWebhooks(*reportWebhook) (Webhooks, error)
 }
 
 type repositoryCursorList struct {
@@ -114,7 +132,15 @@ func reportWebhookRun(cmd *cobra.Command, args []string) error {
 	log.Printf("Rate limit remaining %d", reportWebhookResponse.RateLimit)
 	log.Printf("Rate limit reset %v", time.Unix(reportWebhookResponse.RateLimitResetSecs, 0).Format(time.RFC1123))
 
-	return reportWebhookCreate(report)
+	err := reportWebhookCreate(report)
+	if errors.Is(err, errWebhookAuditFindings) {
+		// Cobra skips the post run when the command fails, the status file is still needed to resume
+		if postErr := reportWebhookPostRun(cmd, args); postErr != nil {
+			return postErr
+		}
+	}
+
+	return err
 }
 
 func reportWebhookValidateFlags(r *reportWebhook, cmd *cobra.Command) error {
@@ -156,6 +182,27 @@ func reportWebhookValidateFlags(r *reportWebhook, cmd *cobra.Command) error {
 		return errInvalidTimeout
 	}
 
+	r.deliveries, err = cmd.Flags().GetBool("deliveries")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	r.audit, err = cmd.Flags().GetBool("audit")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	allowList, err := cmd.Flags().GetString("allow-list")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if allowList != "" {
+		if r.allowedHosts, err = reportWebhookAllowList(allowList); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -174,6 +221,22 @@ func reportWebhookCreate(r *reportWebhook) error {
 		return fmt.Errorf("%w", err)
 	}
 
+	// A run resuming from a cursor has already reported the organisation webhooks
+	if r.startCursor == "" {
+		orgWebhooks, err := r.reportWebhookGetter.getOrgWebhooks(r)
+		if err != nil {
+			// Listing organisation webhooks needs the admin:org_hook scope, the repository webhooks are still reported
+			reportWebhookResponse.Errors = append(reportWebhookResponse.Errors, err.Error())
+			log.Printf("Organisation webhooks not reported: %v", err)
+		} else {
+			allWebhooks = append([]Webhooks{orgWebhooks}, allWebhooks...)
+		}
+	}
+
+	if r.audit {
+		return reportWebhookAuditCreate(r, allWebhooks)
+	}
+
 	if r.fileType == "json" {
 		jsonReport, err := r.reportJSON.generateWebhook(allWebhooks)
 		if err != nil {
@@ -187,7 +250,7 @@ func reportWebhookCreate(r *reportWebhook) error {
 		return nil
 	}
 
-	lines := reportCSVWebhookGenerate(allWebhooks)
+	lines := reportCSVWebhookGenerate(allWebhooks, r.deliveries)
 	if err := reportCSVUpload(r.reportCSV, r.filePath, lines); err != nil {
 		return fmt.Errorf("upload failed: %w", err)
 	}
@@ -195,6 +258,142 @@ func reportWebhookCreate(r *reportWebhook) error {
 	return nil
 }
 
+// reportWebhookAuditCreate writes the findings report, the returned error makes the command exit non-zero when there
+// are findings.
+func reportWebhookAuditCreate(r *reportWebhook, allWebhooks []Webhooks) error {
+	findings := reportWebhookAudit(allWebhooks, r.allowedHosts)
+
+	if r.fileType == "json" {
+		jsonReport, err := r.reportJSON.generateWebhookFindings(findings)
+		if err != nil {
+			return fmt.Errorf("generate json failed: %w", err)
+		}
+
+		if err := r.reportJSON.uploader(r.filePath, jsonReport); err != nil {
+			return fmt.Errorf("upload json failed: %w", err)
+		}
+	} else {
+		lines := reportCSVWebhookFindingsGenerate(findings)
+		if err := reportCSVUpload(r.reportCSV, r.filePath, lines); err != nil {
+			return fmt.Errorf("upload failed: %w", err)
+		}
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("%w: %d found", errWebhookAuditFindings, len(findings))
+	}
+
+	log.Print("Webhook audit found no findings")
+
+	return nil
+}
+
+// reportWebhookAudit returns a finding for each problem with each webhook, the hostname is only checked when there is
+// an allow list.
+func reportWebhookAudit(allWebhooks []Webhooks, allowedHosts []string) []WebhookFinding {
+	var findings []WebhookFinding
+
+	for _, webhooks := range allWebhooks {
+		for _, webhook := range webhooks.Webhooks {
+			var problems []string
+
+			if webhook.Config.InsecureSSL == "1" {
+				problems = append(problems, "ssl verification disabled")
+			}
+
+			webhookURL, err := url.Parse(webhook.Config.URL)
+			if err == nil && strings.EqualFold(webhookURL.Scheme, "http") {
+				problems = append(problems, "plain http url")
+			}
+
+			if webhook.Config.Secret == "" {
+				problems = append(problems, "no secret")
+			}
+
+			if !webhook.Active {
+				problems = append(problems, "inactive")
+			}
+
+			if len(allowedHosts) > 0 && (err != nil || !reportWebhookHostAllowed(webhookURL.Hostname(), allowedHosts)) {
+				problems = append(problems, "host not on allow list")
+			}
+
+			for _, problem := range problems {
+				findings = append(findings, WebhookFinding{
+					RepositoryName: webhooks.RepositoryName,
+					Scope:          webhooks.Scope,
+					WebhookID:      webhook.ID,
+					URL:            webhook.Config.URL,
+					Finding:        problem,
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// reportWebhookHostAllowed matches the hostname exactly, an entry of *.example.com also allows its subdomains.
+func reportWebhookHostAllowed(host string, allowedHosts []string) bool {
+	for _, allowed := range allowedHosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+
+		if strings.HasPrefix(allowed, "*.") && strings.HasSuffix(strings.ToLower(host), strings.ToLower(allowed[1:])) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reportWebhookAllowList reads the hostnames in the allow list file, blank lines and # comments are ignored.
+func reportWebhookAllowList(allowListFile string) ([]string, error) {
+	var hosts []string
+
+	file, err := os.Open(allowListFile)
+	if err != nil {
+		return hosts, fmt.Errorf("could not open allow list file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		host := strings.TrimSpace(scanner.Text())
+		if host == "" || strings.HasPrefix(host, "#") {
+			continue
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return hosts, fmt.Errorf("could not read allow list file: %w", err)
+	}
+
+	return hosts, nil
+}
+
+// UnmarshalJSON accepts insecure_ssl as either a string or a number.
+func (s *WebhookInsecureSSL) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	switch v := value.(type) {
+	case string:
+		*s = WebhookInsecureSSL(v)
+	case float64:
+		*s = WebhookInsecureSSL(fmt.Sprintf("%d", int(v)))
+	default:
+		*s = ""
+	}
+
+	return nil
+}
+
 func reportWebhookQuery() string {
 	var query strings.Builder
 
@@ -333,22 +532,24 @@ func (r *reportWebhookGetterService) getWebhooks(
 		}
 
 		for _, repositoryName := range repositoryCursorList.repositories {
-			client := restclient.NewClient(
-				fmt.Sprintf("/repos/%s/%s/hooks", config.Org, repositoryName),
-				config.Token,
-				http.MethodGet,
-			)
-
-			response := []WebhookResponse{}
-			if err := client.Run(ctx, &response); err != nil {
+			response, err := listWebhooks(ctx, repositoryName)
+			if err != nil {
 				// Ignore any other errors and continue to top of loop
 				reportWebhookResponse.Errors = append(reportWebhookResponse.Errors, err.Error())
 
 				continue
 			}
 
-			allResults = append(allResults, Webhooks{RepositoryName: repositoryName, Webhooks: response})
 			reportWebhookResponse.RestCalls++
+
+			if report.deliveries {
+				setWebhookDeliveryHealth(ctx, repositoryName, response)
+			}
+
+			allResults = append(
+				allResults,
+				Webhooks{RepositoryName: repositoryName, Scope: webhookScopeRepo, Webhooks: response},
+			)
 		}
 
 		reportWebhookResponse.LastCursor = repositoryCursorList.cursor
@@ -362,6 +563,82 @@ func (r *reportWebhookGetterService) getWebhooks(
 	return allResults, nil
 }
 
+// getOrgWebhooks returns the organisation webhooks, the organisation name is used as the repository name.
+func (r *reportWebhookGetterService) getOrgWebhooks(report *reportWebhook) (Webhooks, error) {
+	ctx := context.Background()
+	orgWebhooks := Webhooks{RepositoryName: config.Org, Scope: webhookScopeOrg}
+
+	webhooks, err := listWebhooks(ctx, "")
+	if err != nil {
+		return orgWebhooks, fmt.Errorf("list organisation webhooks: %w", err)
+	}
+
+	orgWebhooks.Webhooks = webhooks
+
+	reportWebhookResponse.RestCalls++
+
+	if report.deliveries {
+		setWebhookDeliveryHealth(ctx, "", orgWebhooks.Webhooks)
+	}
+
+	return orgWebhooks, nil
+}
+
+// setWebhookDeliveryHealth adds the delivery health to each webhook, a webhook without it is recorded as an error.
+func setWebhookDeliveryHealth(ctx context.Context, repositoryName string, webhooks []WebhookResponse) {
+	for i := range webhooks {
+		health, err := getWebhookDeliveryHealth(ctx, repositoryName, webhooks[i].ID)
+		if err != nil {
+			reportWebhookResponse.Errors = append(reportWebhookResponse.Errors, err.Error())
+
+			continue
+		}
+
+		webhooks[i].Deliveries = health
+		reportWebhookResponse.RestCalls++
+	}
+}
+
+// getWebhookDeliveryHealth summarises the most recent page of deliveries, GitHub returns the newest delivery first.
+func getWebhookDeliveryHealth(ctx context.Context, repositoryName string, hookID int) (*WebhookDeliveryHealth, error) {
+	client := restclient.NewClient(
+		fmt.Sprintf("%s/%d/deliveries?per_page=100", webhooksPath(repositoryName), hookID),
+		config.Token,
+		http.MethodGet,
+	)
+
+	var response []WebhookDeliveryResponse
+	if err := client.Run(ctx, &response); err != nil {
+		return nil, fmt.Errorf("list deliveries for hook %d in %s: %w", hookID, webhooksTarget(repositoryName), err)
+	}
+
+	health := &WebhookDeliveryHealth{Deliveries: len(response)}
+	if len(response) == 0 {
+		return health, nil
+	}
+
+	health.LastStatusCode = response[0].StatusCode
+
+	var failures int
+
+	for _, delivery := range response {
+		// A status code of 0 means the delivery got no response
+		if delivery.StatusCode < 200 || delivery.StatusCode > 299 {
+			failures++
+
+			continue
+		}
+
+		if health.LastSuccessAt == "" {
+			health.LastSuccessAt = delivery.DeliveredAt
+		}
+	}
+
+	health.FailureRate = float64(failures) / float64(len(response))
+
+	return health, nil
+}
+
 func setTimeout(timeout int) {
 	now := time.Now()
 	reportWebhookResponse.StartTimeSecs = now.Unix()
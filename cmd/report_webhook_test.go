@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"reflect"
 	"testing"
 	"time"
@@ -82,13 +83,16 @@ func Test_reportWebhookRun(t *testing.T) {
 	cmdAllSetFlags.Flags().BoolP("dry-run", "d", false, "dry run flag")
 	cmdAllSetFlags.Flags().BoolP("ignore-archived", "i", false, "ignore-archived flag")
 	cmdAllSetFlags.Flags().StringP(
-		"file-path", "f", "report.csv", "File path for report to be created, must be .csv or .json",
+		"file-path", "f", "/tmp/test_report.csv", "File path for report to be created, must be .csv or .json",
 	)
 	cmdAllSetFlags.Flags().StringP("file-type", "t", "csv", "file type, must be csv or json")
 	cmdAllSetFlags.Flags().StringP("start-cursor", "s", "", "The starting cursor for webhook search to start from")
 	cmdAllSetFlags.Flags().IntP(
 		"timeout", "o", 60, "Timeout for script (in minutes), useful when calling from Lambdas",
 	)
+	cmdAllSetFlags.Flags().Bool("deliveries", false, "deliveries flag")
+	cmdAllSetFlags.Flags().Bool("audit", false, "audit flag")
+	cmdAllSetFlags.Flags().String("allow-list", "", "allow list flag")
 
 	tests := []struct {
 		name                 string
@@ -202,6 +206,62 @@ func Test_reportWebhookValidateFlags(t *testing.T) {
 		"timeout", "o", 70, "Timeout for script (in minutes), useful when calling from Lambdas",
 	)
 
+	cmdInvalidDeliveries := &cobra.Command{Use: "report-webook"}
+	cmdInvalidDeliveries.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdInvalidDeliveries.Flags().BoolP("ignore-archived", "i", false, "ignore-archived flag")
+	cmdInvalidDeliveries.Flags().StringP(
+		"file-path", "f", "report.csv", "File path for report to be created, must be .csv or .json",
+	)
+	cmdInvalidDeliveries.Flags().StringP("file-type", "t", "csv", "file type, must be csv or json")
+	cmdInvalidDeliveries.Flags().StringP("start-cursor", "s", "", "The starting cursor for webhook search to start from")
+	cmdInvalidDeliveries.Flags().IntP(
+		"timeout", "o", 60, "Timeout for script (in minutes), useful when calling from Lambdas",
+	)
+
+	cmdInvalidAudit := &cobra.Command{Use: "report-webook"}
+	cmdInvalidAudit.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdInvalidAudit.Flags().BoolP("ignore-archived", "i", false, "ignore-archived flag")
+	cmdInvalidAudit.Flags().StringP(
+		"file-path", "f", "report.csv", "File path for report to be created, must be .csv or .json",
+	)
+	cmdInvalidAudit.Flags().StringP("file-type", "t", "csv", "file type, must be csv or json")
+	cmdInvalidAudit.Flags().StringP("start-cursor", "s", "", "The starting cursor for webhook search to start from")
+	cmdInvalidAudit.Flags().IntP(
+		"timeout", "o", 60, "Timeout for script (in minutes), useful when calling from Lambdas",
+	)
+	cmdInvalidAudit.Flags().Bool("deliveries", false, "deliveries flag")
+
+	cmdInvalidAllowList := &cobra.Command{Use: "report-webook"}
+	cmdInvalidAllowList.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdInvalidAllowList.Flags().BoolP("ignore-archived", "i", false, "ignore-archived flag")
+	cmdInvalidAllowList.Flags().StringP(
+		"file-path", "f", "report.csv", "File path for report to be created, must be .csv or .json",
+	)
+	cmdInvalidAllowList.Flags().StringP("file-type", "t", "csv", "file type, must be csv or json")
+	cmdInvalidAllowList.Flags().StringP("start-cursor", "s", "", "The starting cursor for webhook search to start from")
+	cmdInvalidAllowList.Flags().IntP(
+		"timeout", "o", 60, "Timeout for script (in minutes), useful when calling from Lambdas",
+	)
+	cmdInvalidAllowList.Flags().Bool("deliveries", false, "deliveries flag")
+	cmdInvalidAllowList.Flags().Bool("audit", true, "audit flag")
+
+	cmdMissingAllowListFile := &cobra.Command{Use: "report-webook"}
+	cmdMissingAllowListFile.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmdMissingAllowListFile.Flags().BoolP("ignore-archived", "i", false, "ignore-archived flag")
+	cmdMissingAllowListFile.Flags().StringP(
+		"file-path", "f", "report.csv", "File path for report to be created, must be .csv or .json",
+	)
+	cmdMissingAllowListFile.Flags().StringP("file-type", "t", "csv", "file type, must be csv or json")
+	cmdMissingAllowListFile.Flags().StringP(
+		"start-cursor", "s", "", "The starting cursor for webhook search to start from",
+	)
+	cmdMissingAllowListFile.Flags().IntP(
+		"timeout", "o", 60, "Timeout for script (in minutes), useful when calling from Lambdas",
+	)
+	cmdMissingAllowListFile.Flags().Bool("deliveries", false, "deliveries flag")
+	cmdMissingAllowListFile.Flags().Bool("audit", true, "audit flag")
+	cmdMissingAllowListFile.Flags().String("allow-list", "testdata/missing_allow_list.txt", "allow list flag")
+
 	cmdValid := &cobra.Command{Use: "report-webook"}
 	cmdValid.Flags().BoolP("dry-run", "d", false, "dry run flag")
 	cmdValid.Flags().BoolP("ignore-archived", "i", false, "ignore-archived flag")
@@ -213,6 +273,9 @@ func Test_reportWebhookValidateFlags(t *testing.T) {
 	cmdValid.Flags().IntP(
 		"timeout", "o", 60, "Timeout for script (in minutes), useful when calling from Lambdas",
 	)
+	cmdValid.Flags().Bool("deliveries", false, "deliveries flag")
+	cmdValid.Flags().Bool("audit", true, "audit flag")
+	cmdValid.Flags().String("allow-list", "testdata/webhook_allow_list.txt", "allow list flag")
 
 	tests := []struct {
 		name    string
@@ -275,6 +338,38 @@ func Test_reportWebhookValidateFlags(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "reportWebhookValidateFlags deliveries failure",
+			args: args{
+				cmd: cmdInvalidDeliveries,
+				r:   &reportWebhook{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportWebhookValidateFlags audit failure",
+			args: args{
+				cmd: cmdInvalidAudit,
+				r:   &reportWebhook{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportWebhookValidateFlags allow-list failure",
+			args: args{
+				cmd: cmdInvalidAllowList,
+				r:   &reportWebhook{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportWebhookValidateFlags missing allow list file failure",
+			args: args{
+				cmd: cmdMissingAllowListFile,
+				r:   &reportWebhook{},
+			},
+			wantErr: true,
+		},
 		{
 			name: "reportWebhookValidateFlags invalid timeout failure",
 			args: args{
@@ -397,6 +492,76 @@ func Test_reportWebhookCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "reportWebhookCreate org webhooks fail still reports repository webhooks",
+			args: args{
+				r: &reportWebhook{
+					reportWebhookGetter: &mockReportWebhookGetterService{
+						failOrgWebhook: true,
+					},
+					reportCSV: &mockReportCSV{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportWebhookCreate audit json generate findings fail",
+			args: args{
+				r: &reportWebhook{
+					reportWebhookGetter: &mockReportWebhookGetterService{},
+					reportJSON: &mockReportJSON{
+						failgenerate: true,
+					},
+					fileType: "json",
+					audit:    true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportWebhookCreate audit json no findings",
+			args: args{
+				r: &reportWebhook{
+					reportWebhookGetter: &mockReportWebhookGetterService{},
+					reportJSON:          &mockReportJSON{},
+					fileType:            "json",
+					audit:               true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "reportWebhookCreate audit csv upload fail",
+			args: args{
+				r: &reportWebhook{
+					reportWebhookGetter: &mockReportWebhookGetterService{},
+					reportCSV: &mockReportCSV{
+						failOpen: true,
+					},
+					audit: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reportWebhookCreate audit csv with findings",
+			args: args{
+				r: &reportWebhook{
+					reportWebhookGetter: &mockReportWebhookGetterService{
+						returnWebhookList: []Webhooks{{
+							RepositoryName: "repo1",
+							Webhooks: []WebhookResponse{{
+								Config: WebhookResponseConfig{URL: "http://hooks.example.com/push"},
+								ID:     1,
+							}},
+						}},
+					},
+					reportCSV: &mockReportCSV{},
+					audit:     true,
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -408,6 +573,152 @@ func Test_reportWebhookCreate(t *testing.T) {
 	}
 }
 
+func Test_reportWebhookAudit(t *testing.T) {
+	secureConfig := WebhookResponseConfig{
+		URL:         "https://hooks.example.com/push",
+		InsecureSSL: "0",
+		Secret:      "********",
+	}
+
+	tests := []struct {
+		name         string
+		webhook      WebhookResponse
+		allowedHosts []string
+		want         []string
+	}{
+		{
+			name:    "reportWebhookAudit secure webhook",
+			webhook: WebhookResponse{ID: 1, Active: true, Config: secureConfig},
+		},
+		{
+			name:         "reportWebhookAudit allowed subdomain",
+			webhook:      WebhookResponse{ID: 1, Active: true, Config: secureConfig},
+			allowedHosts: []string{"*.example.com"},
+		},
+		{
+			name:         "reportWebhookAudit wildcard does not allow the domain itself",
+			webhook:      WebhookResponse{ID: 1, Active: true, Config: secureConfig},
+			allowedHosts: []string{"*.hooks.example.com"},
+			want:         []string{"host not on allow list"},
+		},
+		{
+			name: "reportWebhookAudit all findings",
+			webhook: WebhookResponse{
+				ID: 1,
+				Config: WebhookResponseConfig{
+					URL:         "HTTP://other.example.org/push",
+					InsecureSSL: "1",
+				},
+			},
+			allowedHosts: []string{"hooks.example.com"},
+			want: []string{
+				"ssl verification disabled",
+				"plain http url",
+				"no secret",
+				"inactive",
+				"host not on allow list",
+			},
+		},
+		{
+			name: "reportWebhookAudit invalid url is not on allow list",
+			webhook: WebhookResponse{
+				ID:     1,
+				Active: true,
+				Config: WebhookResponseConfig{URL: "://hooks.example.com", Secret: "********"},
+			},
+			allowedHosts: []string{"hooks.example.com"},
+			want:         []string{"host not on allow list"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+
+			findings := reportWebhookAudit(
+				[]Webhooks{{RepositoryName: "repo1", Scope: webhookScopeRepo, Webhooks: []WebhookResponse{tt.webhook}}},
+				tt.allowedHosts,
+			)
+			for _, finding := range findings {
+				if finding.RepositoryName != "repo1" || finding.Scope != webhookScopeRepo || finding.WebhookID != 1 ||
+					finding.URL != tt.webhook.Config.URL {
+					t.Errorf("reportWebhookAudit() finding = %+v, want repo1 webhook 1", finding)
+				}
+
+				got = append(got, finding.Finding)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportWebhookAudit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reportWebhookAllowList(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "reportWebhookAllowList missing file",
+			file:    "testdata/missing_allow_list.txt",
+			wantErr: true,
+		},
+		{
+			name: "reportWebhookAllowList skips comments and blank lines",
+			file: "testdata/webhook_allow_list.txt",
+			want: []string{"hooks.example.com", "*.ci.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := reportWebhookAllowList(tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reportWebhookAllowList() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reportWebhookAllowList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookInsecureSSL_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    WebhookInsecureSSL
+		wantErr bool
+	}{
+		{name: "WebhookInsecureSSL string", data: `{"insecure_ssl":"1"}`, want: "1"},
+		{name: "WebhookInsecureSSL number", data: `{"insecure_ssl":1}`, want: "1"},
+		{name: "WebhookInsecureSSL null", data: `{"insecure_ssl":null}`, want: ""},
+		{name: "WebhookInsecureSSL invalid", data: `{"insecure_ssl":1`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got WebhookResponseConfig
+			if err := json.Unmarshal([]byte(tt.data), &got); (err != nil) != tt.wantErr {
+				t.Errorf("WebhookInsecureSSL.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got.InsecureSSL != tt.want {
+				t.Errorf("WebhookInsecureSSL.UnmarshalJSON() = %v, want %v", got.InsecureSSL, tt.want)
+			}
+		})
+	}
+}
+
 func Test_reportWebhookGetterService_getRepositoryList(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
@@ -504,6 +815,15 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 
 	config.Org = MockOrgName
 
+	mockFile := func(filePath string) string {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("failed to read test data: %v", err)
+		}
+
+		return string(content)
+	}
+
 	type args struct {
 		report       *reportWebhook
 		repositories []repositoryCursorList
@@ -518,6 +838,8 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 		rateLimitResponseFile string
 		setEndTimeSecs        int64
 		setupWebhookCalls     bool
+		nextFile              string
+		deliveryResponses     []dependabotMockResponse
 		want                  []Webhooks
 		wantErr               bool
 	}{
@@ -545,6 +867,7 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 			rateLimitResponseFile: mockRateLimitResponseFile,
 			setEndTimeSecs:        time.Now().Add(10 * time.Minute).Unix(),
 			args: args{
+				report: &reportWebhook{},
 				repositories: []repositoryCursorList{
 					{
 						cursor:       "some-cursor",
@@ -560,11 +883,14 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 			want: []Webhooks{
 				{
 					RepositoryName: "repo1",
+					Scope:          "repo",
 					Webhooks: []WebhookResponse{
 						{
 							Config: WebhookResponseConfig{
 								URL:         "https://trigger.some_url.com/json",
 								InsecureURL: 0,
+								InsecureSSL: "0",
+								ContentType: "json",
 							},
 							Active: true,
 							ID:     12345670,
@@ -574,6 +900,8 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 							Config: WebhookResponseConfig{
 								URL:         "https://www.some_url.com/sync",
 								InsecureURL: 0,
+								InsecureSSL: "0",
+								ContentType: "form",
 							},
 							Active: true,
 							ID:     12345671,
@@ -583,6 +911,8 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 							Config: WebhookResponseConfig{
 								URL:         "https://www.some_url.com/sync",
 								InsecureURL: 0,
+								InsecureSSL: "0",
+								ContentType: "json",
 							},
 							Active: true,
 							ID:     12345672,
@@ -592,11 +922,14 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 				},
 				{
 					RepositoryName: "repo2",
+					Scope:          "repo",
 					Webhooks: []WebhookResponse{
 						{
 							Config: WebhookResponseConfig{
 								URL:         "https://trigger.some_url.com/json",
 								InsecureURL: 0,
+								InsecureSSL: "0",
+								ContentType: "json",
 							},
 							Active: true,
 							ID:     12345670,
@@ -606,6 +939,8 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 							Config: WebhookResponseConfig{
 								URL:         "https://www.some_url.com/sync",
 								InsecureURL: 0,
+								InsecureSSL: "0",
+								ContentType: "form",
 							},
 							Active: true,
 							ID:     12345671,
@@ -615,11 +950,149 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 							Config: WebhookResponseConfig{
 								URL:         "https://www.some_url.com/sync",
 								InsecureURL: 0,
+								InsecureSSL: "0",
+								ContentType: "json",
+							},
+							Active: true,
+							ID:     12345672,
+							Events: []string{"issue_comment", "pull_request", "pull_request_review_comment", "push"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:                  "getWebhooks follows the next page",
+			rateLimitResponseFile: mockRateLimitResponseFile,
+			setEndTimeSecs:        time.Now().Add(10 * time.Minute).Unix(),
+			args: args{
+				report:       &reportWebhook{},
+				repositories: []repositoryCursorList{{cursor: "some-cursor", repositories: []string{"repo1"}}},
+			},
+			setupWebhookCalls: true,
+			nextFile:          "testdata/mockGetWebhooksNextPageResponse.json",
+			want: []Webhooks{
+				{
+					RepositoryName: "repo1",
+					Scope:          "repo",
+					Webhooks: []WebhookResponse{
+						{
+							Config: WebhookResponseConfig{
+								URL:         "https://trigger.some_url.com/json",
+								InsecureSSL: "0",
+								ContentType: "json",
+							},
+							Active: true,
+							ID:     12345670,
+							Events: []string{"push"},
+						},
+						{
+							Config: WebhookResponseConfig{
+								URL:         "https://www.some_url.com/sync",
+								InsecureSSL: "0",
+								ContentType: "form",
+							},
+							Active: true,
+							ID:     12345671,
+							Events: []string{"push"},
+						},
+						{
+							Config: WebhookResponseConfig{
+								URL:         "https://www.some_url.com/sync",
+								InsecureSSL: "0",
+								ContentType: "json",
 							},
 							Active: true,
 							ID:     12345672,
 							Events: []string{"issue_comment", "pull_request", "pull_request_review_comment", "push"},
 						},
+						{
+							Config: WebhookResponseConfig{
+								URL:         "https://some-external-webhook.org",
+								InsecureSSL: "0",
+								ContentType: "json",
+							},
+							Active: true,
+							ID:     999,
+							Events: []string{"push"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:                  "getWebhooks with deliveries",
+			rateLimitResponseFile: mockRateLimitResponseFile,
+			setEndTimeSecs:        time.Now().Add(10 * time.Minute).Unix(),
+			args: args{
+				report:       &reportWebhook{deliveries: true},
+				repositories: []repositoryCursorList{{cursor: "some-cursor", repositories: []string{"repo1"}}},
+			},
+			setupWebhookCalls: true,
+			deliveryResponses: []dependabotMockResponse{
+				{
+					method:     "GET",
+					url:        "https://api.github.com/repos/some-org/repo1/hooks/12345670/deliveries?per_page=100",
+					file:       "testdata/mockWebhookDeliveriesResponse.json",
+					statusCode: 200,
+				},
+				{
+					method:     "GET",
+					url:        "https://api.github.com/repos/some-org/repo1/hooks/12345671/deliveries?per_page=100",
+					file:       "testdata/mockRest404Response.json",
+					statusCode: 404,
+				},
+				{
+					method:     "GET",
+					url:        "https://api.github.com/repos/some-org/repo1/hooks/12345672/deliveries?per_page=100",
+					file:       "testdata/mockEmptyListResponse.json",
+					statusCode: 200,
+				},
+			},
+			want: []Webhooks{
+				{
+					RepositoryName: "repo1",
+					Scope:          "repo",
+					Webhooks: []WebhookResponse{
+						{
+							Config: WebhookResponseConfig{
+								URL:         "https://trigger.some_url.com/json",
+								InsecureSSL: "0",
+								ContentType: "json",
+							},
+							Active: true,
+							ID:     12345670,
+							Events: []string{"push"},
+							Deliveries: &WebhookDeliveryHealth{
+								Deliveries:     4,
+								LastStatusCode: 502,
+								LastSuccessAt:  "2026-10-01T09:00:00Z",
+								FailureRate:    0.5,
+							},
+						},
+						{
+							Config: WebhookResponseConfig{
+								URL:         "https://www.some_url.com/sync",
+								InsecureSSL: "0",
+								ContentType: "form",
+							},
+							Active: true,
+							ID:     12345671,
+							Events: []string{"push"},
+						},
+						{
+							Config: WebhookResponseConfig{
+								URL:         "https://www.some_url.com/sync",
+								InsecureSSL: "0",
+								ContentType: "json",
+							},
+							Active:     true,
+							ID:         12345672,
+							Events:     []string{"issue_comment", "pull_request", "pull_request_review_comment", "push"},
+							Deliveries: &WebhookDeliveryHealth{},
+						},
 					},
 				},
 			},
@@ -629,6 +1102,8 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
 			r := &reportWebhookGetterService{}
 			reportWebhookResponse.EndTimeSecs = tt.setEndTimeSecs
 
@@ -638,16 +1113,27 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 			if tt.setupWebhookCalls {
 				for _, cursorList := range tt.args.repositories {
 					for _, repoName := range cursorList.repositories {
-						mockHTTPResponder(
-							"GET",
-							fmt.Sprintf("https://api.github.com/repos/some-org/%s/hooks", repoName),
-							"testdata/mockRestWebhookResponse.json",
-							200,
-						)
+						hooksURL := fmt.Sprintf("https://api.github.com/repos/some-org/%s/hooks", repoName)
+
+						if tt.nextFile == "" {
+							mockHTTPResponder("GET", hooksURL, "testdata/mockRestWebhookResponse.json", 200)
+
+							continue
+						}
+
+						firstPage := httpmock.NewStringResponse(200, mockFile("testdata/mockRestWebhookResponse.json"))
+						firstPage.Header.Set("Link", "<"+hooksURL+`?per_page=100&page=2>; rel="next"`)
+
+						httpmock.RegisterResponder("GET", hooksURL+"?per_page=100", httpmock.ResponderFromResponse(firstPage))
+						mockHTTPResponder("GET", hooksURL+"?per_page=100&page=2", tt.nextFile, 200)
 					}
 				}
 			}
 
+			for _, response := range tt.deliveryResponses {
+				mockHTTPResponder(response.method, response.url, response.file, response.statusCode)
+			}
+
 			got, err := r.getWebhooks(tt.args.report, tt.args.repositories)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("reportWebhookGetterService.getWebhooks() error = %v, wantErr %v", err, tt.wantErr)
@@ -663,6 +1149,140 @@ func Test_reportWebhookGetterService_getWebhooks(t *testing.T) {
 	}
 }
 
+func Test_reportWebhookGetterService_getOrgWebhooks(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	hooksURL := "https://api.github.com/orgs/some-org/hooks"
+
+	mockFile := func(filePath string) string {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("failed to read test data: %v", err)
+		}
+
+		return string(content)
+	}
+
+	tests := []struct {
+		name              string
+		report            *reportWebhook
+		hooksFile         string
+		hooksStatus       int
+		nextFile          string
+		deliveryResponses []dependabotMockResponse
+		want              Webhooks
+		wantIDs           []int
+		wantErr           bool
+	}{
+		{
+			name:        "getOrgWebhooks fails without admin:org_hook scope",
+			report:      &reportWebhook{},
+			hooksFile:   "testdata/mockRest403Response.json",
+			hooksStatus: 403,
+			want:        Webhooks{RepositoryName: "some-org", Scope: "org"},
+			wantErr:     true,
+		},
+		{
+			name:        "getOrgWebhooks with deliveries",
+			report:      &reportWebhook{deliveries: true},
+			hooksFile:   "testdata/mockGetWebhooksResponse.json",
+			hooksStatus: 200,
+			deliveryResponses: []dependabotMockResponse{
+				{
+					method:     "GET",
+					url:        hooksURL + "/123/deliveries?per_page=100",
+					file:       "testdata/mockWebhookDeliveriesResponse.json",
+					statusCode: 200,
+				},
+				{
+					method:     "GET",
+					url:        hooksURL + "/456/deliveries?per_page=100",
+					file:       "testdata/mockEmptyListResponse.json",
+					statusCode: 200,
+				},
+				{
+					method:     "GET",
+					url:        hooksURL + "/789/deliveries?per_page=100",
+					file:       "testdata/mockEmptyListResponse.json",
+					statusCode: 200,
+				},
+			},
+			want:    Webhooks{RepositoryName: "some-org", Scope: "org"},
+			wantIDs: []int{123, 456, 789},
+		},
+		{
+			name:        "getOrgWebhooks follows the next page",
+			report:      &reportWebhook{},
+			hooksFile:   "testdata/mockGetWebhooksResponse.json",
+			hooksStatus: 200,
+			nextFile:    "testdata/mockGetWebhooksNextPageResponse.json",
+			want:        Webhooks{RepositoryName: "some-org", Scope: "org"},
+			wantIDs:     []int{123, 456, 789, 999},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			if tt.nextFile != "" {
+				firstPage := httpmock.NewStringResponse(tt.hooksStatus, mockFile(tt.hooksFile))
+				firstPage.Header.Set("Link", "<"+hooksURL+`?per_page=100&page=2>; rel="next"`)
+
+				httpmock.RegisterResponder("GET", hooksURL+"?per_page=100", httpmock.ResponderFromResponse(firstPage))
+				mockHTTPResponder("GET", hooksURL+"?per_page=100&page=2", tt.nextFile, 200)
+			} else {
+				mockHTTPResponder("GET", hooksURL, tt.hooksFile, tt.hooksStatus)
+			}
+
+			for _, response := range tt.deliveryResponses {
+				mockHTTPResponder(response.method, response.url, response.file, response.statusCode)
+			}
+
+			r := &reportWebhookGetterService{}
+			got, err := r.getOrgWebhooks(tt.report)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reportWebhookGetterService.getOrgWebhooks() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got.RepositoryName != tt.want.RepositoryName || got.Scope != tt.want.Scope {
+				t.Errorf("reportWebhookGetterService.getOrgWebhooks() = %s %s, want %s %s",
+					got.RepositoryName, got.Scope, tt.want.RepositoryName, tt.want.Scope)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var gotIDs []int
+			for _, webhook := range got.Webhooks {
+				gotIDs = append(gotIDs, webhook.ID)
+			}
+
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("reportWebhookGetterService.getOrgWebhooks() webhook IDs = %v, want %v", gotIDs, tt.wantIDs)
+			}
+
+			if tt.report.deliveries &&
+				(got.Webhooks[0].Deliveries == nil || got.Webhooks[0].Deliveries.LastStatusCode != 502) {
+				t.Errorf("reportWebhookGetterService.getOrgWebhooks() webhooks = %+v", got.Webhooks)
+			}
+		})
+	}
+}
+
 func Test_setRateLimit(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
@@ -66,9 +66,68 @@ func (r *repositoryGetterService) get(
 		return repositories, fmt.Errorf("failure in repository get : %w", err)
 	}
 
+	for _, repository := range repositories {
+		if repository == nil {
+			continue
+		}
+
+		if err := branchProtectionRulesGetRemaining(repository, sender); err != nil {
+			return repositories, fmt.Errorf("failure in repository get : %w", err)
+		}
+	}
+
 	return repositories, nil
 }
 
+// branchProtectionRulesGetRemaining adds the branch protection rules past the first page to the repository,
+// without them a repo with more than 25 rules would be missing rules and could have duplicates created.
+// The bulk queries only get the first 10 allowances of each rule to stay under the GraphQL node limit, when a rule
+// has more the rules are fetched again from the start with up to 100 allowances each.
+func branchProtectionRulesGetRemaining(repository *RepositoriesNode, sender *githubRepositorySender) error {
+	if branchProtectionAllowancesTruncated(repository.BranchProtectionRules.Nodes) {
+		repository.BranchProtectionRules = BranchProtectionRules{PageInfo: PageInfo{HasNextPage: true}}
+	}
+
+	for repository.BranchProtectionRules.PageInfo.HasNextPage {
+		req := branchProtectionRulesRequest(
+			branchProtectionRulesQuery(),
+			repository.ID,
+			repository.BranchProtectionRules.PageInfo.EndCursor,
+		)
+
+		response, err := sender.sender.send(req)
+		if err != nil {
+			return fmt.Errorf("branch protection rules for %s: %w", repository.NameWithOwner, err)
+		}
+
+		page, ok := response["repository"]
+		if !ok || page == nil {
+			return fmt.Errorf("%w: %s", errBranchProtectionRulesPage, repository.NameWithOwner)
+		}
+
+		repository.BranchProtectionRules.Nodes = append(
+			repository.BranchProtectionRules.Nodes,
+			page.BranchProtectionRules.Nodes...,
+		)
+		repository.BranchProtectionRules.PageInfo = page.BranchProtectionRules.PageInfo
+	}
+
+	return nil
+}
+
+func branchProtectionAllowancesTruncated(rules []BranchProtectionRulesNode) bool {
+	for _, rule := range rules {
+		if rule.PushAllowances.TotalCount > len(rule.PushAllowances.Nodes) ||
+			rule.BypassForcePushAllowances.TotalCount > len(rule.BypassForcePushAllowances.Nodes) ||
+			rule.BypassPullRequestAllowances.TotalCount > len(rule.BypassPullRequestAllowances.Nodes) ||
+			rule.ReviewDismissalAllowances.TotalCount > len(rule.ReviewDismissalAllowances.Nodes) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type githubRepositorySender struct {
 	sender repositorySender
 }
@@ -103,15 +162,87 @@ func repositoryQuery(repos []string) string {
 	query.WriteString("	defaultBranchRef {")
 	query.WriteString("		name")
 	query.WriteString("	}")
-	query.WriteString("	branchProtectionRules(first: 100) {")
+	query.WriteString("	branchProtectionRules(first: 25) {")
+	query.WriteString("		pageInfo {")
+	query.WriteString("			endCursor")
+	query.WriteString("			hasNextPage")
+	query.WriteString("		}")
 	query.WriteString("		nodes {")
 	query.WriteString("			id")
 	query.WriteString("			requiresCommitSignatures")
 	query.WriteString("			pattern")
+	query.WriteString("			matchingRefs(first: 1) {")
+	query.WriteString("				totalCount")
+	query.WriteString("			}")
 	query.WriteString("			requiresApprovingReviews")
 	query.WriteString("			requiresCodeOwnerReviews")
 	query.WriteString("			requiredApprovingReviewCount")
 	query.WriteString("			dismissesStaleReviews")
+	query.WriteString("			restrictsPushes")
+	query.WriteString("			isAdminEnforced")
+	query.WriteString("			requiresStatusChecks")
+	query.WriteString("			requiresStrictStatusChecks")
+	query.WriteString("			requireLastPushApproval")
+	query.WriteString("			allowsForcePushes")
+	query.WriteString("			allowsDeletions")
+	query.WriteString("			blocksCreations")
+	query.WriteString("			lockAllowsFetchAndMerge")
+	query.WriteString("			lockBranch")
+	query.WriteString("			requiresConversationResolution")
+	query.WriteString("			requiresDeployments")
+	query.WriteString("			requiresLinearHistory")
+	query.WriteString("			restrictsReviewDismissals")
+	query.WriteString("			requiredDeploymentEnvironments")
+	query.WriteString("			requiredStatusChecks {")
+	query.WriteString("				context")
+	query.WriteString("				app {")
+	query.WriteString("					id")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("			pushAllowances(first: 10) {")
+	query.WriteString("				totalCount")
+	query.WriteString("				nodes {")
+	query.WriteString("					actor {")
+	query.WriteString("						__typename")
+	query.WriteString("						... on App { id }")
+	query.WriteString("						... on Team { id }")
+	query.WriteString("						... on User { id }")
+	query.WriteString("					}")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("			bypassForcePushAllowances(first: 10) {")
+	query.WriteString("				totalCount")
+	query.WriteString("				nodes {")
+	query.WriteString("					actor {")
+	query.WriteString("						__typename")
+	query.WriteString("						... on App { id }")
+	query.WriteString("						... on Team { id }")
+	query.WriteString("						... on User { id }")
+	query.WriteString("					}")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("			bypassPullRequestAllowances(first: 10) {")
+	query.WriteString("				totalCount")
+	query.WriteString("				nodes {")
+	query.WriteString("					actor {")
+	query.WriteString("						__typename")
+	query.WriteString("						... on App { id }")
+	query.WriteString("						... on Team { id }")
+	query.WriteString("						... on User { id }")
+	query.WriteString("					}")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("			reviewDismissalAllowances(first: 10) {")
+	query.WriteString("				totalCount")
+	query.WriteString("				nodes {")
+	query.WriteString("					actor {")
+	query.WriteString("						__typename")
+	query.WriteString("						... on App { id }")
+	query.WriteString("						... on Team { id }")
+	query.WriteString("						... on User { id }")
+	query.WriteString("					}")
+	query.WriteString("				}")
+	query.WriteString("			}")
 	query.WriteString("		}")
 	query.WriteString("	}")
 	query.WriteString("}")
@@ -128,6 +259,123 @@ func repositoryQuery(repos []string) string {
 	return query.String()
 }
 
+// branchProtectionRulesQuery gets a page of branch protection rules for a single repository with every field
+// used by the report and the branch protection commands.
+func branchProtectionRulesQuery() string {
+	var query strings.Builder
+
+	query.WriteString("query ($id: ID! $after: String) {")
+	query.WriteString("	repository: node(id: $id) {")
+	query.WriteString("		... on Repository {")
+	query.WriteString("			id")
+	query.WriteString("			nameWithOwner")
+	query.WriteString("			branchProtectionRules(first: 100, after: $after) {")
+	query.WriteString("				pageInfo {")
+	query.WriteString("					endCursor")
+	query.WriteString("					hasNextPage")
+	query.WriteString("				}")
+	query.WriteString("				nodes {")
+	query.WriteString("					id")
+	query.WriteString("					isAdminEnforced")
+	query.WriteString("					requiresCommitSignatures")
+	query.WriteString("					restrictsPushes")
+	query.WriteString("					requiresApprovingReviews")
+	query.WriteString("					requiresStatusChecks")
+	query.WriteString("					requiresCodeOwnerReviews")
+	query.WriteString("					dismissesStaleReviews")
+	query.WriteString("					requiresStrictStatusChecks")
+	query.WriteString("					requiredApprovingReviewCount")
+	query.WriteString("					requireLastPushApproval")
+	query.WriteString("					allowsForcePushes")
+	query.WriteString("					allowsDeletions")
+	query.WriteString("					blocksCreations")
+	query.WriteString("					lockAllowsFetchAndMerge")
+	query.WriteString("					lockBranch")
+	query.WriteString("					requiresConversationResolution")
+	query.WriteString("					requiresDeployments")
+	query.WriteString("					requiresLinearHistory")
+	query.WriteString("					restrictsReviewDismissals")
+	query.WriteString("					requiredDeploymentEnvironments")
+	query.WriteString("					requiredStatusChecks {")
+	query.WriteString("						context")
+	query.WriteString("						app {")
+	query.WriteString("							id")
+	query.WriteString("						}")
+	query.WriteString("					}")
+	query.WriteString("					pattern")
+	query.WriteString("					matchingRefs(first: 1) {")
+	query.WriteString("						totalCount")
+	query.WriteString("					}")
+	query.WriteString("					pushAllowances(first: 100) {")
+	query.WriteString("						totalCount")
+	query.WriteString("						nodes {")
+	query.WriteString("							actor {")
+	query.WriteString("								__typename")
+	query.WriteString("								... on App { id slug }")
+	query.WriteString("								... on Team { id slug }")
+	query.WriteString("								... on User { id login }")
+	query.WriteString("							}")
+	query.WriteString("						}")
+	query.WriteString("					}")
+	query.WriteString("					bypassForcePushAllowances(first: 100) {")
+	query.WriteString("						totalCount")
+	query.WriteString("						nodes {")
+	query.WriteString("							actor {")
+	query.WriteString("								__typename")
+	query.WriteString("								... on App { id slug }")
+	query.WriteString("								... on Team { id slug }")
+	query.WriteString("								... on User { id login }")
+	query.WriteString("							}")
+	query.WriteString("						}")
+	query.WriteString("					}")
+	query.WriteString("					bypassPullRequestAllowances(first: 100) {")
+	query.WriteString("						totalCount")
+	query.WriteString("						nodes {")
+	query.WriteString("							actor {")
+	query.WriteString("								__typename")
+	query.WriteString("								... on App { id slug }")
+	query.WriteString("								... on Team { id slug }")
+	query.WriteString("								... on User { id login }")
+	query.WriteString("							}")
+	query.WriteString("						}")
+	query.WriteString("					}")
+	query.WriteString("					reviewDismissalAllowances(first: 100) {")
+	query.WriteString("						totalCount")
+	query.WriteString("						nodes {")
+	query.WriteString("							actor {")
+	query.WriteString("								__typename")
+	query.WriteString("								... on App { id slug }")
+	query.WriteString("								... on Team { id slug }")
+	query.WriteString("								... on User { id login }")
+	query.WriteString("							}")
+	query.WriteString("						}")
+	query.WriteString("					}")
+	query.WriteString("				}")
+	query.WriteString("			}")
+	query.WriteString("		}")
+	query.WriteString("	}")
+	query.WriteString("}")
+
+	return query.String()
+}
+
+func branchProtectionRulesRequest(queryString, repositoryID, cursor string) *graphqlclient.Request {
+	authStr := fmt.Sprintf("bearer %s", config.Token)
+
+	req := graphqlclient.NewRequest(queryString)
+	req.Var("id", repositoryID)
+
+	// An empty cursor gets the first page
+	if cursor != "" {
+		req.Var("after", cursor)
+	}
+
+	req.Header.Set("Cache-Control", "no-cache")
+	req.Header.Set("Authorization", authStr)
+
+	return req
+}
+
 func repositoryRequest(queryString string) *graphqlclient.Request {
 	authStr := fmt.Sprintf("bearer %s", config.Token)
 
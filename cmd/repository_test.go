@@ -268,3 +268,123 @@ func Test_repositorySenderService_send(t *testing.T) {
 		})
 	}
 }
+
+func Test_branchProtectionRulesGetRemaining(t *testing.T) {
+	newRepository := func(hasNextPage bool) *RepositoriesNode {
+		return &RepositoriesNode{
+			ID:            "repoIdTEST",
+			NameWithOwner: "org/some-repo-name",
+			BranchProtectionRules: BranchProtectionRules{
+				PageInfo: PageInfo{EndCursor: "cursor1", HasNextPage: hasNextPage},
+				Nodes:    []BranchProtectionRulesNode{{Pattern: "main"}},
+			},
+		}
+	}
+
+	type args struct {
+		repository *RepositoriesNode
+		sender     *githubRepositorySender
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		wantPatterns []string
+		wantErr      bool
+	}{
+		{
+			name: "branchProtectionRulesGetRemaining no next page",
+			args: args{
+				repository: newRepository(false),
+				sender: &githubRepositorySender{
+					sender: &mockRepositorySender{sendFail: true},
+				},
+			},
+			wantPatterns: []string{"main"},
+		},
+		{
+			name: "branchProtectionRulesGetRemaining fails on send",
+			args: args{
+				repository: newRepository(true),
+				sender: &githubRepositorySender{
+					sender: &mockRepositorySender{sendFail: true},
+				},
+			},
+			wantPatterns: []string{"main"},
+			wantErr:      true,
+		},
+		{
+			name: "branchProtectionRulesGetRemaining fails on missing repository",
+			args: args{
+				repository: newRepository(true),
+				sender: &githubRepositorySender{
+					sender: &mockRepositorySender{},
+				},
+			},
+			wantPatterns: []string{"main"},
+			wantErr:      true,
+		},
+		{
+			name: "branchProtectionRulesGetRemaining adds next page",
+			args: args{
+				repository: newRepository(true),
+				sender: &githubRepositorySender{
+					sender: &mockRepositorySender{
+						returnValue: map[string]*RepositoriesNode{"repository": {
+							BranchProtectionRules: BranchProtectionRules{
+								Nodes: []BranchProtectionRulesNode{{Pattern: "release/*"}},
+							},
+						}},
+					},
+				},
+			},
+			wantPatterns: []string{"main", "release/*"},
+		},
+		{
+			name: "branchProtectionRulesGetRemaining fetches rules again when allowances are truncated",
+			args: args{
+				repository: &RepositoriesNode{
+					ID:            "repoIdTEST",
+					NameWithOwner: "org/some-repo-name",
+					BranchProtectionRules: BranchProtectionRules{
+						Nodes: []BranchProtectionRulesNode{{
+							Pattern: "main",
+							PushAllowances: BranchProtectionAllowances{
+								TotalCount: 11,
+								Nodes:      make([]BranchProtectionAllowancesNode, 10),
+							},
+						}},
+					},
+				},
+				sender: &githubRepositorySender{
+					sender: &mockRepositorySender{
+						returnValue: map[string]*RepositoriesNode{"repository": {
+							BranchProtectionRules: BranchProtectionRules{
+								Nodes: []BranchProtectionRulesNode{{Pattern: "main"}, {Pattern: "release/*"}},
+							},
+						}},
+					},
+				},
+			},
+			wantPatterns: []string{"main", "release/*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := branchProtectionRulesGetRemaining(tt.args.repository, tt.args.sender)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("branchProtectionRulesGetRemaining() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var gotPatterns []string
+			for _, rule := range tt.args.repository.BranchProtectionRules.Nodes {
+				gotPatterns = append(gotPatterns, rule.Pattern)
+			}
+
+			if !reflect.DeepEqual(gotPatterns, tt.wantPatterns) {
+				t.Errorf("branchProtectionRulesGetRemaining() patterns = %v, want %v", gotPatterns, tt.wantPatterns)
+			}
+		})
+	}
+}
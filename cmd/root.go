@@ -9,18 +9,19 @@ import (
 )
 
 var (
-	configFile        string // nolint // needed for cobra
-	reposFile         string // nolint // needed for cobra
-	branchName        string // nolint // needed for cobra
-	webhookURL        string // nolint // needed for cobra
-	config            Config // nolint // using with viper
-	dryRun            bool   // nolint // using for global flag
-	ignoreArchived    bool   // nolint // modifying within this package
-	filePath          string // nolint // modifying within this package
-	fileType          string // nolint // modifying within this package
-	errInvalidRepo    = errors.New("invalid repo name")
-	errInvalidTimeout = errors.New("invalid timeout")
-	rootCmd           = &cobra.Command{ // nolint // needed for cobra
+	configFile                   string // nolint // needed for cobra
+	reposFile                    string // nolint // needed for cobra
+	branchName                   string // nolint // needed for cobra
+	webhookURL                   string // nolint // needed for cobra
+	config                       Config // nolint // using with viper
+	dryRun                       bool   // nolint // using for global flag
+	ignoreArchived               bool   // nolint // modifying within this package
+	filePath                     string // nolint // modifying within this package
+	fileType                     string // nolint // modifying within this package
+	errInvalidRepo               = errors.New("invalid repo name")
+	errInvalidTimeout            = errors.New("invalid timeout")
+	errBranchProtectionRulesPage = errors.New("no branch protection rules returned for repository")
+	rootCmd                      = &cobra.Command{ // nolint // needed for cobra
 		Use:   "github-admin-tool",
 		Short: "Github admin tool allows you to perform actions on your github repos",
 		Long: `Using Github GraphQL API where possible (some actions only available using REST API) 
@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"os"
+	"reflect"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errRulesetInvalid      = errors.New("ruleset file must contain a name and enforcement")
+	errRulesetTargetFlags  = errors.New("must set either repos or org-level")
+	errRulesetTargetDouble = errors.New("cannot set both repos and org-level")
+	rulesetCmd             = &cobra.Command{ // nolint // needed for cobra
+		Use:   "ruleset",
+		Short: "Manage repository and organisation rulesets",
+	}
+	rulesetApplyCmd = &cobra.Command{ // nolint // needed for cobra
+		Use:   "apply",
+		Short: "Create or update a named ruleset for repos in provided list or at org level",
+		RunE:  rulesetApplyRun,
+	}
+)
+
+func rulesetApplyRun(cmd *cobra.Command, args []string) error {
+	err := rulesetApplyCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+	)
+
+	return err
+}
+
+func rulesetApplyCommand(cmd *cobra.Command, repo *repository) error {
+	dryRun, rulesetFilePath, reposFilePath, orgLevel, err := rulesetApplyFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	ruleset, err := rulesetRead(rulesetFilePath)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	var paths []string
+
+	if orgLevel {
+		paths = append(paths, fmt.Sprintf("/orgs/%s/rulesets", config.Org))
+	} else {
+		repositoryList, err := repo.reader.read(reposFilePath)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		for _, repositoryName := range repositoryList {
+			paths = append(paths, fmt.Sprintf("/repos/%s/%s/rulesets", config.Org, repositoryName))
+		}
+	}
+
+	log.SetFlags(0)
+
+	if dryRun {
+		log.Printf("This is a dry run, the run would process %d ruleset targets", len(paths))
+	}
+
+	ctx := context.Background()
+
+	for _, path := range paths {
+		result, err := rulesetApply(ctx, path, ruleset, dryRun)
+		if err != nil {
+			log.Printf("Error (%s): %v", path, err)
+
+			continue
+		}
+
+		log.Print(result)
+	}
+
+	return nil
+}
+
+// rulesetApply creates the ruleset under path when no ruleset has the same name, otherwise it is updated
+// only when the existing ruleset does not already contain the desired settings.
+func rulesetApply(ctx context.Context, path string, ruleset RulesetDefinition, dryRun bool) (string, error) {
+	existingID, err := rulesetFind(ctx, path, ruleset.Name)
+	if err != nil {
+		return "", err
+	}
+
+	if existingID == 0 {
+		if dryRun {
+			return fmt.Sprintf("Would create ruleset %s for %s", ruleset.Name, path), nil
+		}
+
+		if err := rulesetSend(ctx, path, http.MethodPost, ruleset); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("Created ruleset %s for %s", ruleset.Name, path), nil
+	}
+
+	rulesetPath := fmt.Sprintf("%s/%d", path, existingID)
+
+	client := restclient.NewClient(rulesetPath, config.Token, http.MethodGet)
+
+	var existing interface{}
+	if err := client.Run(ctx, &existing); err != nil {
+		return "", fmt.Errorf("get ruleset: %w", err)
+	}
+
+	desired, err := rulesetGeneric(ruleset)
+	if err != nil {
+		return "", err
+	}
+
+	if jsonSubset(desired, existing) {
+		return fmt.Sprintf("Ruleset %s already up to date for %s", ruleset.Name, path), nil
+	}
+
+	if dryRun {
+		return fmt.Sprintf("Would update ruleset %s for %s", ruleset.Name, path), nil
+	}
+
+	if err := rulesetSend(ctx, rulesetPath, http.MethodPut, ruleset); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Updated ruleset %s for %s", ruleset.Name, path), nil
+}
+
+// rulesetFind returns the ID of the ruleset defined directly on path with the given name or 0 if there is none.
+func rulesetFind(ctx context.Context, path, name string) (int, error) {
+	listPath := fmt.Sprintf("%s?includes_parents=false&per_page=100", path)
+
+	for listPath != "" {
+		client := restclient.NewClient(listPath, config.Token, http.MethodGet)
+
+		var response []RulesetDefinition
+		if err := client.Run(ctx, &response); err != nil {
+			return 0, fmt.Errorf("list rulesets: %w", err)
+		}
+
+		for _, ruleset := range response {
+			if ruleset.Name == name {
+				return ruleset.ID, nil
+			}
+		}
+
+		listPath = client.NextPath()
+	}
+
+	return 0, nil
+}
+
+func rulesetSend(ctx context.Context, path, method string, ruleset RulesetDefinition) error {
+	client := restclient.NewClient(path, config.Token, method)
+
+	if err := client.SetBody(ruleset); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	var response interface{}
+	if err := client.Run(ctx, &response); err != nil {
+		return fmt.Errorf("send ruleset: %w", err)
+	}
+
+	return nil
+}
+
+func rulesetRead(filePath string) (ruleset RulesetDefinition, err error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return ruleset, fmt.Errorf("could not open ruleset file: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &ruleset); err != nil {
+		return ruleset, fmt.Errorf("could not parse ruleset file: %w", err)
+	}
+
+	if ruleset.Name == "" || ruleset.Enforcement == "" {
+		return ruleset, errRulesetInvalid
+	}
+
+	// An exported ruleset carries the ID of its source, the target is found by name instead
+	ruleset.ID = 0
+
+	// Send empty lists rather than null so rules and bypass actors can be cleared
+	if ruleset.Rules == nil {
+		ruleset.Rules = []interface{}{}
+	}
+
+	if ruleset.BypassActors == nil {
+		ruleset.BypassActors = []interface{}{}
+	}
+
+	return ruleset, nil
+}
+
+func rulesetGeneric(ruleset RulesetDefinition) (generic interface{}, err error) {
+	content, err := json.Marshal(ruleset)
+	if err != nil {
+		return generic, fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &generic); err != nil {
+		return generic, fmt.Errorf("failed to unmarshal: %w", err)
+	}
+
+	return generic, nil
+}
+
+// jsonSubset reports whether every value in want is also in got. GitHub fills in defaults on rulesets
+// so they never match the file exactly, list order is also not kept.
+func jsonSubset(want, got interface{}) bool {
+	switch wantValue := want.(type) {
+	case map[string]interface{}:
+		gotValue, ok := got.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for key, value := range wantValue {
+			if !jsonSubset(value, gotValue[key]) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		gotValue, ok := got.([]interface{})
+		if !ok || len(wantValue) != len(gotValue) {
+			return false
+		}
+
+		for _, wantItem := range wantValue {
+			found := false
+
+			for _, gotItem := range gotValue {
+				if jsonSubset(wantItem, gotItem) {
+					found = true
+
+					break
+				}
+			}
+
+			if !found {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(want, got)
+	}
+}
+
+func rulesetApplyFlagCheck(cmd *cobra.Command) (
+	dryRun bool,
+	rulesetFilePath,
+	reposFilePath string,
+	orgLevel bool,
+	err error,
+) {
+	dryRun, err = cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return dryRun, rulesetFilePath, reposFilePath, orgLevel, fmt.Errorf("%w", err)
+	}
+
+	rulesetFilePath, err = cmd.Flags().GetString("file")
+	if err != nil {
+		return dryRun, rulesetFilePath, reposFilePath, orgLevel, fmt.Errorf("%w", err)
+	}
+
+	reposFilePath, err = cmd.Flags().GetString("repos")
+	if err != nil {
+		return dryRun, rulesetFilePath, reposFilePath, orgLevel, fmt.Errorf("%w", err)
+	}
+
+	orgLevel, err = cmd.Flags().GetBool("org-level")
+	if err != nil {
+		return dryRun, rulesetFilePath, reposFilePath, orgLevel, fmt.Errorf("%w", err)
+	}
+
+	if reposFilePath == "" && !orgLevel {
+		return dryRun, rulesetFilePath, reposFilePath, orgLevel, errRulesetTargetFlags
+	}
+
+	if reposFilePath != "" && orgLevel {
+		return dryRun, rulesetFilePath, reposFilePath, orgLevel, errRulesetTargetDouble
+	}
+
+	return dryRun, rulesetFilePath, reposFilePath, orgLevel, nil
+}
+
+// nolint // needed for cobra
+func init() {
+	rulesetApplyCmd.Flags().StringP("file", "f", "", "path to ruleset JSON file, in the format used by the GitHub rulesets API or export")
+	rulesetApplyCmd.Flags().StringP("repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	rulesetApplyCmd.Flags().BoolP("org-level", "o", false, "apply the ruleset to the organisation instead of a list of repos")
+	rulesetApplyCmd.MarkFlagRequired("file")
+	rulesetApplyCmd.Flags().SortFlags = false
+	rulesetCmd.AddCommand(rulesetApplyCmd)
+	rootCmd.AddCommand(rulesetCmd)
+}
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_rulesetRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     RulesetDefinition
+		wantErr  bool
+	}{
+		{
+			name:     "rulesetRead fails on missing file",
+			filePath: "testdata/does-not-exist.json",
+			wantErr:  true,
+		},
+		{
+			name:     "rulesetRead fails on parse",
+			filePath: "testdata/two_repo_list.txt",
+			wantErr:  true,
+		},
+		{
+			name:     "rulesetRead fails on missing name",
+			filePath: "testdata/ruleset_invalid.json",
+			wantErr:  true,
+		},
+		{
+			name:     "rulesetRead success",
+			filePath: "testdata/ruleset.json",
+			want: RulesetDefinition{
+				Name:        "main protection",
+				Target:      "branch",
+				Enforcement: "active",
+				Conditions: map[string]interface{}{
+					"ref_name": map[string]interface{}{
+						"include": []interface{}{"~DEFAULT_BRANCH"},
+						"exclude": []interface{}{},
+					},
+				},
+				Rules: []interface{}{
+					map[string]interface{}{"type": "deletion"},
+					map[string]interface{}{"type": "non_fast_forward"},
+				},
+				BypassActors: []interface{}{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rulesetRead(tt.filePath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rulesetRead() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rulesetRead() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_jsonSubset(t *testing.T) {
+	tests := []struct {
+		name string
+		want interface{}
+		got  interface{}
+		ok   bool
+	}{
+		{
+			name: "jsonSubset extra keys in got",
+			want: map[string]interface{}{"name": "a"},
+			got:  map[string]interface{}{"name": "a", "id": float64(1)},
+			ok:   true,
+		},
+		{
+			name: "jsonSubset missing key in got",
+			want: map[string]interface{}{"name": "a", "target": "branch"},
+			got:  map[string]interface{}{"name": "a"},
+			ok:   false,
+		},
+		{
+			name: "jsonSubset lists in different order",
+			want: []interface{}{"a", "b"},
+			got:  []interface{}{"b", "a"},
+			ok:   true,
+		},
+		{
+			name: "jsonSubset lists with different length",
+			want: []interface{}{"a"},
+			got:  []interface{}{"a", "b"},
+			ok:   false,
+		},
+		{
+			name: "jsonSubset different types",
+			want: []interface{}{"a"},
+			got:  "a",
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jsonSubset(tt.want, tt.got); got != tt.ok {
+				t.Errorf("jsonSubset() = %v, want %v", got, tt.ok)
+			}
+		})
+	}
+}
+
+func Test_rulesetApply(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	ruleset, err := rulesetRead("testdata/ruleset.json")
+	if err != nil {
+		t.Fatalf("failed to read test data: %v", err)
+	}
+
+	path := "/repos/some-org/some-repo/rulesets"
+	listURL := "https://api.github.com" + path + "?includes_parents=false&per_page=100"
+	nextURL := listURL + "&page=2"
+
+	mockFile := func(filePath string) string {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("failed to read test data: %v", err)
+		}
+
+		return string(content)
+	}
+	getURL := "https://api.github.com" + path + "/7"
+
+	tests := []struct {
+		name         string
+		dryRun       bool
+		listFile     string
+		listStatus   int
+		nextFile     string
+		getFile      string
+		wantResult   string
+		wantErr      bool
+		wantPostCall int
+		wantPutCall  int
+	}{
+		{
+			name:       "rulesetApply fails on list",
+			listFile:   "testdata/mockRest404Response.json",
+			listStatus: 404,
+			wantErr:    true,
+		},
+		{
+			name:       "rulesetApply dry run create",
+			dryRun:     true,
+			listFile:   "testdata/mockEmptyListResponse.json",
+			listStatus: 200,
+			wantResult: "Would create ruleset main protection for /repos/some-org/some-repo/rulesets",
+		},
+		{
+			name:         "rulesetApply create",
+			listFile:     "testdata/mockEmptyListResponse.json",
+			listStatus:   200,
+			wantResult:   "Created ruleset main protection for /repos/some-org/some-repo/rulesets",
+			wantPostCall: 1,
+		},
+		{
+			name:       "rulesetApply already up to date",
+			listFile:   "testdata/mockListRulesetsResponse.json",
+			listStatus: 200,
+			getFile:    "testdata/mockGetRulesetResponse.json",
+			wantResult: "Ruleset main protection already up to date for /repos/some-org/some-repo/rulesets",
+		},
+		{
+			name:       "rulesetApply finds ruleset on next page",
+			listFile:   "testdata/mockEmptyListResponse.json",
+			listStatus: 200,
+			nextFile:   "testdata/mockListRulesetsResponse.json",
+			getFile:    "testdata/mockGetRulesetResponse.json",
+			wantResult: "Ruleset main protection already up to date for /repos/some-org/some-repo/rulesets",
+		},
+		{
+			name:       "rulesetApply dry run update",
+			dryRun:     true,
+			listFile:   "testdata/mockListRulesetsResponse.json",
+			listStatus: 200,
+			getFile:    "testdata/mockGetRulesetChangedResponse.json",
+			wantResult: "Would update ruleset main protection for /repos/some-org/some-repo/rulesets",
+		},
+		{
+			name:        "rulesetApply update",
+			listFile:    "testdata/mockListRulesetsResponse.json",
+			listStatus:  200,
+			getFile:     "testdata/mockGetRulesetChangedResponse.json",
+			wantResult:  "Updated ruleset main protection for /repos/some-org/some-repo/rulesets",
+			wantPutCall: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			if tt.nextFile != "" {
+				firstPage := httpmock.NewStringResponse(tt.listStatus, mockFile(tt.listFile))
+				firstPage.Header.Set("Link", "<"+nextURL+`>; rel="next"`)
+
+				httpmock.RegisterResponder("GET", listURL, httpmock.ResponderFromResponse(firstPage))
+				mockHTTPResponder("GET", nextURL, tt.nextFile, 200)
+			} else {
+				mockHTTPResponder("GET", listURL, tt.listFile, tt.listStatus)
+			}
+			mockHTTPResponder("POST", "https://api.github.com"+path, "testdata/mockGetRulesetResponse.json", 201)
+			mockHTTPResponder("PUT", getURL, "testdata/mockGetRulesetResponse.json", 200)
+
+			if tt.getFile != "" {
+				mockHTTPResponder("GET", getURL, tt.getFile, 200)
+			}
+
+			got, err := rulesetApply(context.Background(), path, ruleset, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rulesetApply() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got != tt.wantResult {
+				t.Errorf("rulesetApply() = %v, want %v", got, tt.wantResult)
+			}
+
+			calls := httpmock.GetCallCountInfo()
+			if calls["POST https://api.github.com"+path] != tt.wantPostCall {
+				t.Errorf("rulesetApply() POST calls = %d, want %d", calls["POST https://api.github.com"+path], tt.wantPostCall)
+			}
+
+			if calls["PUT "+getURL] != tt.wantPutCall {
+				t.Errorf("rulesetApply() PUT calls = %d, want %d", calls["PUT "+getURL], tt.wantPutCall)
+			}
+		})
+	}
+}
+
+func Test_rulesetApplyCommand(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	mockHTTPResponder(
+		"GET",
+		"https://api.github.com/orgs/some-org/rulesets?includes_parents=false&per_page=100",
+		"testdata/mockEmptyListResponse.json",
+		200,
+	)
+
+	newCmd := func(file, repos string, orgLevel bool) *cobra.Command {
+		cmd := &cobra.Command{Use: "apply"}
+		cmd.Flags().Bool("dry-run", true, "dry run flag")
+		cmd.Flags().String("file", file, "ruleset file")
+		cmd.Flags().String("repos", repos, "repos file")
+		cmd.Flags().Bool("org-level", orgLevel, "org level flag")
+
+		return cmd
+	}
+
+	type args struct {
+		cmd  *cobra.Command
+		repo *repository
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "rulesetApplyCommand fails on missing flags",
+			args: args{
+				cmd: &cobra.Command{Use: "apply"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rulesetApplyCommand fails with no target",
+			args: args{
+				cmd: newCmd("testdata/ruleset.json", "", false),
+			},
+			wantErr: true,
+		},
+		{
+			name: "rulesetApplyCommand fails with both targets",
+			args: args{
+				cmd: newCmd("testdata/ruleset.json", "testdata/two_repo_list.txt", true),
+			},
+			wantErr: true,
+		},
+		{
+			name: "rulesetApplyCommand fails on invalid ruleset",
+			args: args{
+				cmd: newCmd("testdata/ruleset_invalid.json", "", true),
+			},
+			wantErr: true,
+		},
+		{
+			name: "rulesetApplyCommand fails on repo read",
+			args: args{
+				cmd:  newCmd("testdata/ruleset.json", "testdata/two_repo_list.txt", false),
+				repo: &repository{reader: &mockRepositoryReader{readFail: true}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rulesetApplyCommand org level dry run",
+			args: args{
+				cmd: newCmd("testdata/ruleset.json", "", true),
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rulesetApplyCommand(tt.args.cmd, tt.args.repo); (err != nil) != tt.wantErr {
+				t.Errorf("rulesetApplyCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errSecretScanningEmptyFlags   = errors.New("must set secret-scanning, push-protection or validity-checks")
+	errSecretScanningFlagsInvalid = errors.New("secret-scanning must be enabled to enable push-protection or validity-checks")
+	errSecretScanningUnavailable  = errors.New("feature unavailable")
+	secretScanningCmd             = &cobra.Command{ // nolint // needed for cobra
+		Use:   "secret-scanning",
+		Short: "Enable and disable secret scanning, push protection and validity checks for repos in provided list",
+		RunE:  secretScanningRun,
+	}
+)
+
+// secretScanningFeature is a security_and_analysis setting the command can change.
+type secretScanningFeature struct {
+	flag        string
+	description string
+	status      func(*SecurityAndAnalysis) **SecurityAndAnalysisStatus
+}
+
+// secretScanningFeatures are in the order the settings are applied and reported.
+var secretScanningFeatures = []secretScanningFeature{ // nolint // expected global
+	{
+		flag:        "secret-scanning",
+		description: "secret scanning",
+		status:      func(s *SecurityAndAnalysis) **SecurityAndAnalysisStatus { return &s.SecretScanning },
+	},
+	{
+		flag:        "push-protection",
+		description: "push protection",
+		status:      func(s *SecurityAndAnalysis) **SecurityAndAnalysisStatus { return &s.SecretScanningPushProtection },
+	},
+	{
+		flag:        "validity-checks",
+		description: "validity checks",
+		status:      func(s *SecurityAndAnalysis) **SecurityAndAnalysisStatus { return &s.SecretScanningValidityChecks },
+	},
+}
+
+type secretScanningSetting struct {
+	feature secretScanningFeature
+	enable  bool
+}
+
+func secretScanningRun(cmd *cobra.Command, args []string) error {
+	err := secretScanningCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+	)
+
+	return err
+}
+
+func secretScanningCommand(cmd *cobra.Command, repo *repository) error {
+	dryRun, reposFilePath, err := branchProtectionFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	settings, err := secretScanningSettings(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	repositoryList, err := repo.reader.read(reposFilePath)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	log.SetFlags(0)
+
+	if dryRun {
+		log.Printf("This is a dry run, the run would process %d repositories", len(repositoryList))
+	}
+
+	ctx := context.Background()
+
+	var (
+		count   changeCount
+		skipped int
+	)
+
+	for _, repositoryName := range repositoryList {
+		changed, result, err := secretScanningUpdate(ctx, repositoryName, settings, dryRun)
+		if errors.Is(err, errSecretScanningUnavailable) {
+			log.Printf("Skipped (%s): %v", repositoryName, err)
+
+			skipped++
+
+			continue
+		}
+
+		if err != nil {
+			log.Printf("Error (%s): %v", repositoryName, err)
+
+			continue
+		}
+
+		log.Print(result)
+		count.add(changed)
+	}
+
+	log.Printf("Secret scanning: %d changed, %d unchanged, %d skipped", count.changed, count.unchanged, skipped)
+
+	return nil
+}
+
+// secretScanningUpdate sets the features on the repository that are not already in the wanted state, it returns
+// whether the repository was changed. A repository that cannot use a feature returns errSecretScanningUnavailable.
+func secretScanningUpdate(
+	ctx context.Context,
+	repositoryName string,
+	settings []secretScanningSetting,
+	dryRun bool,
+) (bool, string, error) {
+	path := fmt.Sprintf("/repos/%s/%s", config.Org, repositoryName)
+
+	var current struct {
+		Private             bool                `json:"private"`
+		SecurityAndAnalysis SecurityAndAnalysis `json:"security_and_analysis"` // nolint // this is from github
+	}
+	if err := restclient.NewClient(path, config.Token, http.MethodGet).Run(ctx, &current); err != nil {
+		return false, "", fmt.Errorf("get repository: %w", err)
+	}
+
+	var (
+		update  SecurityAndAnalysis
+		changes []string
+	)
+
+	for _, setting := range settings {
+		currentStatus := *setting.feature.status(&current.SecurityAndAnalysis)
+		wanted := securityStatusDisabled
+
+		if setting.enable {
+			wanted = securityStatusEnabled
+
+			if err := secretScanningAvailable(current.Private, current.SecurityAndAnalysis, currentStatus, setting); err != nil {
+				return false, "", err
+			}
+		}
+
+		// A feature that is not available to the repository is already disabled
+		if securityAndAnalysisStatus(currentStatus) == wanted || (currentStatus == nil && !setting.enable) {
+			continue
+		}
+
+		*setting.feature.status(&update) = &SecurityAndAnalysisStatus{Status: wanted}
+		changes = append(changes, fmt.Sprintf("%s '%s'", setting.feature.description, wanted))
+	}
+
+	if len(changes) == 0 {
+		return false, fmt.Sprintf("Secret scanning settings already set for repo %s", repositoryName), nil
+	}
+
+	if dryRun {
+		return true, fmt.Sprintf("Would set %s for repo %s", strings.Join(changes, ", "), repositoryName), nil
+	}
+
+	client := restclient.NewClient(path, config.Token, http.MethodPatch)
+	if err := client.SetBody(map[string]SecurityAndAnalysis{"security_and_analysis": update}); err != nil {
+		return false, "", fmt.Errorf("%w", err)
+	}
+
+	var response interface{}
+	if err := client.Run(ctx, &response); err != nil {
+		// GitHub refuses the change when the repository cannot use the feature, the message gives the reason
+		if errors.Is(err, restclient.ErrForbidden) {
+			return false, "", fmt.Errorf("%w: %v", errSecretScanningUnavailable, err)
+		}
+
+		return false, "", fmt.Errorf("update security and analysis: %w", err)
+	}
+
+	return true, fmt.Sprintf(
+		"Successful setting %s for repo %s",
+		strings.Join(changes, ", "),
+		repositoryName,
+	), nil
+}
+
+// secretScanningAvailable checks a feature can be enabled, a private repository needs Advanced Security and a
+// feature missing from security_and_analysis is not offered to the repository.
+func secretScanningAvailable(
+	private bool,
+	current SecurityAndAnalysis,
+	status *SecurityAndAnalysisStatus,
+	setting secretScanningSetting,
+) error {
+	if private && securityAndAnalysisStatus(current.AdvancedSecurity) == securityStatusDisabled {
+		return fmt.Errorf(
+			"%w: %s needs Advanced Security, which is not licensed or enabled for this private repository",
+			errSecretScanningUnavailable,
+			setting.feature.description,
+		)
+	}
+
+	if status == nil {
+		return fmt.Errorf(
+			"%w: %s is not offered to this repository",
+			errSecretScanningUnavailable,
+			setting.feature.description,
+		)
+	}
+
+	return nil
+}
+
+// secretScanningSettings returns the features set on the command line, in the order of secretScanningFeatures.
+func secretScanningSettings(cmd *cobra.Command) ([]secretScanningSetting, error) {
+	var settings []secretScanningSetting
+
+	enabled := make(map[string]bool)
+
+	for _, feature := range secretScanningFeatures {
+		if !cmd.Flags().Changed(feature.flag) {
+			continue
+		}
+
+		enable, err := cmd.Flags().GetBool(feature.flag)
+		if err != nil {
+			return settings, fmt.Errorf("%w", err)
+		}
+
+		enabled[feature.flag] = enable
+		settings = append(settings, secretScanningSetting{feature: feature, enable: enable})
+	}
+
+	if len(settings) == 0 {
+		return settings, errSecretScanningEmptyFlags
+	}
+
+	if enable, ok := enabled["secret-scanning"]; ok && !enable && (enabled["push-protection"] || enabled["validity-checks"]) {
+		return settings, errSecretScanningFlagsInvalid
+	}
+
+	return settings, nil
+}
+
+// nolint // needed for cobra
+func init() {
+	secretScanningCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	secretScanningCmd.Flags().Bool("secret-scanning", true, "boolean indicating the status of the secret scanning setting")
+	secretScanningCmd.Flags().Bool("push-protection", true, "boolean indicating the status of the secret scanning push protection setting")
+	secretScanningCmd.Flags().Bool("validity-checks", true, "boolean indicating the status of the secret scanning validity checks setting")
+	secretScanningCmd.MarkFlagRequired("repos")
+	secretScanningCmd.Flags().SortFlags = false
+	rootCmd.AddCommand(secretScanningCmd)
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func secretScanningTestFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", true, "dry run flag")
+	cmd.Flags().String("repos", "testdata/two_repo_list.txt", "repos file")
+	cmd.Flags().Bool("secret-scanning", true, "secret scanning flag")
+	cmd.Flags().Bool("push-protection", true, "push protection flag")
+	cmd.Flags().Bool("validity-checks", true, "validity checks flag")
+}
+
+func Test_secretScanningSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "secretScanningSettings fails with no settings",
+			cmd:     mockFlagsCmd(secretScanningTestFlags),
+			wantErr: true,
+		},
+		{
+			name:    "secretScanningSettings fails enabling push protection without secret scanning",
+			cmd:     mockFlagsCmd(secretScanningTestFlags, "secret-scanning", "false", "push-protection", "true"),
+			wantErr: true,
+		},
+		{
+			name: "secretScanningSettings disables all",
+			cmd: mockFlagsCmd(secretScanningTestFlags,
+				"secret-scanning", "false",
+				"push-protection", "false",
+				"validity-checks", "false",
+			),
+			want: []string{"secret-scanning=false", "push-protection=false", "validity-checks=false"},
+		},
+		{
+			name: "secretScanningSettings only changed flags",
+			cmd:  mockFlagsCmd(secretScanningTestFlags, "validity-checks", "true", "secret-scanning", "true"),
+			want: []string{"secret-scanning=true", "validity-checks=true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := secretScanningSettings(tt.cmd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("secretScanningSettings() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var got []string
+			for _, setting := range settings {
+				got = append(got, setting.feature.flag+"="+strconv.FormatBool(setting.enable))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("secretScanningSettings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_secretScanningUpdate(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	repoURL := "https://api.github.com/repos/some-org/repo1"
+
+	setting := func(flag string, enable bool) []secretScanningSetting {
+		for _, feature := range secretScanningFeatures {
+			if feature.flag == flag {
+				return []secretScanningSetting{{feature: feature, enable: enable}}
+			}
+		}
+
+		return nil
+	}
+
+	tests := []struct {
+		name            string
+		settings        []secretScanningSetting
+		dryRun          bool
+		getFile         string
+		getStatus       int
+		patchFile       string
+		patchStatus     int
+		wantChanged     bool
+		wantResult      string
+		wantErr         bool
+		wantUnavailable bool
+		wantPatchCall   int
+	}{
+		{
+			name:      "secretScanningUpdate fails on get",
+			settings:  setting("secret-scanning", true),
+			getFile:   "testdata/mockRest404Response.json",
+			getStatus: 404,
+			wantErr:   true,
+		},
+		{
+			name:       "secretScanningUpdate already enabled",
+			settings:   setting("secret-scanning", true),
+			getFile:    "testdata/mockGetRepositorySecurityResponse.json",
+			getStatus:  200,
+			wantResult: "Secret scanning settings already set for repo repo1",
+		},
+		{
+			name:       "secretScanningUpdate disabling a feature not offered is unchanged",
+			settings:   setting("validity-checks", false),
+			getFile:    "testdata/mockGetRepositorySecurityResponse.json",
+			getStatus:  200,
+			wantResult: "Secret scanning settings already set for repo repo1",
+		},
+		{
+			name:            "secretScanningUpdate skips a feature not offered",
+			settings:        setting("validity-checks", true),
+			getFile:         "testdata/mockGetRepositorySecurityResponse.json",
+			getStatus:       200,
+			wantErr:         true,
+			wantUnavailable: true,
+		},
+		{
+			name:            "secretScanningUpdate skips private repo without advanced security",
+			settings:        setting("secret-scanning", true),
+			getFile:         "testdata/mockGetPrivateRepositoryNoAdvancedSecurityResponse.json",
+			getStatus:       200,
+			wantErr:         true,
+			wantUnavailable: true,
+		},
+		{
+			name:        "secretScanningUpdate dry run",
+			settings:    setting("push-protection", true),
+			dryRun:      true,
+			getFile:     "testdata/mockGetRepositorySecurityResponse.json",
+			getStatus:   200,
+			wantChanged: true,
+			wantResult:  "Would set push protection 'enabled' for repo repo1",
+		},
+		{
+			name:            "secretScanningUpdate skips when GitHub forbids the change",
+			settings:        setting("push-protection", true),
+			getFile:         "testdata/mockGetRepositorySecurityResponse.json",
+			getStatus:       200,
+			patchFile:       "testdata/mockRest403Response.json",
+			patchStatus:     403,
+			wantErr:         true,
+			wantUnavailable: true,
+			wantPatchCall:   1,
+		},
+		{
+			name:          "secretScanningUpdate fails on update",
+			settings:      setting("push-protection", true),
+			getFile:       "testdata/mockGetRepositorySecurityResponse.json",
+			getStatus:     200,
+			patchFile:     "testdata/mockRest404Response.json",
+			patchStatus:   500,
+			wantErr:       true,
+			wantPatchCall: 1,
+		},
+		{
+			name:          "secretScanningUpdate enables push protection",
+			settings:      setting("push-protection", true),
+			getFile:       "testdata/mockGetRepositorySecurityResponse.json",
+			getStatus:     200,
+			patchFile:     "testdata/mockGetRepositorySecurityResponse.json",
+			patchStatus:   200,
+			wantChanged:   true,
+			wantResult:    "Successful setting push protection 'enabled' for repo repo1",
+			wantPatchCall: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			mockHTTPResponder("GET", repoURL, tt.getFile, tt.getStatus)
+
+			if tt.patchFile != "" {
+				mockHTTPResponder("PATCH", repoURL, tt.patchFile, tt.patchStatus)
+			}
+
+			changed, result, err := secretScanningUpdate(context.Background(), "repo1", tt.settings, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("secretScanningUpdate() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if unavailable := errors.Is(err, errSecretScanningUnavailable); unavailable != tt.wantUnavailable {
+				t.Errorf("secretScanningUpdate() unavailable = %v, want %v", unavailable, tt.wantUnavailable)
+			}
+
+			if changed != tt.wantChanged || result != tt.wantResult {
+				t.Errorf("secretScanningUpdate() = %v, %v, want %v, %v", changed, result, tt.wantChanged, tt.wantResult)
+			}
+
+			if calls := httpmock.GetCallCountInfo()["PATCH "+repoURL]; calls != tt.wantPatchCall {
+				t.Errorf("secretScanningUpdate PATCH calls = %d, want %d", calls, tt.wantPatchCall)
+			}
+		})
+	}
+}
+
+func Test_secretScanningCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		repo    *repository
+		wantErr bool
+	}{
+		{
+			name:    "secretScanningCommand fails on missing flags",
+			cmd:     &cobra.Command{Use: "secret-scanning"},
+			wantErr: true,
+		},
+		{
+			name:    "secretScanningCommand fails with no settings",
+			cmd:     mockFlagsCmd(secretScanningTestFlags),
+			wantErr: true,
+		},
+		{
+			name:    "secretScanningCommand fails on repo read",
+			cmd:     mockFlagsCmd(secretScanningTestFlags, "secret-scanning", "true"),
+			repo:    &repository{reader: &mockRepositoryReader{readFail: true}},
+			wantErr: true,
+		},
+		{
+			name:    "secretScanningCommand with no repos",
+			cmd:     mockFlagsCmd(secretScanningTestFlags, "secret-scanning", "true"),
+			repo:    &repository{reader: &mockRepositoryReader{}},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := secretScanningCommand(tt.cmd, tt.repo); (err != nil) != tt.wantErr {
+				t.Errorf("secretScanningCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errTeamAccessNoTeams    = errors.New("must set team or mapping-file")
+	errTeamAccessPermission = errors.New("permission must be one of pull, triage, push, maintain or admin")
+	errTeamAccessMapping    = errors.New("invalid line in mapping file")
+	errTeamAccessInvalid    = errors.New("invalid team slug")
+	teamAccessCmd           = &cobra.Command{ // nolint // needed for cobra
+		Use:   "team-access",
+		Short: "Grant and revoke team permissions for repos in provided list",
+	}
+	teamAccessGrantCmd = &cobra.Command{ // nolint // needed for cobra
+		Use:   "grant",
+		Short: "Grant teams a permission on repos in provided list",
+		RunE:  teamAccessGrantRun,
+	}
+	teamAccessRevokeCmd = &cobra.Command{ // nolint // needed for cobra
+		Use:   "revoke",
+		Short: "Remove team access to repos in provided list",
+		RunE:  teamAccessRevokeRun,
+	}
+)
+
+// teamAccessRoleNames maps the permission used to grant access to the role name GitHub reports back.
+var teamAccessRoleNames = map[string]string{ // nolint // expected global
+	"pull":     "read",
+	"triage":   "triage",
+	"push":     "write",
+	"maintain": "maintain",
+	"admin":    "admin",
+}
+
+type teamPermission struct {
+	team       string
+	permission string
+}
+
+func teamAccessGrantRun(cmd *cobra.Command, args []string) error {
+	err := teamAccessCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+		true,
+	)
+
+	return err
+}
+
+func teamAccessRevokeRun(cmd *cobra.Command, args []string) error {
+	err := teamAccessCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+		false,
+	)
+
+	return err
+}
+
+func teamAccessCommand(cmd *cobra.Command, repo *repository, grant bool) error {
+	dryRun, reposFilePath, err := branchProtectionFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	teams, err := teamAccessTeams(cmd, grant)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	repositoryList, err := repo.reader.read(reposFilePath)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	log.SetFlags(0)
+
+	if dryRun {
+		log.Printf(
+			"This is a dry run, the run would process %d teams for %d repositories",
+			len(teams),
+			len(repositoryList),
+		)
+	}
+
+	ctx := context.Background()
+
+	for _, team := range teams {
+		for _, repositoryName := range repositoryList {
+			var (
+				result string
+				err    error
+			)
+
+			if grant {
+				result, err = teamAccessGrant(ctx, team, repositoryName, dryRun)
+			} else {
+				result, err = teamAccessRevoke(ctx, team.team, repositoryName, dryRun)
+			}
+
+			if err != nil {
+				log.Printf("Error (%s/%s): %v", team.team, repositoryName, err)
+
+				continue
+			}
+
+			log.Print(result)
+		}
+	}
+
+	return nil
+}
+
+// teamAccessGrant sets the team permission on the repository unless the team already has it.
+func teamAccessGrant(ctx context.Context, team teamPermission, repositoryName string, dryRun bool) (string, error) {
+	current, err := teamAccessCurrent(ctx, team.team, repositoryName)
+	if err != nil {
+		return "", err
+	}
+
+	if current == teamAccessRoleNames[team.permission] {
+		return fmt.Sprintf("Team %s already has %s on %s", team.team, team.permission, repositoryName), nil
+	}
+
+	if current == "" {
+		current = "none"
+	}
+
+	if dryRun {
+		return fmt.Sprintf(
+			"Would grant team %s %s on %s (currently %s)",
+			team.team,
+			team.permission,
+			repositoryName,
+			current,
+		), nil
+	}
+
+	client := restclient.NewClient(teamAccessPath(team.team, repositoryName), config.Token, http.MethodPut)
+	if err := client.SetBody(map[string]string{"permission": team.permission}); err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	var response interface{}
+	if err := client.Run(ctx, &response); err != nil {
+		return "", fmt.Errorf("grant team access: %w", err)
+	}
+
+	return fmt.Sprintf(
+		"Granted team %s %s on %s (was %s)",
+		team.team,
+		team.permission,
+		repositoryName,
+		current,
+	), nil
+}
+
+// teamAccessRevoke removes the team from the repository when it has access.
+func teamAccessRevoke(ctx context.Context, team, repositoryName string, dryRun bool) (string, error) {
+	current, err := teamAccessCurrent(ctx, team, repositoryName)
+	if err != nil {
+		return "", err
+	}
+
+	if current == "" {
+		return fmt.Sprintf("Team %s has no access to %s", team, repositoryName), nil
+	}
+
+	if dryRun {
+		return fmt.Sprintf("Would revoke team %s %s on %s", team, current, repositoryName), nil
+	}
+
+	client := restclient.NewClient(teamAccessPath(team, repositoryName), config.Token, http.MethodDelete)
+
+	var response interface{}
+	if err := client.Run(ctx, &response); err != nil {
+		return "", fmt.Errorf("revoke team access: %w", err)
+	}
+
+	return fmt.Sprintf("Revoked team %s %s on %s", team, current, repositoryName), nil
+}
+
+// teamAccessCurrent returns the role name the team has on the repository, or empty when it has no access.
+func teamAccessCurrent(ctx context.Context, team, repositoryName string) (string, error) {
+	client := restclient.NewClient(teamAccessPath(team, repositoryName), config.Token, http.MethodGet)
+	client.SetAccept("application/vnd.github.v3.repository+json")
+
+	var response struct {
+		RoleName string `json:"role_name"`
+	}
+	if err := client.Run(ctx, &response); err != nil {
+		if errors.Is(err, restclient.ErrNotFound) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("get team access: %w", err)
+	}
+
+	return response.RoleName, nil
+}
+
+func teamAccessPath(team, repositoryName string) string {
+	return fmt.Sprintf("/orgs/%s/teams/%s/repos/%s/%s", config.Org, team, config.Org, repositoryName)
+}
+
+// teamAccessTeams returns the teams from the team flag and mapping file, grants need a permission for each team.
+func teamAccessTeams(cmd *cobra.Command, grant bool) ([]teamPermission, error) {
+	var teams []teamPermission
+
+	teamSlugs, err := cmd.Flags().GetStringSlice("team")
+	if err != nil {
+		return teams, fmt.Errorf("%w", err)
+	}
+
+	mappingFilePath, err := cmd.Flags().GetString("mapping-file")
+	if err != nil {
+		return teams, fmt.Errorf("%w", err)
+	}
+
+	permission := ""
+	if grant {
+		if permission, err = cmd.Flags().GetString("permission"); err != nil {
+			return teams, fmt.Errorf("%w", err)
+		}
+	}
+
+	for _, teamSlug := range teamSlugs {
+		teams = append(teams, teamPermission{team: teamSlug, permission: permission})
+	}
+
+	if mappingFilePath != "" {
+		mapping, err := teamAccessReadMapping(mappingFilePath)
+		if err != nil {
+			return teams, err
+		}
+
+		teams = append(teams, mapping...)
+	}
+
+	if len(teams) == 0 {
+		return teams, errTeamAccessNoTeams
+	}
+
+	validTeamSlug := regexp.MustCompile("^[A-Za-z0-9_.-]+$")
+
+	for _, team := range teams {
+		if !validTeamSlug.MatchString(team.team) {
+			return teams, fmt.Errorf("%w: %s", errTeamAccessInvalid, team.team)
+		}
+
+		if _, ok := teamAccessRoleNames[team.permission]; grant && !ok {
+			return teams, fmt.Errorf("%w: %s for team %s", errTeamAccessPermission, team.permission, team.team)
+		}
+	}
+
+	return teams, nil
+}
+
+// teamAccessReadMapping reads a file of "team-slug,permission" lines, the permission is ignored on revoke.
+func teamAccessReadMapping(mappingFilePath string) ([]teamPermission, error) {
+	var teams []teamPermission
+
+	file, err := os.Open(mappingFilePath)
+	if err != nil {
+		return teams, fmt.Errorf("could not open mapping file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) > 2 {
+			return teams, fmt.Errorf("%w: %s", errTeamAccessMapping, line)
+		}
+
+		team := teamPermission{team: strings.TrimSpace(fields[0])}
+		if len(fields) == 2 {
+			team.permission = strings.TrimSpace(fields[1])
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+// nolint // needed for cobra
+func init() {
+	teamAccessGrantCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	teamAccessGrantCmd.Flags().StringSliceP("team", "t", []string{}, "team slug to grant the permission to, can be repeated")
+	teamAccessGrantCmd.Flags().StringP("permission", "p", "", "permission to grant: pull, triage, push, maintain or admin")
+	teamAccessGrantCmd.Flags().StringP("mapping-file", "m", "", "path to file containing team-slug,permission on new lines")
+	teamAccessGrantCmd.MarkFlagRequired("repos")
+	teamAccessGrantCmd.Flags().SortFlags = false
+
+	teamAccessRevokeCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	teamAccessRevokeCmd.Flags().StringSliceP("team", "t", []string{}, "team slug to remove access for, can be repeated")
+	teamAccessRevokeCmd.Flags().StringP("mapping-file", "m", "", "path to file containing team slugs on new lines")
+	teamAccessRevokeCmd.MarkFlagRequired("repos")
+	teamAccessRevokeCmd.Flags().SortFlags = false
+
+	teamAccessCmd.AddCommand(teamAccessGrantCmd)
+	teamAccessCmd.AddCommand(teamAccessRevokeCmd)
+	rootCmd.AddCommand(teamAccessCmd)
+}
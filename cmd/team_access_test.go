@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func teamAccessTestFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", true, "dry run flag")
+	cmd.Flags().String("repos", "testdata/two_repo_list.txt", "repos file")
+	cmd.Flags().StringSlice("team", []string{}, "team flag")
+	cmd.Flags().String("permission", "", "permission flag")
+	cmd.Flags().String("mapping-file", "", "mapping file flag")
+}
+
+func Test_teamAccessTeams(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		grant   bool
+		want    []teamPermission
+		wantErr bool
+	}{
+		{
+			name:    "teamAccessTeams fails with no teams",
+			cmd:     mockFlagsCmd(teamAccessTestFlags, "permission", "maintain"),
+			grant:   true,
+			wantErr: true,
+		},
+		{
+			name:    "teamAccessTeams fails on invalid permission",
+			cmd:     mockFlagsCmd(teamAccessTestFlags, "team", "platform", "permission", "write"),
+			grant:   true,
+			wantErr: true,
+		},
+		{
+			name:    "teamAccessTeams fails on invalid team slug",
+			cmd:     mockFlagsCmd(teamAccessTestFlags, "team", "some team", "permission", "maintain"),
+			grant:   true,
+			wantErr: true,
+		},
+		{
+			name:    "teamAccessTeams fails on missing mapping file",
+			cmd:     mockFlagsCmd(teamAccessTestFlags, "mapping-file", "testdata/does-not-exist.txt"),
+			wantErr: true,
+		},
+		{
+			name:    "teamAccessTeams fails on invalid mapping line",
+			cmd:     mockFlagsCmd(teamAccessTestFlags, "mapping-file", "testdata/team_mapping_bad.txt"),
+			wantErr: true,
+		},
+		{
+			name:  "teamAccessTeams revoke does not need a permission",
+			cmd:   mockFlagsCmd(teamAccessTestFlags, "team", "platform,security"),
+			grant: false,
+			want: []teamPermission{
+				{team: "platform"},
+				{team: "security"},
+			},
+		},
+		{
+			name: "teamAccessTeams grant from flag and mapping file",
+			cmd: mockFlagsCmd(
+				teamAccessTestFlags,
+				"team", "developers",
+				"permission", "push",
+				"mapping-file", "testdata/team_mapping.txt",
+			),
+			grant: true,
+			want: []teamPermission{
+				{team: "developers", permission: "push"},
+				{team: "platform", permission: "maintain"},
+				{team: "security", permission: "pull"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := teamAccessTeams(tt.cmd, tt.grant)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("teamAccessTeams() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("teamAccessTeams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_teamAccessGrantAndRevoke(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	teamRepoURL := "https://api.github.com/orgs/some-org/teams/platform/repos/some-org/some-repo"
+
+	tests := []struct {
+		name           string
+		grant          bool
+		permission     string
+		dryRun         bool
+		getFile        string
+		getStatus      int
+		wantResult     string
+		wantErr        bool
+		wantUpdateCall int
+	}{
+		{
+			name:       "teamAccessGrant fails on get",
+			grant:      true,
+			permission: "maintain",
+			getFile:    "testdata/mockRest401Response.json",
+			getStatus:  401,
+			wantErr:    true,
+		},
+		{
+			name:       "teamAccessGrant already has permission",
+			grant:      true,
+			permission: "push",
+			getFile:    "testdata/mockGetTeamRepoResponse.json",
+			getStatus:  200,
+			wantResult: "Team platform already has push on some-repo",
+		},
+		{
+			name:       "teamAccessGrant dry run with no access",
+			grant:      true,
+			permission: "maintain",
+			dryRun:     true,
+			getFile:    "testdata/mockRest404Response.json",
+			getStatus:  404,
+			wantResult: "Would grant team platform maintain on some-repo (currently none)",
+		},
+		{
+			name:           "teamAccessGrant changes permission",
+			grant:          true,
+			permission:     "maintain",
+			getFile:        "testdata/mockGetTeamRepoResponse.json",
+			getStatus:      200,
+			wantResult:     "Granted team platform maintain on some-repo (was write)",
+			wantUpdateCall: 1,
+		},
+		{
+			name:       "teamAccessRevoke with no access",
+			getFile:    "testdata/mockRest404Response.json",
+			getStatus:  404,
+			wantResult: "Team platform has no access to some-repo",
+		},
+		{
+			name:       "teamAccessRevoke dry run",
+			dryRun:     true,
+			getFile:    "testdata/mockGetTeamRepoResponse.json",
+			getStatus:  200,
+			wantResult: "Would revoke team platform write on some-repo",
+		},
+		{
+			name:           "teamAccessRevoke removes access",
+			getFile:        "testdata/mockGetTeamRepoResponse.json",
+			getStatus:      200,
+			wantResult:     "Revoked team platform write on some-repo",
+			wantUpdateCall: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			mockHTTPResponder("GET", teamRepoURL, tt.getFile, tt.getStatus)
+			mockHTTPResponder("PUT", teamRepoURL, "testdata/mockEmptyResponse.json", 204)
+			mockHTTPResponder("DELETE", teamRepoURL, "testdata/mockEmptyResponse.json", 204)
+
+			var (
+				got    string
+				err    error
+				method = "DELETE"
+			)
+
+			if tt.grant {
+				method = "PUT"
+				got, err = teamAccessGrant(
+					context.Background(),
+					teamPermission{team: "platform", permission: tt.permission},
+					"some-repo",
+					tt.dryRun,
+				)
+			} else {
+				got, err = teamAccessRevoke(context.Background(), "platform", "some-repo", tt.dryRun)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("teamAccess error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got != tt.wantResult {
+				t.Errorf("teamAccess = %v, want %v", got, tt.wantResult)
+			}
+
+			if calls := httpmock.GetCallCountInfo()[method+" "+teamRepoURL]; calls != tt.wantUpdateCall {
+				t.Errorf("teamAccess %s calls = %d, want %d", method, calls, tt.wantUpdateCall)
+			}
+		})
+	}
+}
+
+func Test_teamAccessCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		repo    *repository
+		wantErr bool
+	}{
+		{
+			name:    "teamAccessCommand fails on missing flags",
+			cmd:     &cobra.Command{Use: "grant"},
+			wantErr: true,
+		},
+		{
+			name:    "teamAccessCommand fails with no teams",
+			cmd:     mockFlagsCmd(teamAccessTestFlags, "permission", "maintain"),
+			wantErr: true,
+		},
+		{
+			name:    "teamAccessCommand fails on repo read",
+			cmd:     mockFlagsCmd(teamAccessTestFlags, "team", "platform", "permission", "maintain"),
+			repo:    &repository{reader: &mockRepositoryReader{readFail: true}},
+			wantErr: true,
+		},
+		{
+			name:    "teamAccessCommand with no repos",
+			cmd:     mockFlagsCmd(teamAccessTestFlags, "team", "platform", "permission", "maintain"),
+			repo:    &repository{reader: &mockRepositoryReader{}},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := teamAccessCommand(tt.cmd, tt.repo, true); (err != nil) != tt.wantErr {
+				t.Errorf("teamAccessCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/restclient"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	webhookScopeRepo = "repo"
+	webhookScopeOrg  = "org"
+)
+
+var (
+	errWebhookMatch       = errors.New("set one of url, host, url-prefix, glob or regex")
+	errWebhookTarget      = errors.New("set one of repos, all-repos or org")
+	errWebhookSecret      = errors.New("set only one of secret-env or secret-file")
+	errWebhookSecretEmpty = errors.New("webhook secret is empty")
+	errWebhookContentType = errors.New("content-type must be json or form")
+	errWebhookEvents      = errors.New("at least one event is needed")
+)
+
+// webhookSettings are the webhook-add and webhook-update settings, changed has the flags set on the command line.
+type webhookSettings struct {
+	url         string
+	contentType string
+	events      []string
+	secret      string
+	active      bool
+	changed     map[string]bool
+}
+
+// webhookMatcher matches webhook URLs, the description is used for logging.
+type webhookMatcher struct {
+	description string
+	match       func(webhookURL string) bool
+}
+
+// webhookMatchFlags are the flags webhookMatcherFlags reads, only one can be set.
+var webhookMatchFlags = []string{"url", "host", "url-prefix", "glob", "regex"} // nolint // expected global
+
+func webhookMatcherFlags(cmd *cobra.Command) (webhookMatcher, error) {
+	var flag, value string
+
+	for _, name := range webhookMatchFlags {
+		flagValue, err := cmd.Flags().GetString(name)
+		if err != nil {
+			return webhookMatcher{}, fmt.Errorf("%w", err)
+		}
+
+		if flagValue == "" {
+			continue
+		}
+
+		if flag != "" {
+			return webhookMatcher{}, errWebhookMatch
+		}
+
+		flag, value = name, flagValue
+	}
+
+	return newWebhookMatcher(flag, value)
+}
+
+func newWebhookMatcher(flag, value string) (webhookMatcher, error) {
+	matcher := webhookMatcher{description: fmt.Sprintf("%s %s", flag, value)}
+
+	switch flag {
+	case "url":
+		if _, err := url.ParseRequestURI(value); err != nil {
+			return matcher, fmt.Errorf("%w", err)
+		}
+
+		matcher.match = func(webhookURL string) bool { return webhookURL == value }
+	case "host":
+		matcher.match = func(webhookURL string) bool {
+			parsed, err := url.Parse(webhookURL)
+
+			return err == nil && strings.EqualFold(parsed.Hostname(), value)
+		}
+	case "url-prefix":
+		matcher.match = func(webhookURL string) bool { return strings.HasPrefix(webhookURL, value) }
+	case "glob":
+		// * matches any characters, including /, and ? matches a single character
+		pattern := strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(value))
+		matcher.match = regexp.MustCompile("^" + pattern + "$").MatchString
+	case "regex":
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return matcher, fmt.Errorf("invalid regex: %w", err)
+		}
+
+		matcher.match = pattern.MatchString
+	default:
+		return matcher, errWebhookMatch
+	}
+
+	return matcher, nil
+}
+
+// webhooksPath returns the hooks path of the repository, or the organisation when there is no repository name.
+func webhooksPath(repositoryName string) string {
+	if repositoryName == "" {
+		return fmt.Sprintf("/orgs/%s/hooks", config.Org)
+	}
+
+	return fmt.Sprintf("/repos/%s/%s/hooks", config.Org, repositoryName)
+}
+
+// webhooksTarget describes the webhooksPath owner for logging.
+func webhooksTarget(repositoryName string) string {
+	if repositoryName == "" {
+		return fmt.Sprintf("org %s", config.Org)
+	}
+
+	return fmt.Sprintf("repo %s", repositoryName)
+}
+
+// getMatchingWebhooks returns every webhook of the repository, or the organisation, with a URL the matcher matches.
+func getMatchingWebhooks(
+	ctx context.Context,
+	matcher webhookMatcher,
+	repositoryName string,
+) ([]WebhookResponse, error) {
+	webhooks, err := listWebhooks(ctx, repositoryName)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterWebhooks(webhooks, matcher), nil
+}
+
+func filterWebhooks(webhooks []WebhookResponse, matcher webhookMatcher) []WebhookResponse {
+	var matching []WebhookResponse
+
+	for _, webhook := range webhooks {
+		if matcher.match(webhook.Config.URL) {
+			matching = append(matching, webhook)
+		}
+	}
+
+	return matching
+}
+
+func listWebhooks(ctx context.Context, repositoryName string) ([]WebhookResponse, error) {
+	var webhooks []WebhookResponse
+
+	path := webhooksPath(repositoryName) + "?per_page=100"
+
+	for path != "" {
+		client := restclient.NewClient(path, config.Token, http.MethodGet)
+
+		var response []WebhookResponse
+		if err := client.Run(ctx, &response); err != nil {
+			return webhooks, fmt.Errorf("list webhooks: %w", err)
+		}
+
+		webhooks = append(webhooks, response...)
+		path = client.NextPath()
+	}
+
+	return webhooks, nil
+}
+
+// setWebhookConfig changes only the config fields that are set, an existing secret is kept when none is given.
+func setWebhookConfig(ctx context.Context, repositoryName string, webhookID int, update WebhookConfigUpdate) error {
+	client := restclient.NewClient(
+		fmt.Sprintf("%s/%d/config", webhooksPath(repositoryName), webhookID),
+		config.Token,
+		http.MethodPatch,
+	)
+	if err := client.SetBody(update); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	var response interface{}
+	if err := client.Run(ctx, &response); err != nil {
+		return fmt.Errorf("update webhook %d config: %w", webhookID, err)
+	}
+
+	return nil
+}
+
+// webhookTargets returns the repositories set by the repos, all-repos or org flags, the organisation is an empty
+// repository name.
+func webhookTargets(cmd *cobra.Command, repo *repository, reposFilePath string) ([]string, error) {
+	org, err := cmd.Flags().GetBool("org")
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	allRepos, err := cmd.Flags().GetBool("all-repos")
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	targets := 0
+
+	for _, set := range []bool{org, allRepos, reposFilePath != ""} {
+		if set {
+			targets++
+		}
+	}
+
+	if targets != 1 {
+		return nil, errWebhookTarget
+	}
+
+	switch {
+	case org:
+		return []string{""}, nil
+	case allRepos:
+		return webhookAllRepositories()
+	}
+
+	repositoryList, err := repo.reader.read(reposFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return repositoryList, nil
+}
+
+func webhookSettingsFlags(cmd *cobra.Command) (webhookSettings, error) {
+	var err error
+
+	settings := webhookSettings{changed: make(map[string]bool)}
+
+	for _, flag := range []string{"content-type", "events", "active"} {
+		settings.changed[flag] = cmd.Flags().Changed(flag)
+	}
+
+	settings.url, err = cmd.Flags().GetString("url")
+	if err != nil {
+		return settings, fmt.Errorf("%w", err)
+	}
+
+	if _, err = url.ParseRequestURI(settings.url); err != nil {
+		return settings, fmt.Errorf("%w", err)
+	}
+
+	settings.contentType, err = cmd.Flags().GetString("content-type")
+	if err != nil {
+		return settings, fmt.Errorf("%w", err)
+	}
+
+	if settings.contentType != "json" && settings.contentType != "form" {
+		return settings, fmt.Errorf("%w: %s", errWebhookContentType, settings.contentType)
+	}
+
+	settings.events, err = cmd.Flags().GetStringSlice("events")
+	if err != nil {
+		return settings, fmt.Errorf("%w", err)
+	}
+
+	if len(settings.events) == 0 {
+		return settings, errWebhookEvents
+	}
+
+	settings.active, err = cmd.Flags().GetBool("active")
+	if err != nil {
+		return settings, fmt.Errorf("%w", err)
+	}
+
+	settings.secret, err = webhookSecretFlags(cmd)
+	if err != nil {
+		return settings, err
+	}
+
+	return settings, nil
+}
+
+// webhookSecretFlags returns the secret from the environment variable named by secret-env or the contents of
+// secret-file, the secret is not taken on the command line so it does not end up in shell history.
+func webhookSecretFlags(cmd *cobra.Command) (string, error) {
+	secretEnv, err := cmd.Flags().GetString("secret-env")
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	secretFile, err := cmd.Flags().GetString("secret-file")
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	var secret string
+
+	switch {
+	case secretEnv != "" && secretFile != "":
+		return "", errWebhookSecret
+	case secretEnv != "":
+		secret = os.Getenv(secretEnv)
+	case secretFile != "":
+		contents, err := os.ReadFile(secretFile)
+		if err != nil {
+			return "", fmt.Errorf("could not read secret file: %w", err)
+		}
+
+		secret = strings.TrimSpace(string(contents))
+	default:
+		return "", nil
+	}
+
+	if secret == "" {
+		return "", errWebhookSecretEmpty
+	}
+
+	return secret, nil
+}
+
+// webhookAllRepositories returns the organisation repositories that are not archived, archived repository webhooks
+// cannot be changed.
+func webhookAllRepositories() ([]string, error) {
+	var repositories []string
+
+	getter := &reportWebhookGetterService{}
+
+	cursorLists, err := getter.getRepositoryList(&reportWebhook{ignoreArchived: true})
+	if err != nil {
+		return repositories, fmt.Errorf("%w", err)
+	}
+
+	for _, cursorList := range cursorLists {
+		repositories = append(repositories, cursorList.repositories...)
+	}
+
+	return repositories, nil
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+var webhookAddCmd = &cobra.Command{ // nolint // needed for cobra
+	Use:   "webhook-add",
+	Short: "Add a webhook to repos in provided list, all repos or the organisation where it is missing",
+	RunE:  webhookAddRun,
+}
+
+// nolint // needed for cobra
+func init() {
+	webhookAddCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	webhookAddCmd.Flags().Bool("all-repos", false, "add the webhook to every repository in the organisation that is not archived")
+	webhookAddCmd.Flags().Bool("org", false, "add an organisation webhook instead of repository webhooks")
+	webhookAddFlags(webhookAddCmd)
+	webhookAddCmd.MarkFlagRequired("url")
+	webhookAddCmd.Flags().SortFlags = false
+	rootCmd.AddCommand(webhookAddCmd)
+}
+
+// webhookAddFlags are the webhook settings flags shared with webhook-update.
+func webhookAddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("url", "u", "", "full url the webhook sends to")
+	cmd.Flags().String("content-type", "json", "webhook payload content type, must be json or form")
+	cmd.Flags().StringSlice("events", []string{"push"}, "events that trigger the webhook, can be repeated")
+	cmd.Flags().String("secret-env", "", "name of the environment variable containing the webhook secret")
+	cmd.Flags().String("secret-file", "", "path to file containing the webhook secret")
+	cmd.Flags().Bool("active", true, "boolean indicating whether the webhook sends deliveries")
+}
+
+func webhookAddRun(cmd *cobra.Command, args []string) error {
+	err := webhookAddCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+	)
+
+	return err
+}
+
+func webhookAddCommand(cmd *cobra.Command, repo *repository) error {
+	dryRun, reposFilePath, err := branchProtectionFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	settings, err := webhookSettingsFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	repositoryList, err := webhookTargets(cmd, repo, reposFilePath)
+	if err != nil {
+		return err
+	}
+
+	log.SetFlags(0)
+
+	if dryRun {
+		log.Printf("This is a dry run, the run would process %d repositories", len(repositoryList))
+	}
+
+	ctx := context.Background()
+
+	var count changeCount
+
+	for _, repositoryName := range repositoryList {
+		changed, result, err := webhookAddRepository(ctx, repositoryName, settings, dryRun)
+		if err != nil {
+			log.Printf("Error (%s): %v", webhooksTarget(repositoryName), err)
+
+			continue
+		}
+
+		log.Print(result)
+		count.add(changed)
+	}
+
+	log.Printf("Webhook %s: %d created, %d already existed", settings.url, count.changed, count.unchanged)
+
+	return nil
+}
+
+// webhookAddRepository creates the webhook unless the repository, or the organisation, already has one for the URL.
+func webhookAddRepository(
+	ctx context.Context,
+	repositoryName string,
+	settings webhookSettings,
+	dryRun bool,
+) (bool, string, error) {
+	webhooks, err := listWebhooks(ctx, repositoryName)
+	if err != nil {
+		return false, "", err
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.Config.URL == settings.url {
+			return false, fmt.Sprintf(
+				"Webhook %s already exists for %s id is %d", settings.url, webhooksTarget(repositoryName), webhook.ID,
+			), nil
+		}
+	}
+
+	if dryRun {
+		return true, fmt.Sprintf("Would create webhook %s for %s", settings.url, webhooksTarget(repositoryName)), nil
+	}
+
+	client := restclient.NewClient(webhooksPath(repositoryName), config.Token, http.MethodPost)
+	if err := client.SetBody(WebhookCreate{
+		Name:   "web",
+		Active: settings.active,
+		Events: settings.events,
+		Config: WebhookConfigUpdate{
+			URL:         settings.url,
+			ContentType: settings.contentType,
+			Secret:      settings.secret,
+			InsecureSSL: "0",
+		},
+	}); err != nil {
+		return false, "", fmt.Errorf("%w", err)
+	}
+
+	var response WebhookResponse
+	if err := client.Run(ctx, &response); err != nil {
+		return false, "", fmt.Errorf("create webhook: %w", err)
+	}
+
+	return true, fmt.Sprintf(
+		"Successful creating webhook %s for %s id is %d", settings.url, webhooksTarget(repositoryName), response.ID,
+	), nil
+}
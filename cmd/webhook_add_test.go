@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_webhookAddRepository(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	ctx := context.Background()
+	settings := webhookSettings{
+		url:         "https://ci.example.com/hook",
+		contentType: "json",
+		events:      []string{"push"},
+		active:      true,
+	}
+	existingSettings := settings
+	existingSettings.url = "https://some-external-webhook.net"
+
+	tests := []struct {
+		name           string
+		repositoryName string
+		settings       webhookSettings
+		dryRun         bool
+		responses      []dependabotMockResponse
+		wantChanged    bool
+		wantCalls      map[string]int
+		wantErr        bool
+	}{
+		{
+			name:           "webhookAddRepository list webhooks failure",
+			repositoryName: "some-repo",
+			settings:       settings,
+			responses: []dependabotMockResponse{
+				{"GET", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockRest404Response.json", 404},
+			},
+			wantErr: true,
+		},
+		{
+			name:           "webhookAddRepository webhook already exists",
+			repositoryName: "some-repo",
+			settings:       existingSettings,
+			responses: []dependabotMockResponse{
+				{"GET", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockGetWebhooksResponse.json", 200},
+				{"POST", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockGetWebhookResponse.json", 201},
+			},
+			wantCalls: map[string]int{"POST https://api.github.com/repos/some-org/some-repo/hooks": 0},
+		},
+		{
+			name:           "webhookAddRepository dry run",
+			repositoryName: "some-repo",
+			settings:       settings,
+			dryRun:         true,
+			responses: []dependabotMockResponse{
+				{"GET", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockGetWebhooksResponse.json", 200},
+				{"POST", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockGetWebhookResponse.json", 201},
+			},
+			wantChanged: true,
+			wantCalls:   map[string]int{"POST https://api.github.com/repos/some-org/some-repo/hooks": 0},
+		},
+		{
+			name:           "webhookAddRepository create failure",
+			repositoryName: "some-repo",
+			settings:       settings,
+			responses: []dependabotMockResponse{
+				{"GET", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockEmptyListResponse.json", 200},
+				{"POST", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockRest404Response.json", 404},
+			},
+			wantErr: true,
+		},
+		{
+			name:           "webhookAddRepository create success",
+			repositoryName: "some-repo",
+			settings:       settings,
+			responses: []dependabotMockResponse{
+				{"GET", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockGetWebhooksResponse.json", 200},
+				{"POST", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockGetWebhookResponse.json", 201},
+			},
+			wantChanged: true,
+			wantCalls:   map[string]int{"POST https://api.github.com/repos/some-org/some-repo/hooks": 1},
+		},
+		{
+			name:     "webhookAddRepository organisation create success",
+			settings: settings,
+			responses: []dependabotMockResponse{
+				{"GET", "https://api.github.com/orgs/some-org/hooks", "testdata/mockEmptyListResponse.json", 200},
+				{"POST", "https://api.github.com/orgs/some-org/hooks", "testdata/mockGetWebhookResponse.json", 201},
+			},
+			wantChanged: true,
+			wantCalls:   map[string]int{"POST https://api.github.com/orgs/some-org/hooks": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			for _, response := range tt.responses {
+				mockHTTPResponder(response.method, response.url, response.file, response.statusCode)
+			}
+
+			gotChanged, _, err := webhookAddRepository(ctx, tt.repositoryName, tt.settings, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookAddRepository() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if gotChanged != tt.wantChanged {
+				t.Errorf("webhookAddRepository() changed = %v, want %v", gotChanged, tt.wantChanged)
+			}
+
+			calls := httpmock.GetCallCountInfo()
+			for call, want := range tt.wantCalls {
+				if calls[call] != want {
+					t.Errorf("webhookAddRepository() %s called %d times, want %d", call, calls[call], want)
+				}
+			}
+		})
+	}
+}
+
+func Test_webhookAddCommand(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	tests := []struct {
+		name      string
+		cmd       *cobra.Command
+		repo      *repository
+		responses []dependabotMockResponse
+		wantCalls map[string]int
+		wantErr   bool
+	}{
+		{
+			name:    "webhookAddCommand flag check failure",
+			cmd:     &cobra.Command{Use: "webhook-add"},
+			wantErr: true,
+		},
+		{
+			name:    "webhookAddCommand settings failure",
+			cmd:     mockFlagsCmd(webhookSettingsTestFlags, "repos", "filepath", "url", "ci.example.com"),
+			wantErr: true,
+		},
+		{
+			name:    "webhookAddCommand no target failure",
+			cmd:     mockFlagsCmd(webhookSettingsTestFlags, "url", "https://ci.example.com/hook"),
+			wantErr: true,
+		},
+		{
+			name: "webhookAddCommand repo read failure",
+			cmd:  mockFlagsCmd(webhookSettingsTestFlags, "repos", "filepath", "url", "https://ci.example.com/hook"),
+			repo: &repository{
+				reader: &mockRepositoryReader{readFail: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "webhookAddCommand creates only missing webhooks",
+			cmd: mockFlagsCmd(
+				webhookSettingsTestFlags,
+				"repos", "filepath",
+				"url", "https://some-external-webhook.com",
+			),
+			repo: &repository{
+				reader: &mockRepositoryReader{returnValue: []string{"repo1", "repo2", "repo3"}},
+			},
+			responses: []dependabotMockResponse{
+				{"GET", "https://api.github.com/repos/some-org/repo1/hooks", "testdata/mockGetWebhooksResponse.json", 200},
+				{"GET", "https://api.github.com/repos/some-org/repo2/hooks", "testdata/mockEmptyListResponse.json", 200},
+				{"GET", "https://api.github.com/repos/some-org/repo3/hooks", "testdata/mockRest404Response.json", 404},
+				{"POST", "https://api.github.com/repos/some-org/repo1/hooks", "testdata/mockGetWebhookResponse.json", 201},
+				{"POST", "https://api.github.com/repos/some-org/repo2/hooks", "testdata/mockGetWebhookResponse.json", 201},
+			},
+			wantCalls: map[string]int{
+				"POST https://api.github.com/repos/some-org/repo1/hooks": 0,
+				"POST https://api.github.com/repos/some-org/repo2/hooks": 1,
+			},
+		},
+		{
+			name: "webhookAddCommand organisation dry run",
+			cmd: mockFlagsCmd(
+				webhookSettingsTestFlags,
+				"dry-run", "true",
+				"org", "true",
+				"url", "https://ci.example.com/hook",
+			),
+			responses: []dependabotMockResponse{
+				{"GET", "https://api.github.com/orgs/some-org/hooks", "testdata/mockGetWebhooksResponse.json", 200},
+				{"POST", "https://api.github.com/orgs/some-org/hooks", "testdata/mockGetWebhookResponse.json", 201},
+			},
+			wantCalls: map[string]int{"POST https://api.github.com/orgs/some-org/hooks": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			for _, response := range tt.responses {
+				mockHTTPResponder(response.method, response.url, response.file, response.statusCode)
+			}
+
+			if err := webhookAddCommand(tt.cmd, tt.repo); (err != nil) != tt.wantErr {
+				t.Errorf("webhookAddCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			calls := httpmock.GetCallCountInfo()
+			for call, want := range tt.wantCalls {
+				if calls[call] != want {
+					t.Errorf("webhookAddCommand() %s called %d times, want %d", call, calls[call], want)
+				}
+			}
+		})
+	}
+}
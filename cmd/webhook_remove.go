@@ -6,14 +6,13 @@ import (
 	"github-admin-tool/restclient"
 	"log"
 	"net/http"
-	"net/url"
 
 	"github.com/spf13/cobra"
 )
 
 var webhookRemoveCmd = &cobra.Command{ // nolint // needed for cobra
 	Use:   "webhook-remove",
-	Short: "Remove webhook settings for repos in provided list by hostname",
+	Short: "Remove webhooks matching a url, hostname, url prefix, glob or regex for repos in provided list, all repos or the organisation",
 	RunE:  webhookRemoveRun,
 }
 
@@ -22,9 +21,13 @@ func init() {
 	webhookRemoveCmd.Flags().StringVarP(
 		&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)",
 	)
-	webhookRemoveCmd.Flags().StringVarP(&webhookURL, "url", "u", "", "full url to remove webhook for")
-	webhookRemoveCmd.MarkFlagRequired("repos")
-	webhookRemoveCmd.MarkFlagRequired("url")
+	webhookRemoveCmd.Flags().Bool("all-repos", false, "remove webhooks from every repository in the organisation that is not archived")
+	webhookRemoveCmd.Flags().Bool("org", false, "remove organisation webhooks instead of repository webhooks")
+	webhookRemoveCmd.Flags().StringVarP(&webhookURL, "url", "u", "", "full url to remove webhooks for")
+	webhookRemoveCmd.Flags().String("host", "", "hostname to remove webhooks for")
+	webhookRemoveCmd.Flags().String("url-prefix", "", "url prefix to remove webhooks for")
+	webhookRemoveCmd.Flags().String("glob", "", "url glob to remove webhooks for, * matches any characters, e.g. https://*.example.com/*")
+	webhookRemoveCmd.Flags().String("regex", "", "url regular expression to remove webhooks for")
 	webhookRemoveCmd.Flags().SortFlags = true
 	rootCmd.AddCommand(webhookRemoveCmd)
 }
@@ -41,41 +44,64 @@ func webhookRemoveRun(cmd *cobra.Command, args []string) error {
 }
 
 func removeWebhookCommand(cmd *cobra.Command, repo *repository) error {
-	webhookURL, reposFilePath, dryRun, err := removeWebhookFlagCheck(cmd)
+	matcher, reposFilePath, dryRun, err := removeWebhookFlagCheck(cmd)
 	if err != nil {
 		return fmt.Errorf("%w", err)
 	}
 
-	repositoryList, err := repo.reader.read(reposFilePath)
+	repositoryList, err := webhookTargets(cmd, repo, reposFilePath)
 	if err != nil {
-		return fmt.Errorf("%w", err)
+		return err
 	}
 
 	if dryRun {
 		log.Printf("This is a dry run, the run would process %d repositories", len(repositoryList))
-
-		return nil
 	}
 
 	ctx := context.Background()
+	removed := 0
 
 	for _, repositoryName := range repositoryList {
-		webhookID := getWebhookID(ctx, webhookURL, repositoryName)
-		if webhookID > 0 {
-			log.Printf("Removing %s for repo %s id is %d", webhookURL, repositoryName, webhookID)
+		webhooks, err := getMatchingWebhooks(ctx, matcher, repositoryName)
+		if err != nil {
+			log.Printf("Error (%s): %v", webhooksTarget(repositoryName), err)
+
+			continue
+		}
+
+		if len(webhooks) == 0 {
+			log.Printf("No webhook for %s found in %s", matcher.description, webhooksTarget(repositoryName))
+
+			continue
+		}
 
-			if err = removeWebhook(ctx, webhookID, repositoryName); err != nil {
+		for _, webhook := range webhooks {
+			if dryRun {
+				log.Printf("Would remove %s for %s id is %d", webhook.Config.URL, webhooksTarget(repositoryName), webhook.ID)
+
+				removed++
+
+				continue
+			}
+
+			log.Printf("Removing %s for %s id is %d", webhook.Config.URL, webhooksTarget(repositoryName), webhook.ID)
+
+			if err = removeWebhook(ctx, webhook.ID, repositoryName); err != nil {
 				return fmt.Errorf("%w", err)
 			}
+
+			removed++
 		}
 	}
 
+	log.Printf("Webhooks matching %s: %d removed", matcher.description, removed)
+
 	return nil
 }
 
 func removeWebhook(ctx context.Context, webhookID int, repositoryName string) error {
 	client := restclient.NewClient(
-		fmt.Sprintf("/repos/%s/%s/hooks/%d", config.Org, repositoryName, webhookID),
+		fmt.Sprintf("%s/%d", webhooksPath(repositoryName), webhookID),
 		config.Token,
 		http.MethodDelete,
 	)
@@ -89,48 +115,21 @@ func removeWebhook(ctx context.Context, webhookID int, repositoryName string) er
 	return nil
 }
 
-func getWebhookID(ctx context.Context, host, repositoryName string) (webhookID int) {
-	// Get webhooks and find ID if they match the host
-	client := restclient.NewClient(
-		fmt.Sprintf("/repos/%s/%s/hooks", config.Org, repositoryName),
-		config.Token,
-		http.MethodGet,
-	)
-
-	response := []WebhookResponse{}
-	if err := client.Run(ctx, &response); err == nil {
-		for _, webhook := range response {
-			if webhook.Config.URL == host {
-				return webhook.ID
-			}
-		}
-	}
-
-	log.Printf("No webhook for %s found in %s", host, repositoryName)
-
-	return webhookID
-}
-
-func removeWebhookFlagCheck(cmd *cobra.Command) (webhookURL, reposFilePath string, dryRun bool, err error) {
+func removeWebhookFlagCheck(cmd *cobra.Command) (matcher webhookMatcher, reposFilePath string, dryRun bool, err error) {
 	dryRun, err = cmd.Flags().GetBool("dry-run")
 	if err != nil {
-		return webhookURL, reposFilePath, dryRun, fmt.Errorf("%w", err)
-	}
-
-	webhookURL, err = cmd.Flags().GetString("url")
-	if err != nil {
-		return webhookURL, reposFilePath, dryRun, fmt.Errorf("%w", err)
+		return matcher, reposFilePath, dryRun, fmt.Errorf("%w", err)
 	}
 
-	_, err = url.ParseRequestURI(webhookURL)
+	matcher, err = webhookMatcherFlags(cmd)
 	if err != nil {
-		return webhookURL, reposFilePath, dryRun, fmt.Errorf("%w", err)
+		return matcher, reposFilePath, dryRun, err
 	}
 
 	reposFilePath, err = cmd.Flags().GetString("repos")
 	if err != nil {
-		return webhookURL, reposFilePath, dryRun, fmt.Errorf("%w", err)
+		return matcher, reposFilePath, dryRun, fmt.Errorf("%w", err)
 	}
 
-	return webhookURL, reposFilePath, dryRun, nil
+	return matcher, reposFilePath, dryRun, nil
 }
@@ -9,6 +9,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+func webhookRemoveTestFlags(cmd *cobra.Command) {
+	webhookTargetTestFlags(cmd)
+
+	for _, flag := range webhookMatchFlags {
+		cmd.Flags().String(flag, "", flag+" flag")
+	}
+}
+
 func Test_removeWebhook(t *testing.T) {
 	originalConfig := config
 
@@ -33,6 +41,7 @@ func Test_removeWebhook(t *testing.T) {
 	tests := []struct {
 		name               string
 		args               args
+		mockHTTPURL        string
 		mockHTTPStatusCode int
 		wantErr            bool
 	}{
@@ -43,6 +52,7 @@ func Test_removeWebhook(t *testing.T) {
 				webhookID:      12456789,
 				repositoryName: "some-repo-name",
 			},
+			mockHTTPURL:        "/repos/some-org/some-repo-name/hooks/12456789",
 			mockHTTPStatusCode: 404,
 			wantErr:            true,
 		},
@@ -53,92 +63,30 @@ func Test_removeWebhook(t *testing.T) {
 				webhookID:      12456789,
 				repositoryName: "some-repo-name",
 			},
+			mockHTTPURL:        "/repos/some-org/some-repo-name/hooks/12456789",
 			mockHTTPStatusCode: 204,
 			wantErr:            false,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockHTTPResponder(
-				"DELETE",
-				fmt.Sprintf("/repos/some-org/%s/hooks/%d", tt.args.repositoryName, tt.args.webhookID),
-				"testdata/mockEmptyResponse.json",
-				tt.mockHTTPStatusCode,
-			)
-
-			if err := removeWebhook(tt.args.ctx, tt.args.webhookID, tt.args.repositoryName); (err != nil) != tt.wantErr {
-				t.Errorf("removeWebhook() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-func Test_getWebhookID(t *testing.T) {
-	originalConfig := config
-
-	httpmock.Activate()
-
-	defer func() {
-		httpmock.DeactivateAndReset()
-
-		config = originalConfig
-	}()
-
-	config.Org = MockOrgName
-
-	ctx := context.Background()
-
-	type args struct {
-		ctx            context.Context
-		webhookURL     string
-		repositoryName string
-	}
-
-	tests := []struct {
-		name                 string
-		args                 args
-		mockHTTPResponseFile string
-		mockHTTPStatusCode   int
-		wantWebhookID        int
-	}{
-		{
-			name: "getWebhookID not found",
-			args: args{
-				ctx:            ctx,
-				webhookURL:     "https://some-webhook-host",
-				repositoryName: "some-repo-name",
-			},
-			mockHTTPResponseFile: "testdata/blank.json",
-			mockHTTPStatusCode:   404,
-		},
 		{
-			name: "getWebhookID found",
+			name: "removeWebhook organisation success",
 			args: args{
-				ctx:            ctx,
-				webhookURL:     "https://some-external-webhook.org",
-				repositoryName: "some-repo-name2",
+				ctx:       ctx,
+				webhookID: 12456789,
 			},
-			mockHTTPResponseFile: "testdata/mockGetWebhooksResponse.json",
-			mockHTTPStatusCode:   200,
-			wantWebhookID:        789,
+			mockHTTPURL:        "https://api.github.com/orgs/some-org/hooks/12456789",
+			mockHTTPStatusCode: 204,
+			wantErr:            false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockHTTPResponder(
-				"GET",
-				fmt.Sprintf("/repos/some-org/%s/hooks", tt.args.repositoryName),
-				tt.mockHTTPResponseFile,
-				tt.mockHTTPStatusCode,
-			)
-			if gotWebhookID := getWebhookID(
-				tt.args.ctx,
-				tt.args.webhookURL,
-				tt.args.repositoryName,
-			); gotWebhookID != tt.wantWebhookID {
-				t.Errorf("getWebhookID() = %v, want %v", gotWebhookID, tt.wantWebhookID)
+			httpmock.Reset()
+
+			mockHTTPResponder("DELETE", tt.mockHTTPURL, "testdata/mockEmptyResponse.json", tt.mockHTTPStatusCode)
+
+			if err := removeWebhook(tt.args.ctx, tt.args.webhookID, tt.args.repositoryName); (err != nil) != tt.wantErr {
+				t.Errorf("removeWebhook() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -154,23 +102,25 @@ func Test_removeWebhookFlagCheck(t *testing.T) {
 	cmdNoWebhookURLFlags := &cobra.Command{Use: "webhook-remove"}
 	cmdNoWebhookURLFlags.Flags().BoolP("dry-run", "d", false, "dry run flag")
 
-	cmdInvalidWebhookURLFlags := &cobra.Command{Use: "webhook-remove"}
-	cmdInvalidWebhookURLFlags.Flags().BoolP("dry-run", "d", false, "dry run flag")
-	cmdInvalidWebhookURLFlags.Flags().StringP("url", "", "http//invalid-host", "url flag")
+	cmdNoReposFlags := mockFlagsCmd(func(cmd *cobra.Command) {
+		cmd.Flags().BoolP("dry-run", "d", false, "dry run flag")
 
-	cmdNoReposFlags := &cobra.Command{Use: "webhook-remove"}
-	cmdNoReposFlags.Flags().BoolP("dry-run", "d", false, "dry run flag")
-	cmdNoReposFlags.Flags().StringP("url", "", "https://valid-host", "url flag")
+		for _, flag := range webhookMatchFlags {
+			cmd.Flags().String(flag, "", flag+" flag")
+		}
+	}, "url", "https://valid-host")
 
-	cmdValidFlags := &cobra.Command{Use: "webhook-remove"}
-	cmdValidFlags.Flags().BoolP("dry-run", "d", false, "dry run flag")
-	cmdValidFlags.Flags().StringP("url", "", "https://valid-host", "url flag")
-	cmdValidFlags.Flags().StringP("repos", "", "filepath", "repos flag")
+	cmdTwoMatchFlags := mockFlagsCmd(
+		webhookRemoveTestFlags,
+		"repos", "filepath",
+		"url", "https://valid-host",
+		"host", "valid-host",
+	)
 
 	tests := []struct {
 		name              string
 		args              args
-		wantWebhookURL    string
+		wantMatcher       string
 		wantReposFilePath string
 		wantDryRun        bool
 		wantErr           bool
@@ -192,25 +142,37 @@ func Test_removeWebhookFlagCheck(t *testing.T) {
 		{
 			name: "removeWebhookFlagCheck fails invalid url",
 			args: args{
-				cmd: cmdInvalidWebhookURLFlags,
+				cmd: mockFlagsCmd(webhookRemoveTestFlags, "repos", "filepath", "url", "http//invalid-host"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "removeWebhookFlagCheck fails no match flag",
+			args: args{
+				cmd: mockFlagsCmd(webhookRemoveTestFlags, "repos", "filepath"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "removeWebhookFlagCheck fails two match flags",
+			args: args{
+				cmd: cmdTwoMatchFlags,
 			},
-			wantWebhookURL: "http//invalid-host",
-			wantErr:        true,
+			wantErr: true,
 		},
 		{
 			name: "removeWebhookFlagCheck fails no repos",
 			args: args{
 				cmd: cmdNoReposFlags,
 			},
-			wantWebhookURL: "https://valid-host",
-			wantErr:        true,
+			wantErr: true,
 		},
 		{
-			name: "removeWebhookFlagCheck fails no repos",
+			name: "removeWebhookFlagCheck success",
 			args: args{
-				cmd: cmdValidFlags,
+				cmd: mockFlagsCmd(webhookRemoveTestFlags, "repos", "filepath", "host", "valid-host"),
 			},
-			wantWebhookURL:    "https://valid-host",
+			wantMatcher:       "host valid-host",
 			wantDryRun:        false,
 			wantReposFilePath: "filepath",
 			wantErr:           false,
@@ -218,14 +180,17 @@ func Test_removeWebhookFlagCheck(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotWebhookURL, gotReposFilePath, gotDryRun, err := removeWebhookFlagCheck(tt.args.cmd)
+			gotMatcher, gotReposFilePath, gotDryRun, err := removeWebhookFlagCheck(tt.args.cmd)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("removeWebhookFlagCheck() error = %v, wantErr %v", err, tt.wantErr)
 
 				return
 			}
-			if gotWebhookURL != tt.wantWebhookURL {
-				t.Errorf("removeWebhookFlagCheck() gotWebhookURL = %v, want %v", gotWebhookURL, tt.wantWebhookURL)
+			if tt.wantErr {
+				return
+			}
+			if gotMatcher.description != tt.wantMatcher {
+				t.Errorf("removeWebhookFlagCheck() gotMatcher = %v, want %v", gotMatcher.description, tt.wantMatcher)
 			}
 			if gotReposFilePath != tt.wantReposFilePath {
 				t.Errorf("removeWebhookFlagCheck() gotReposFilePath = %v, want %v", gotReposFilePath, tt.wantReposFilePath)
@@ -257,21 +222,53 @@ func Test_removeWebhookCommand(t *testing.T) {
 
 	cmdInvalidFlags := &cobra.Command{Use: "webhook-remove"}
 
-	cmdDryRunOnFlags := &cobra.Command{Use: "webhook-remove"}
-	cmdDryRunOnFlags.Flags().BoolP("dry-run", "d", true, "dry run flag")
-	cmdDryRunOnFlags.Flags().StringP("url", "", "https://some-external-webhook.com", "url flag")
-	cmdDryRunOnFlags.Flags().StringP("repos", "", "filepath", "repos flag")
-
-	cmdDryRunOffFlags := &cobra.Command{Use: "webhook-remove"}
-	cmdDryRunOffFlags.Flags().BoolP("dry-run", "d", false, "dry run flag")
-	cmdDryRunOffFlags.Flags().StringP("url", "", "https://some-external-webhook.com", "url flag")
-	cmdDryRunOffFlags.Flags().StringP("repos", "", "filepath", "repos flag")
+	cmdDryRunOnFlags := mockFlagsCmd(
+		webhookRemoveTestFlags,
+		"dry-run", "true",
+		"repos", "filepath",
+		"url", "https://some-external-webhook.com",
+	)
+	cmdDryRunOffFlags := mockFlagsCmd(
+		webhookRemoveTestFlags,
+		"repos", "filepath",
+		"url", "https://some-external-webhook.com",
+	)
+	cmdHostFlags := mockFlagsCmd(webhookRemoveTestFlags, "repos", "filepath", "host", "some-external-webhook.net")
+	cmdRegexFlags := mockFlagsCmd(
+		webhookRemoveTestFlags,
+		"repos", "filepath",
+		"regex", `^https://some-external-webhook\.`,
+	)
+	cmdNoTargetFlags := mockFlagsCmd(webhookRemoveTestFlags, "url", "https://some-external-webhook.com")
+	cmdOrgAndReposFlags := mockFlagsCmd(
+		webhookRemoveTestFlags,
+		"repos", "filepath",
+		"org", "true",
+		"url", "https://some-external-webhook.com",
+	)
+	cmdOrgDryRunOnFlags := mockFlagsCmd(
+		webhookRemoveTestFlags,
+		"dry-run", "true",
+		"org", "true",
+		"url", "https://some-external-webhook.com",
+	)
+	cmdOrgDryRunOffFlags := mockFlagsCmd(
+		webhookRemoveTestFlags,
+		"org", "true",
+		"url", "https://some-external-webhook.com",
+	)
+	cmdAllReposFlags := mockFlagsCmd(
+		webhookRemoveTestFlags,
+		"all-repos", "true",
+		"glob", "https://some-external-webhook.*",
+	)
 
 	tests := []struct {
-		name         string
-		args         args
-		mockHTTPFunc func()
-		wantErr      bool
+		name            string
+		args            args
+		mockHTTPFunc    func()
+		wantDeleteCalls map[string]int
+		wantErr         bool
 	}{
 		{
 			name: "removeWebhookCommand flag check failure",
@@ -301,13 +298,21 @@ func Test_removeWebhookCommand(t *testing.T) {
 				repo: &repository{
 					reader: &mockRepositoryReader{
 						returnValue: []string{
-							"some-repo-name",
+							"some-repo",
 						},
 					},
 				},
 			},
-			mockHTTPFunc: func() {},
-			wantErr:      false,
+			mockHTTPFunc: func() {
+				mockHTTPResponder(
+					"GET",
+					"https://api.github.com/repos/some-org/some-repo/hooks",
+					"testdata/mockGetWebhooksResponse.json",
+					200,
+				)
+			},
+			wantDeleteCalls: map[string]int{"DELETE https://api.github.com/repos/some-org/some-repo/hooks/123": 0},
+			wantErr:         false,
 		},
 		{
 			name: "removeWebhookCommand remove webhook error",
@@ -324,19 +329,34 @@ func Test_removeWebhookCommand(t *testing.T) {
 			mockHTTPFunc: func() {
 				mockHTTPResponder(
 					"GET",
-					"/repos/some-org/some-repo/hooks",
+					"https://api.github.com/repos/some-org/some-repo/hooks",
 					"testdata/mockGetWebhooksResponse.json",
 					200,
 				)
 				mockHTTPResponder(
 					"DELETE",
-					"/repos/some-org/some-repo/hooks/123",
+					"https://api.github.com/repos/some-org/some-repo/hooks/123",
 					"testdata/mockDeleteWebhookResponse404.json",
 					404,
 				)
 			},
 			wantErr: true,
 		},
+		{
+			name: "removeWebhookCommand list webhooks error continues",
+			args: args{
+				cmd: cmdDryRunOffFlags,
+				repo: &repository{
+					reader: &mockRepositoryReader{
+						returnValue: []string{"some-repo"},
+					},
+				},
+			},
+			mockHTTPFunc: func() {
+				mockHTTPResponder("GET", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockRest404Response.json", 404)
+			},
+			wantErr: false,
+		},
 		{
 			name: "removeWebhookCommand success with empty repo",
 			args: args{
@@ -356,23 +376,205 @@ func Test_removeWebhookCommand(t *testing.T) {
 				cmd: cmdDryRunOffFlags,
 				repo: &repository{
 					reader: &mockRepositoryReader{
-						returnValue: []string{"some-repo-name"},
+						returnValue: []string{"some-repo"},
 					},
 				},
 			},
 			mockHTTPFunc: func() {
 				mockHTTPResponder(
 					"GET",
-					"/repos/some-org/some-repo/hooks",
+					"https://api.github.com/repos/some-org/some-repo/hooks",
 					"testdata/mockGetWebhooksResponse.json",
 					200,
 				)
 				mockHTTPResponder(
 					"DELETE",
-					"/repos/some-org/some-repo/hooks/123",
-					"testdata/blank.json",
+					"https://api.github.com/repos/some-org/some-repo/hooks/123",
+					"testdata/mockRest20xEmptyResponse.json",
+					204,
+				)
+			},
+			wantDeleteCalls: map[string]int{"DELETE https://api.github.com/repos/some-org/some-repo/hooks/123": 1},
+			wantErr:         false,
+		},
+		{
+			name: "removeWebhookCommand host removes only the matching webhook",
+			args: args{
+				cmd: cmdHostFlags,
+				repo: &repository{
+					reader: &mockRepositoryReader{
+						returnValue: []string{"some-repo"},
+					},
+				},
+			},
+			mockHTTPFunc: func() {
+				mockHTTPResponder("GET", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockGetWebhooksResponse.json", 200)
+
+				for _, id := range []int{123, 456, 789} {
+					mockHTTPResponder(
+						"DELETE",
+						fmt.Sprintf("https://api.github.com/repos/some-org/some-repo/hooks/%d", id),
+						"testdata/mockRest20xEmptyResponse.json",
+						204,
+					)
+				}
+			},
+			wantDeleteCalls: map[string]int{
+				"DELETE https://api.github.com/repos/some-org/some-repo/hooks/123": 0,
+				"DELETE https://api.github.com/repos/some-org/some-repo/hooks/456": 1,
+				"DELETE https://api.github.com/repos/some-org/some-repo/hooks/789": 0,
+			},
+			wantErr: false,
+		},
+		{
+			name: "removeWebhookCommand regex removes every matching webhook",
+			args: args{
+				cmd: cmdRegexFlags,
+				repo: &repository{
+					reader: &mockRepositoryReader{
+						returnValue: []string{"some-repo"},
+					},
+				},
+			},
+			mockHTTPFunc: func() {
+				mockHTTPResponder("GET", "https://api.github.com/repos/some-org/some-repo/hooks", "testdata/mockGetWebhooksResponse.json", 200)
+
+				for _, id := range []int{123, 456, 789} {
+					mockHTTPResponder(
+						"DELETE",
+						fmt.Sprintf("https://api.github.com/repos/some-org/some-repo/hooks/%d", id),
+						"testdata/mockRest20xEmptyResponse.json",
+						204,
+					)
+				}
+			},
+			wantDeleteCalls: map[string]int{
+				"DELETE https://api.github.com/repos/some-org/some-repo/hooks/123": 1,
+				"DELETE https://api.github.com/repos/some-org/some-repo/hooks/456": 1,
+				"DELETE https://api.github.com/repos/some-org/some-repo/hooks/789": 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "removeWebhookCommand fails without repos, all-repos or org",
+			args: args{
+				cmd: cmdNoTargetFlags,
+			},
+			mockHTTPFunc: func() {},
+			wantErr:      true,
+		},
+		{
+			name: "removeWebhookCommand fails with repos and org",
+			args: args{
+				cmd: cmdOrgAndReposFlags,
+			},
+			mockHTTPFunc: func() {},
+			wantErr:      true,
+		},
+		{
+			name: "removeWebhookCommand org dry run success",
+			args: args{
+				cmd: cmdOrgDryRunOnFlags,
+			},
+			mockHTTPFunc: func() {
+				mockHTTPResponder(
+					"GET",
+					"https://api.github.com/orgs/some-org/hooks",
+					"testdata/mockGetWebhooksResponse.json",
+					200,
+				)
+			},
+			wantDeleteCalls: map[string]int{"DELETE https://api.github.com/orgs/some-org/hooks/123": 0},
+			wantErr:         false,
+		},
+		{
+			name: "removeWebhookCommand org remove webhook error",
+			args: args{
+				cmd: cmdOrgDryRunOffFlags,
+			},
+			mockHTTPFunc: func() {
+				mockHTTPResponder(
+					"GET",
+					"https://api.github.com/orgs/some-org/hooks",
+					"testdata/mockGetWebhooksResponse.json",
+					200,
+				)
+				mockHTTPResponder(
+					"DELETE",
+					"https://api.github.com/orgs/some-org/hooks/123",
+					"testdata/mockDeleteWebhookResponse404.json",
+					404,
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "removeWebhookCommand org success with remove",
+			args: args{
+				cmd: cmdOrgDryRunOffFlags,
+			},
+			mockHTTPFunc: func() {
+				mockHTTPResponder(
+					"GET",
+					"https://api.github.com/orgs/some-org/hooks",
+					"testdata/mockGetWebhooksResponse.json",
 					200,
 				)
+				mockHTTPResponder(
+					"DELETE",
+					"https://api.github.com/orgs/some-org/hooks/123",
+					"testdata/mockRest20xEmptyResponse.json",
+					204,
+				)
+			},
+			wantDeleteCalls: map[string]int{"DELETE https://api.github.com/orgs/some-org/hooks/123": 1},
+			wantErr:         false,
+		},
+		{
+			name: "removeWebhookCommand all repos fails to list repositories",
+			args: args{
+				cmd: cmdAllReposFlags,
+			},
+			mockHTTPFunc: func() {
+				mockHTTPResponder("POST", "https://api.github.com/graphql", "testdata/mockEmptyResponse.json", 401)
+			},
+			wantErr: true,
+		},
+		{
+			name: "removeWebhookCommand all repos success with remove",
+			args: args{
+				cmd: cmdAllReposFlags,
+			},
+			mockHTTPFunc: func() {
+				mockHTTPResponder(
+					"POST",
+					"https://api.github.com/graphql",
+					"testdata/mockGraphqlWebhookRepoResponse.json",
+					200,
+				)
+
+				for _, repositoryName := range []string{"repo1", "repo2"} {
+					mockHTTPResponder(
+						"GET",
+						fmt.Sprintf("https://api.github.com/repos/some-org/%s/hooks", repositoryName),
+						"testdata/mockGetWebhooksResponse.json",
+						200,
+					)
+
+					for _, id := range []int{123, 456, 789} {
+						mockHTTPResponder(
+							"DELETE",
+							fmt.Sprintf("https://api.github.com/repos/some-org/%s/hooks/%d", repositoryName, id),
+							"testdata/mockRest20xEmptyResponse.json",
+							204,
+						)
+					}
+				}
+			},
+			wantDeleteCalls: map[string]int{
+				"DELETE https://api.github.com/repos/some-org/repo1/hooks/123": 1,
+				"DELETE https://api.github.com/repos/some-org/repo1/hooks/789": 1,
+				"DELETE https://api.github.com/repos/some-org/repo2/hooks/456": 1,
 			},
 			wantErr: false,
 		},
@@ -380,25 +582,31 @@ func Test_removeWebhookCommand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
 			tt.mockHTTPFunc()
 			if err := removeWebhookCommand(tt.args.cmd, tt.args.repo); (err != nil) != tt.wantErr {
 				t.Errorf("removeWebhookCommand() error = %v, wantErr %v", err, tt.wantErr)
 			}
+
+			calls := httpmock.GetCallCountInfo()
+			for call, want := range tt.wantDeleteCalls {
+				if calls[call] != want {
+					t.Errorf("removeWebhookCommand() %s calls = %d, want %d", call, calls[call], want)
+				}
+			}
 		})
 	}
 }
 
 func Test_webhookRemoveRun(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
 	type args struct {
 		cmd  *cobra.Command
 		args []string
 	}
 
-	cmdDryRunOnFlags := &cobra.Command{Use: "webhook-remove"}
-	cmdDryRunOnFlags.Flags().BoolP("dry-run", "d", true, "dry run flag")
-	cmdDryRunOnFlags.Flags().StringP("url", "", "https://some-external-webhook.com", "url flag")
-	cmdDryRunOnFlags.Flags().StringP("repos", "", "testdata/one_repo_list.txt", "repos flag")
-
 	tests := []struct {
 		name    string
 		args    args
@@ -407,7 +615,12 @@ func Test_webhookRemoveRun(t *testing.T) {
 		{
 			name: "webhookRemoveRun success",
 			args: args{
-				cmd: cmdDryRunOnFlags,
+				cmd: mockFlagsCmd(
+					webhookRemoveTestFlags,
+					"dry-run", "true",
+					"repos", "testdata/one_repo_list.txt",
+					"url", "https://some-external-webhook.com",
+				),
 			},
 			wantErr: false,
 		},
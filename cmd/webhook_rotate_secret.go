@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errWebhookURLMatch       = errors.New("url-match is required")
+	errWebhookSecretRequired = errors.New("set one of secret-env or secret-file")
+	errWebhookLimit          = errors.New("limit cannot be negative")
+	errWebhookRotationRecord = errors.New("rotation record is not a webhook-rotate-secret file")
+	errWebhookRotationPath   = errors.New("file-path is required")
+	webhookRotateSecretCmd   = &cobra.Command{ // nolint // needed for cobra
+		Use:   "webhook-rotate-secret",
+		Short: "Rotate the secret of webhooks matching a url for repos in provided list, all repos or the organisation",
+		Long: `Rotate the secret of webhooks matching a url for repos in provided list, all repos or the organisation.
+Rotated webhooks are recorded in $file-path with a fingerprint of the secret, webhooks already in the file with
+the same fingerprint are skipped so a rollout can be staged with --limit and run again with the same file-path
+to carry on.`,
+		RunE: webhookRotateSecretRun,
+	}
+)
+
+// webhookRotationHeader is the header of the rotation record, the webhook ID and secret fingerprint columns are
+// read back on the next run.
+var webhookRotationHeader = []string{ // nolint // expected global
+	"Repo Name", "Scope", "Webhook ID", "Webhook URL", "Secret Fingerprint", "Rotated At",
+}
+
+// webhookRotation holds the state of a run, rotatedIDs are the webhooks already rotated to the same secret.
+type webhookRotation struct {
+	secret         string
+	fingerprint    string
+	limit          int
+	dryRun         bool
+	rotatedIDs     map[int]bool
+	record         *csv.Writer
+	rotated        int
+	alreadyRotated int
+	failed         int
+}
+
+// nolint // needed for cobra
+func init() {
+	webhookRotateSecretCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	webhookRotateSecretCmd.Flags().Bool("all-repos", false, "rotate webhook secrets in every repository in the organisation that is not archived")
+	webhookRotateSecretCmd.Flags().Bool("org", false, "rotate organisation webhook secrets instead of repository webhook secrets")
+	webhookRotateSecretCmd.Flags().String("url-match", "", "url of the webhooks to rotate, * matches any characters, e.g. https://*.example.com/*")
+	webhookRotateSecretCmd.Flags().String("secret-env", "", "name of the environment variable containing the new webhook secret")
+	webhookRotateSecretCmd.Flags().String("secret-file", "", "path to file containing the new webhook secret")
+	webhookRotateSecretCmd.Flags().Int("limit", 0, "maximum number of webhooks to rotate in this run, 0 rotates every matching webhook")
+	webhookRotateSecretCmd.Flags().StringP("file-path", "f", "", "file path for the record of rotated webhooks, use the same file to carry on a rollout")
+	webhookRotateSecretCmd.MarkFlagRequired("url-match")
+	webhookRotateSecretCmd.MarkFlagRequired("file-path")
+	webhookRotateSecretCmd.Flags().SortFlags = false
+	rootCmd.AddCommand(webhookRotateSecretCmd)
+}
+
+func webhookRotateSecretRun(cmd *cobra.Command, args []string) error {
+	err := webhookRotateSecretCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+	)
+
+	return err
+}
+
+func webhookRotateSecretCommand(cmd *cobra.Command, repo *repository) error {
+	dryRun, reposFilePath, err := branchProtectionFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	matcher, rotation, recordPath, err := webhookRotateSecretFlagCheck(cmd)
+	if err != nil {
+		return err
+	}
+
+	rotation.dryRun = dryRun
+
+	repositoryList, err := webhookTargets(cmd, repo, reposFilePath)
+	if err != nil {
+		return err
+	}
+
+	rotation.rotatedIDs, err = webhookRotationRecordRead(recordPath, rotation.fingerprint)
+	if err != nil {
+		return err
+	}
+
+	log.SetFlags(0)
+
+	if dryRun {
+		log.Printf("This is a dry run, the run would process %d repositories", len(repositoryList))
+	} else {
+		file, err := webhookRotationRecordOpen(recordPath)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		rotation.record = csv.NewWriter(file)
+	}
+
+	ctx := context.Background()
+
+	for _, repositoryName := range repositoryList {
+		if rotation.limitReached() {
+			break
+		}
+
+		webhooks, err := getMatchingWebhooks(ctx, matcher, repositoryName)
+		if err != nil {
+			log.Printf("Error (%s): %v", webhooksTarget(repositoryName), err)
+
+			continue
+		}
+
+		if len(webhooks) == 0 {
+			log.Printf("No webhook for %s found in %s", matcher.description, webhooksTarget(repositoryName))
+
+			continue
+		}
+
+		if err := rotation.rotateRepository(ctx, repositoryName, webhooks); err != nil {
+			return err
+		}
+	}
+
+	log.Printf(
+		"Webhooks matching %s: %d rotated, %d already rotated, %d failed",
+		matcher.description, rotation.rotated, rotation.alreadyRotated, rotation.failed,
+	)
+
+	if rotation.limitReached() {
+		log.Printf("Limit of %d reached, run again with the same file-path to rotate any remaining webhooks", rotation.limit)
+	}
+
+	return nil
+}
+
+func (r *webhookRotation) limitReached() bool {
+	return r.limit > 0 && r.rotated >= r.limit
+}
+
+// rotateRepository sets the secret of the webhooks not already rotated until the limit is reached. Each rotation
+// is recorded as soon as it succeeds so an interrupted run can carry on, a failed rotation is logged and counted
+// but a failure to record stops the run.
+func (r *webhookRotation) rotateRepository(
+	ctx context.Context,
+	repositoryName string,
+	webhooks []WebhookResponse,
+) error {
+	for _, webhook := range webhooks {
+		if r.rotatedIDs[webhook.ID] {
+			log.Printf("Already rotated %s for %s id is %d", webhook.Config.URL, webhooksTarget(repositoryName), webhook.ID)
+
+			r.alreadyRotated++
+
+			continue
+		}
+
+		if r.limitReached() {
+			return nil
+		}
+
+		if r.dryRun {
+			log.Printf("Would rotate %s for %s id is %d", webhook.Config.URL, webhooksTarget(repositoryName), webhook.ID)
+
+			r.rotated++
+
+			continue
+		}
+
+		if err := setWebhookConfig(ctx, repositoryName, webhook.ID, WebhookConfigUpdate{Secret: r.secret}); err != nil {
+			log.Printf("Error (%s): %v", webhooksTarget(repositoryName), err)
+
+			r.failed++
+
+			continue
+		}
+
+		log.Printf("Rotated %s for %s id is %d", webhook.Config.URL, webhooksTarget(repositoryName), webhook.ID)
+
+		name, scope := repositoryName, webhookScopeRepo
+		if repositoryName == "" {
+			name, scope = config.Org, webhookScopeOrg
+		}
+
+		r.record.Write([]string{ // nolint // error is checked after the flush
+			name,
+			scope,
+			strconv.Itoa(webhook.ID),
+			webhook.Config.URL,
+			r.fingerprint,
+			time.Now().UTC().Format(time.RFC3339),
+		})
+		r.record.Flush()
+
+		if err := r.record.Error(); err != nil {
+			return fmt.Errorf("could not record rotation of webhook %d: %w", webhook.ID, err)
+		}
+
+		r.rotatedIDs[webhook.ID] = true
+		r.rotated++
+	}
+
+	return nil
+}
+
+func webhookRotateSecretFlagCheck(cmd *cobra.Command) (webhookMatcher, *webhookRotation, string, error) {
+	rotation := &webhookRotation{}
+
+	urlMatch, err := cmd.Flags().GetString("url-match")
+	if err != nil {
+		return webhookMatcher{}, rotation, "", fmt.Errorf("%w", err)
+	}
+
+	if urlMatch == "" {
+		return webhookMatcher{}, rotation, "", errWebhookURLMatch
+	}
+
+	// A url without * is matched exactly by the glob
+	matcher, err := newWebhookMatcher("glob", urlMatch)
+	if err != nil {
+		return matcher, rotation, "", err
+	}
+
+	matcher.description = fmt.Sprintf("url-match %s", urlMatch)
+
+	rotation.secret, err = webhookSecretFlags(cmd)
+	if err != nil {
+		return matcher, rotation, "", err
+	}
+
+	if rotation.secret == "" {
+		return matcher, rotation, "", errWebhookSecretRequired
+	}
+
+	rotation.fingerprint = webhookSecretFingerprint(rotation.secret)
+
+	rotation.limit, err = cmd.Flags().GetInt("limit")
+	if err != nil {
+		return matcher, rotation, "", fmt.Errorf("%w", err)
+	}
+
+	if rotation.limit < 0 {
+		return matcher, rotation, "", errWebhookLimit
+	}
+
+	recordPath, err := cmd.Flags().GetString("file-path")
+	if err != nil {
+		return matcher, rotation, "", fmt.Errorf("%w", err)
+	}
+
+	if recordPath == "" {
+		return matcher, rotation, "", errWebhookRotationPath
+	}
+
+	return matcher, rotation, recordPath, nil
+}
+
+// webhookSecretFingerprint returns the start of the SHA-256 of the secret, it tells rollouts of different secrets
+// apart in the rotation record without writing the secret itself.
+func webhookSecretFingerprint(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// webhookRotationRecordRead returns the IDs of the webhooks an earlier run rotated to the secret with fingerprint,
+// a missing file is a first run.
+func webhookRotationRecordRead(recordPath, fingerprint string) (map[int]bool, error) {
+	rotatedIDs := make(map[int]bool)
+
+	file, err := os.Open(recordPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return rotatedIDs, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read rotation record: %w", err)
+	}
+
+	defer file.Close()
+
+	lines, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not read rotation record: %w", err)
+	}
+
+	if len(lines) == 0 || len(lines[0]) != len(webhookRotationHeader) {
+		return nil, fmt.Errorf("%w: %s", errWebhookRotationRecord, recordPath)
+	}
+
+	for _, line := range lines[1:] {
+		webhookID, err := strconv.Atoi(line[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errWebhookRotationRecord, recordPath)
+		}
+
+		if line[4] == fingerprint {
+			rotatedIDs[webhookID] = true
+		}
+	}
+
+	return rotatedIDs, nil
+}
+
+// webhookRotationRecordOpen opens the rotation record for appending, the header is written when the file is new.
+func webhookRotationRecordOpen(recordPath string) (*os.File, error) {
+	file, err := os.OpenFile(recordPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open rotation record: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return nil, fmt.Errorf("could not open rotation record: %w", err)
+	}
+
+	if info.Size() == 0 {
+		writer := csv.NewWriter(file)
+		writer.Write(webhookRotationHeader) // nolint // error is checked after the flush
+		writer.Flush()
+
+		if err := writer.Error(); err != nil {
+			file.Close()
+
+			return nil, fmt.Errorf("could not write rotation record: %w", err)
+		}
+	}
+
+	return file, nil
+}
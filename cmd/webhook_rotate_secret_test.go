@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func webhookRotateSecretTestFlags(cmd *cobra.Command) {
+	webhookTargetTestFlags(cmd)
+	cmd.Flags().String("url-match", "", "url match flag")
+	cmd.Flags().String("secret-env", "", "secret env flag")
+	cmd.Flags().String("secret-file", "", "secret file flag")
+	cmd.Flags().Int("limit", 0, "limit flag")
+	cmd.Flags().String("file-path", "", "file path flag")
+}
+
+func Test_webhookRotateSecretFlagCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		cmd             *cobra.Command
+		wantMatcher     string
+		wantSecret      string
+		wantFingerprint string
+		wantLimit       int
+		wantRecordPath  string
+		wantErr         bool
+	}{
+		{
+			name:    "webhookRotateSecretFlagCheck fails no flags",
+			cmd:     &cobra.Command{Use: "webhook-rotate-secret"},
+			wantErr: true,
+		},
+		{
+			name:    "webhookRotateSecretFlagCheck fails no url match",
+			cmd:     mockFlagsCmd(webhookRotateSecretTestFlags, "secret-file", "testdata/webhook_secret.txt"),
+			wantErr: true,
+		},
+		{
+			name:    "webhookRotateSecretFlagCheck fails no secret",
+			cmd:     mockFlagsCmd(webhookRotateSecretTestFlags, "url-match", "https://*.example.com/*"),
+			wantErr: true,
+		},
+		{
+			name: "webhookRotateSecretFlagCheck fails negative limit",
+			cmd: mockFlagsCmd(
+				webhookRotateSecretTestFlags,
+				"url-match", "https://*.example.com/*", "secret-file", "testdata/webhook_secret.txt", "limit", "-1",
+			),
+			wantErr: true,
+		},
+		{
+			name: "webhookRotateSecretFlagCheck fails no file path",
+			cmd: mockFlagsCmd(
+				webhookRotateSecretTestFlags,
+				"url-match", "https://*.example.com/*",
+				"secret-file", "testdata/webhook_secret.txt",
+			),
+			wantErr: true,
+		},
+		{
+			name: "webhookRotateSecretFlagCheck success",
+			cmd: mockFlagsCmd(
+				webhookRotateSecretTestFlags,
+				"url-match", "https://*.example.com/*",
+				"secret-file", "testdata/webhook_secret.txt",
+				"limit", "10",
+				"file-path", "rotated.csv",
+			),
+			wantMatcher:     "url-match https://*.example.com/*",
+			wantSecret:      "some-secret",
+			wantFingerprint: "a6f2f3f095a1",
+			wantLimit:       10,
+			wantRecordPath:  "rotated.csv",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMatcher, gotRotation, gotRecordPath, err := webhookRotateSecretFlagCheck(tt.cmd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookRotateSecretFlagCheck() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if gotMatcher.description != tt.wantMatcher {
+				t.Errorf("webhookRotateSecretFlagCheck() gotMatcher = %v, want %v", gotMatcher.description, tt.wantMatcher)
+			}
+
+			if gotRotation.secret != tt.wantSecret {
+				t.Errorf("webhookRotateSecretFlagCheck() gotSecret = %v, want %v", gotRotation.secret, tt.wantSecret)
+			}
+
+			if gotRotation.fingerprint != tt.wantFingerprint {
+				t.Errorf(
+					"webhookRotateSecretFlagCheck() gotFingerprint = %v, want %v", gotRotation.fingerprint, tt.wantFingerprint,
+				)
+			}
+
+			if gotRotation.limit != tt.wantLimit {
+				t.Errorf("webhookRotateSecretFlagCheck() gotLimit = %v, want %v", gotRotation.limit, tt.wantLimit)
+			}
+
+			if gotRecordPath != tt.wantRecordPath {
+				t.Errorf("webhookRotateSecretFlagCheck() gotRecordPath = %v, want %v", gotRecordPath, tt.wantRecordPath)
+			}
+		})
+	}
+}
+
+func Test_webhookRotationRecordRead(t *testing.T) {
+	tests := []struct {
+		name           string
+		recordPath     string
+		fingerprint    string
+		wantRotatedIDs map[int]bool
+		wantErr        bool
+	}{
+		{
+			name:           "webhookRotationRecordRead first run",
+			recordPath:     "testdata/missing.csv",
+			fingerprint:    "a6f2f3f095a1",
+			wantRotatedIDs: map[int]bool{},
+		},
+		{
+			name:           "webhookRotationRecordRead earlier run",
+			recordPath:     "testdata/webhook_rotated.csv",
+			fingerprint:    "a6f2f3f095a1",
+			wantRotatedIDs: map[int]bool{123: true},
+		},
+		{
+			name:           "webhookRotationRecordRead earlier run of another secret",
+			recordPath:     "testdata/webhook_rotated.csv",
+			fingerprint:    "5d865deae06f",
+			wantRotatedIDs: map[int]bool{456: true},
+		},
+		{
+			name:       "webhookRotationRecordRead fails other csv",
+			recordPath: "testdata/webhook_rotated_invalid.csv",
+			wantErr:    true,
+		},
+		{
+			name:       "webhookRotationRecordRead fails invalid webhook id",
+			recordPath: "testdata/webhook_rotated_invalid_id.csv",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRotatedIDs, err := webhookRotationRecordRead(tt.recordPath, tt.fingerprint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookRotationRecordRead() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(gotRotatedIDs, tt.wantRotatedIDs) {
+				t.Errorf("webhookRotationRecordRead() gotRotatedIDs = %v, want %v", gotRotatedIDs, tt.wantRotatedIDs)
+			}
+		})
+	}
+}
+
+func Test_webhookRotateSecretCommand(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	repoHooks := func() {
+		mockHTTPResponder("GET", "https://api.github.com/repos/some-org/repo1/hooks", "testdata/mockGetWebhooksResponse.json", 200)
+		mockHTTPResponder("GET", "https://api.github.com/repos/some-org/repo2/hooks", "testdata/mockRest404Response.json", 404)
+
+		for _, id := range []int{123, 456, 789} {
+			mockHTTPResponder(
+				"PATCH",
+				fmt.Sprintf("https://api.github.com/repos/some-org/repo1/hooks/%d/config", id),
+				"testdata/mockRest20xEmptyResponse.json",
+				200,
+			)
+		}
+	}
+	repoConfigCalls := func(calls ...int) map[string]int {
+		want := make(map[string]int)
+		for i, id := range []int{123, 456, 789} {
+			want[fmt.Sprintf("PATCH https://api.github.com/repos/some-org/repo1/hooks/%d/config", id)] = calls[i]
+		}
+
+		return want
+	}
+	repos := &repository{
+		reader: &mockRepositoryReader{returnValue: []string{"repo1", "repo2"}},
+	}
+	rotateFlags := []string{"repos", "filepath", "url-match", "https://some-external-webhook.*", "secret-file", "testdata/webhook_secret.txt"}
+
+	tests := []struct {
+		name         string
+		flags        []string
+		cmd          *cobra.Command
+		repo         *repository
+		recordFile   string
+		mockHTTPFunc func()
+		wantCalls    map[string]int
+		wantRecorded []string
+		wantErr      bool
+	}{
+		{
+			name:         "webhookRotateSecretCommand flag check failure",
+			cmd:          &cobra.Command{Use: "webhook-rotate-secret"},
+			mockHTTPFunc: func() {},
+			wantErr:      true,
+		},
+		{
+			name:         "webhookRotateSecretCommand no secret failure",
+			flags:        []string{"repos", "filepath", "url-match", "https://some-external-webhook.*"},
+			mockHTTPFunc: func() {},
+			wantErr:      true,
+		},
+		{
+			name:         "webhookRotateSecretCommand no target failure",
+			flags:        []string{"url-match", "https://some-external-webhook.*", "secret-file", "testdata/webhook_secret.txt"},
+			mockHTTPFunc: func() {},
+			wantErr:      true,
+		},
+		{
+			name:         "webhookRotateSecretCommand rotation record failure",
+			flags:        rotateFlags,
+			repo:         repos,
+			recordFile:   "testdata/webhook_rotated_invalid.csv",
+			mockHTTPFunc: repoHooks,
+			wantCalls:    repoConfigCalls(0, 0, 0),
+			wantErr:      true,
+		},
+		{
+			name:         "webhookRotateSecretCommand dry run",
+			flags:        append([]string{"dry-run", "true"}, rotateFlags...),
+			repo:         repos,
+			mockHTTPFunc: repoHooks,
+			wantCalls:    repoConfigCalls(0, 0, 0),
+		},
+		{
+			name:         "webhookRotateSecretCommand rotates every matching webhook",
+			flags:        rotateFlags,
+			repo:         repos,
+			mockHTTPFunc: repoHooks,
+			wantCalls:    repoConfigCalls(1, 1, 1),
+			wantRecorded: []string{"123", "456", "789"},
+		},
+		{
+			name:         "webhookRotateSecretCommand stops at the limit",
+			flags:        append([]string{"limit", "2"}, rotateFlags...),
+			repo:         repos,
+			mockHTTPFunc: repoHooks,
+			wantCalls:    repoConfigCalls(1, 1, 0),
+			wantRecorded: []string{"123", "456"},
+		},
+		{
+			name:         "webhookRotateSecretCommand skips webhooks rotated by an earlier run",
+			flags:        append([]string{"limit", "1"}, rotateFlags...),
+			repo:         repos,
+			recordFile:   "testdata/webhook_rotated.csv",
+			mockHTTPFunc: repoHooks,
+			wantCalls:    repoConfigCalls(0, 1, 0),
+			wantRecorded: []string{"123", "456", "456"},
+		},
+		{
+			name:         "webhookRotateSecretCommand rotates webhooks an earlier run rotated to another secret",
+			flags:        rotateFlags,
+			repo:         repos,
+			recordFile:   "testdata/webhook_rotated.csv",
+			mockHTTPFunc: repoHooks,
+			wantCalls:    repoConfigCalls(0, 1, 1),
+			wantRecorded: []string{"123", "456", "456", "789"},
+		},
+		{
+			name:  "webhookRotateSecretCommand records rotations around a failure",
+			flags: rotateFlags,
+			repo:  repos,
+			mockHTTPFunc: func() {
+				repoHooks()
+				mockHTTPResponder(
+					"PATCH",
+					"https://api.github.com/repos/some-org/repo1/hooks/456/config",
+					"testdata/mockRest404Response.json",
+					404,
+				)
+			},
+			wantCalls:    repoConfigCalls(1, 1, 1),
+			wantRecorded: []string{"123", "789"},
+		},
+		{
+			name: "webhookRotateSecretCommand organisation",
+			flags: []string{
+				"org", "true", "url-match", "https://some-external-webhook.net", "secret-file", "testdata/webhook_secret.txt",
+			},
+			mockHTTPFunc: func() {
+				mockHTTPResponder("GET", "https://api.github.com/orgs/some-org/hooks", "testdata/mockGetWebhooksResponse.json", 200)
+				mockHTTPResponder(
+					"PATCH",
+					"https://api.github.com/orgs/some-org/hooks/456/config",
+					"testdata/mockRest20xEmptyResponse.json",
+					200,
+				)
+			},
+			wantCalls:    map[string]int{"PATCH https://api.github.com/orgs/some-org/hooks/456/config": 1},
+			wantRecorded: []string{"456"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+			tt.mockHTTPFunc()
+
+			recordPath := filepath.Join(t.TempDir(), "webhook_rotated.csv")
+
+			if tt.recordFile != "" {
+				contents, err := os.ReadFile(tt.recordFile)
+				if err != nil {
+					t.Fatalf("failed to read test data: %v", err)
+				}
+
+				if err := os.WriteFile(recordPath, contents, 0o600); err != nil {
+					t.Fatalf("failed to write rotation record: %v", err)
+				}
+			}
+
+			cmd := tt.cmd
+			if cmd == nil {
+				flags := append([]string{"file-path", recordPath}, tt.flags...)
+				cmd = mockFlagsCmd(webhookRotateSecretTestFlags, flags...)
+			}
+
+			if err := webhookRotateSecretCommand(cmd, tt.repo); (err != nil) != tt.wantErr {
+				t.Errorf("webhookRotateSecretCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			calls := httpmock.GetCallCountInfo()
+			for call, want := range tt.wantCalls {
+				if calls[call] != want {
+					t.Errorf("webhookRotateSecretCommand() %s called %d times, want %d", call, calls[call], want)
+				}
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var gotRecorded []string
+
+			if file, err := os.Open(recordPath); err == nil {
+				lines, _ := csv.NewReader(file).ReadAll()
+				file.Close()
+
+				for _, line := range lines[1:] {
+					gotRecorded = append(gotRecorded, line[2])
+				}
+			}
+
+			if !reflect.DeepEqual(gotRecorded, tt.wantRecorded) {
+				t.Errorf("webhookRotateSecretCommand() recorded = %v, want %v", gotRecorded, tt.wantRecorded)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+// webhookTargetTestFlags registers the flags every webhook command uses to pick its repositories.
+func webhookTargetTestFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("dry-run", "d", false, "dry run flag")
+	cmd.Flags().StringP("repos", "", "", "repos flag")
+	cmd.Flags().Bool("org", false, "org flag")
+	cmd.Flags().Bool("all-repos", false, "all repos flag")
+}
+
+func webhookSettingsTestFlags(cmd *cobra.Command) {
+	webhookTargetTestFlags(cmd)
+	cmd.Flags().String("old-url", "", "old url flag")
+	webhookAddFlags(cmd)
+}
+
+func Test_newWebhookMatcher(t *testing.T) {
+	tests := []struct {
+		name        string
+		flag        string
+		value       string
+		wantMatch   []string
+		wantNoMatch []string
+		wantErr     bool
+	}{
+		{
+			name:    "newWebhookMatcher fails without a flag",
+			wantErr: true,
+		},
+		{
+			name:    "newWebhookMatcher fails on invalid url",
+			flag:    "url",
+			value:   "hooks.example.com/push",
+			wantErr: true,
+		},
+		{
+			name:    "newWebhookMatcher fails on invalid regex",
+			flag:    "regex",
+			value:   "hooks(",
+			wantErr: true,
+		},
+		{
+			name:        "newWebhookMatcher url",
+			flag:        "url",
+			value:       "https://hooks.example.com/push",
+			wantMatch:   []string{"https://hooks.example.com/push"},
+			wantNoMatch: []string{"https://hooks.example.com/push/", "http://hooks.example.com/push"},
+		},
+		{
+			name:        "newWebhookMatcher host",
+			flag:        "host",
+			value:       "hooks.example.com",
+			wantMatch:   []string{"https://hooks.example.com/push", "http://HOOKS.example.com:8080/"},
+			wantNoMatch: []string{"https://ci.hooks.example.com/push", "https://example.com/hooks.example.com"},
+		},
+		{
+			name:        "newWebhookMatcher url prefix",
+			flag:        "url-prefix",
+			value:       "https://hooks.example.com/ci/",
+			wantMatch:   []string{"https://hooks.example.com/ci/push", "https://hooks.example.com/ci/"},
+			wantNoMatch: []string{"https://hooks.example.com/cd/push"},
+		},
+		{
+			name:        "newWebhookMatcher glob",
+			flag:        "glob",
+			value:       "https://*.example.com/ci-?/*",
+			wantMatch:   []string{"https://hooks.example.com/ci-1/push", "https://a.b.example.com/ci-2/x/y"},
+			wantNoMatch: []string{"https://hooks.example.org/ci-1/push", "https://hooks.example.com/ci-10/push"},
+		},
+		{
+			name:        "newWebhookMatcher regex",
+			flag:        "regex",
+			value:       `^https://(ci|cd)\.example\.com/`,
+			wantMatch:   []string{"https://ci.example.com/push", "https://cd.example.com/"},
+			wantNoMatch: []string{"https://cx.example.com/push"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newWebhookMatcher(tt.flag, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newWebhookMatcher() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			for _, webhookURL := range tt.wantMatch {
+				if !got.match(webhookURL) {
+					t.Errorf("newWebhookMatcher() %s does not match %s", got.description, webhookURL)
+				}
+			}
+
+			for _, webhookURL := range tt.wantNoMatch {
+				if got.match(webhookURL) {
+					t.Errorf("newWebhookMatcher() %s matches %s", got.description, webhookURL)
+				}
+			}
+		})
+	}
+}
+
+func Test_getMatchingWebhooks(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	ctx := context.Background()
+	matchAll, _ := newWebhookMatcher("url-prefix", "https://some-external-webhook.")
+	matchOrg, _ := newWebhookMatcher("url", "https://some-external-webhook.org")
+
+	tests := []struct {
+		name           string
+		matcher        webhookMatcher
+		repositoryName string
+		responses      []dependabotMockResponse
+		wantIDs        []int
+		wantErr        bool
+	}{
+		{
+			name:           "getMatchingWebhooks not found",
+			matcher:        matchAll,
+			repositoryName: "some-repo-name",
+			responses: []dependabotMockResponse{
+				{
+					method:     "GET",
+					url:        "https://api.github.com/repos/some-org/some-repo-name/hooks?per_page=100",
+					file:       "testdata/mockRest404Response.json",
+					statusCode: 404,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:           "getMatchingWebhooks found",
+			matcher:        matchOrg,
+			repositoryName: "some-repo-name2",
+			responses: []dependabotMockResponse{
+				{
+					method:     "GET",
+					url:        "https://api.github.com/repos/some-org/some-repo-name2/hooks?per_page=100",
+					file:       "testdata/mockGetWebhooksResponse.json",
+					statusCode: 200,
+				},
+			},
+			wantIDs: []int{789},
+		},
+		{
+			name:    "getMatchingWebhooks organisation returns every match",
+			matcher: matchAll,
+			responses: []dependabotMockResponse{
+				{
+					method:     "GET",
+					url:        "https://api.github.com/orgs/some-org/hooks?per_page=100",
+					file:       "testdata/mockGetWebhooksResponse.json",
+					statusCode: 200,
+				},
+			},
+			wantIDs: []int{123, 456, 789},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			for _, response := range tt.responses {
+				mockHTTPResponder(response.method, response.url, response.file, response.statusCode)
+			}
+
+			got, err := getMatchingWebhooks(ctx, tt.matcher, tt.repositoryName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getMatchingWebhooks() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			var gotIDs []int
+			for _, webhook := range got {
+				gotIDs = append(gotIDs, webhook.ID)
+			}
+
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("getMatchingWebhooks() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func Test_webhookSettingsFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		want    webhookSettings
+		wantErr bool
+	}{
+		{
+			name:    "webhookSettingsFlags fails no flags",
+			cmd:     &cobra.Command{Use: "webhook-add"},
+			wantErr: true,
+		},
+		{
+			name:    "webhookSettingsFlags fails invalid url",
+			cmd:     mockFlagsCmd(webhookSettingsTestFlags, "url", "ci.example.com/hook"),
+			wantErr: true,
+		},
+		{
+			name: "webhookSettingsFlags fails invalid content type",
+			cmd: mockFlagsCmd(
+				webhookSettingsTestFlags,
+				"url", "https://ci.example.com/hook",
+				"content-type", "xml",
+			),
+			wantErr: true,
+		},
+		{
+			name:    "webhookSettingsFlags fails no events",
+			cmd:     mockFlagsCmd(webhookSettingsTestFlags, "url", "https://ci.example.com/hook", "events", ""),
+			wantErr: true,
+		},
+		{
+			name: "webhookSettingsFlags fails empty secret",
+			cmd: mockFlagsCmd(
+				webhookSettingsTestFlags,
+				"url", "https://ci.example.com/hook",
+				"secret-file", "testdata/webhook_secret_empty.txt",
+			),
+			wantErr: true,
+		},
+		{
+			name: "webhookSettingsFlags defaults",
+			cmd:  mockFlagsCmd(webhookSettingsTestFlags, "url", "https://ci.example.com/hook"),
+			want: webhookSettings{
+				url:         "https://ci.example.com/hook",
+				contentType: "json",
+				events:      []string{"push"},
+				active:      true,
+				changed:     map[string]bool{"content-type": false, "events": false, "active": false},
+			},
+		},
+		{
+			name: "webhookSettingsFlags set",
+			cmd: mockFlagsCmd(webhookSettingsTestFlags,
+				"url", "https://ci.example.com/hook",
+				"content-type", "form",
+				"events", "push,pull_request",
+				"active", "false",
+				"secret-file", "testdata/webhook_secret.txt",
+			),
+			want: webhookSettings{
+				url:         "https://ci.example.com/hook",
+				contentType: "form",
+				events:      []string{"push", "pull_request"},
+				secret:      "some-secret",
+				active:      false,
+				changed:     map[string]bool{"content-type": true, "events": true, "active": true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := webhookSettingsFlags(tt.cmd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookSettingsFlags() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("webhookSettingsFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookSecretFlags(t *testing.T) {
+	os.Setenv("WEBHOOK_TEST_SECRET", "env-secret")
+	os.Setenv("WEBHOOK_TEST_EMPTY_SECRET", "")
+
+	defer func() {
+		os.Unsetenv("WEBHOOK_TEST_SECRET")
+		os.Unsetenv("WEBHOOK_TEST_EMPTY_SECRET")
+	}()
+
+	tests := []struct {
+		name    string
+		cmd     *cobra.Command
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "webhookSecretFlags fails no flags",
+			cmd:     &cobra.Command{Use: "webhook-add"},
+			wantErr: true,
+		},
+		{
+			name: "webhookSecretFlags fails env and file",
+			cmd: mockFlagsCmd(webhookSettingsTestFlags,
+				"secret-env", "WEBHOOK_TEST_SECRET", "secret-file", "testdata/webhook_secret.txt",
+			),
+			wantErr: true,
+		},
+		{
+			name:    "webhookSecretFlags fails empty env",
+			cmd:     mockFlagsCmd(webhookSettingsTestFlags, "secret-env", "WEBHOOK_TEST_EMPTY_SECRET"),
+			wantErr: true,
+		},
+		{
+			name:    "webhookSecretFlags fails missing file",
+			cmd:     mockFlagsCmd(webhookSettingsTestFlags, "secret-file", "testdata/missing.txt"),
+			wantErr: true,
+		},
+		{
+			name: "webhookSecretFlags no secret",
+			cmd:  mockFlagsCmd(webhookSettingsTestFlags),
+			want: "",
+		},
+		{
+			name: "webhookSecretFlags env",
+			cmd:  mockFlagsCmd(webhookSettingsTestFlags, "secret-env", "WEBHOOK_TEST_SECRET"),
+			want: "env-secret",
+		},
+		{
+			name: "webhookSecretFlags file",
+			cmd:  mockFlagsCmd(webhookSettingsTestFlags, "secret-file", "testdata/webhook_secret.txt"),
+			want: "some-secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := webhookSecretFlags(tt.cmd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookSecretFlags() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("webhookSecretFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-admin-tool/restclient"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	errWebhookDuplicate = errors.New("update would duplicate a webhook")
+	webhookUpdateCmd    = &cobra.Command{ // nolint // needed for cobra
+		Use:   "webhook-update",
+		Short: "Update the webhook matching an old url for repos in provided list, all repos or the organisation",
+		RunE:  webhookUpdateRun,
+	}
+)
+
+// nolint // needed for cobra
+func init() {
+	webhookUpdateCmd.Flags().StringVarP(&reposFile, "repos", "r", "", "path to file containing repositories (file should contain repos on new line without org/ prefix)")
+	webhookUpdateCmd.Flags().Bool("all-repos", false, "update the webhook in every repository in the organisation that is not archived")
+	webhookUpdateCmd.Flags().Bool("org", false, "update the organisation webhook instead of repository webhooks")
+	webhookUpdateCmd.Flags().String("old-url", "", "full url of the webhook to update, can be the same as url to change other settings")
+	webhookAddFlags(webhookUpdateCmd)
+	webhookUpdateCmd.MarkFlagRequired("old-url")
+	webhookUpdateCmd.MarkFlagRequired("url")
+	webhookUpdateCmd.Flags().SortFlags = false
+	rootCmd.AddCommand(webhookUpdateCmd)
+}
+
+func webhookUpdateRun(cmd *cobra.Command, args []string) error {
+	err := webhookUpdateCommand(
+		cmd,
+		&repository{
+			reader: &repositoryReaderService{},
+		},
+	)
+
+	return err
+}
+
+func webhookUpdateCommand(cmd *cobra.Command, repo *repository) error {
+	dryRun, reposFilePath, err := branchProtectionFlagCheck(cmd)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	oldURL, err := cmd.Flags().GetString("old-url")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if _, err = url.ParseRequestURI(oldURL); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	settings, err := webhookSettingsFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	repositoryList, err := webhookTargets(cmd, repo, reposFilePath)
+	if err != nil {
+		return err
+	}
+
+	log.SetFlags(0)
+
+	if dryRun {
+		log.Printf("This is a dry run, the run would process %d repositories", len(repositoryList))
+	}
+
+	ctx := context.Background()
+
+	var (
+		count   changeCount
+		skipped int
+	)
+
+	for _, repositoryName := range repositoryList {
+		changed, result, err := webhookUpdateRepository(ctx, repositoryName, oldURL, settings, dryRun)
+		if errors.Is(err, errWebhookDuplicate) {
+			log.Printf("Skipped (%s): %v", webhooksTarget(repositoryName), err)
+
+			skipped++
+
+			continue
+		}
+
+		if err != nil {
+			log.Printf("Error (%s): %v", webhooksTarget(repositoryName), err)
+
+			continue
+		}
+
+		log.Print(result)
+		count.add(changed)
+	}
+
+	log.Printf("Webhook %s: %d updated, %d unchanged, %d skipped", oldURL, count.changed, count.unchanged, skipped)
+
+	return nil
+}
+
+// webhookUpdateRepository changes the webhook for the old URL to the settings, only the settings given on the command
+// line are changed. It returns errWebhookDuplicate rather than leave two webhooks for the new URL.
+func webhookUpdateRepository(
+	ctx context.Context,
+	repositoryName string,
+	oldURL string,
+	settings webhookSettings,
+	dryRun bool,
+) (bool, string, error) {
+	webhooks, err := listWebhooks(ctx, repositoryName)
+	if err != nil {
+		return false, "", err
+	}
+
+	var old, existing []WebhookResponse
+
+	for _, webhook := range webhooks {
+		switch webhook.Config.URL {
+		case oldURL:
+			old = append(old, webhook)
+		case settings.url:
+			existing = append(existing, webhook)
+		}
+	}
+
+	if len(old) == 0 {
+		return false, fmt.Sprintf("No webhook for %s found in %s", oldURL, webhooksTarget(repositoryName)), nil
+	}
+
+	if len(old) > 1 || len(existing) > 0 {
+		return false, "", fmt.Errorf(
+			"%w: %d webhooks for %s and %d for %s", errWebhookDuplicate, len(old), oldURL, len(existing), settings.url,
+		)
+	}
+
+	webhook := old[0]
+	configUpdate, update, changes := webhookUpdateChanges(webhook, settings)
+
+	if len(changes) == 0 {
+		return false, fmt.Sprintf(
+			"Webhook %s already set for %s id is %d", oldURL, webhooksTarget(repositoryName), webhook.ID,
+		), nil
+	}
+
+	if dryRun {
+		return true, fmt.Sprintf(
+			"Would set %s for webhook %d in %s", strings.Join(changes, ", "), webhook.ID, webhooksTarget(repositoryName),
+		), nil
+	}
+
+	if configUpdate != (WebhookConfigUpdate{}) {
+		if err := setWebhookConfig(ctx, repositoryName, webhook.ID, configUpdate); err != nil {
+			return false, "", err
+		}
+	}
+
+	if update.Active != nil || update.Events != nil {
+		client := restclient.NewClient(
+			fmt.Sprintf("%s/%d", webhooksPath(repositoryName), webhook.ID),
+			config.Token,
+			http.MethodPatch,
+		)
+		if err := client.SetBody(update); err != nil {
+			return false, "", fmt.Errorf("%w", err)
+		}
+
+		var response WebhookResponse
+		if err := client.Run(ctx, &response); err != nil {
+			return false, "", fmt.Errorf("update webhook %d: %w", webhook.ID, err)
+		}
+	}
+
+	return true, fmt.Sprintf(
+		"Successful setting %s for webhook %d in %s", strings.Join(changes, ", "), webhook.ID, webhooksTarget(repositoryName),
+	), nil
+}
+
+// webhookUpdateChanges returns the config and webhook changes, a secret cannot be read back so is always changed.
+func webhookUpdateChanges(
+	webhook WebhookResponse,
+	settings webhookSettings,
+) (WebhookConfigUpdate, WebhookUpdate, []string) {
+	var (
+		configUpdate WebhookConfigUpdate
+		update       WebhookUpdate
+		changes      []string
+	)
+
+	if webhook.Config.URL != settings.url {
+		configUpdate.URL = settings.url
+		changes = append(changes, fmt.Sprintf("url '%s'", settings.url))
+	}
+
+	if settings.changed["content-type"] && webhook.Config.ContentType != settings.contentType {
+		configUpdate.ContentType = settings.contentType
+		changes = append(changes, fmt.Sprintf("content type '%s'", settings.contentType))
+	}
+
+	if settings.secret != "" {
+		configUpdate.Secret = settings.secret
+		changes = append(changes, "secret")
+	}
+
+	if settings.changed["events"] && !webhookEventsEqual(webhook.Events, settings.events) {
+		update.Events = settings.events
+		changes = append(changes, fmt.Sprintf("events '%s'", strings.Join(settings.events, ",")))
+	}
+
+	if settings.changed["active"] && webhook.Active != settings.active {
+		active := settings.active
+		update.Active = &active
+		changes = append(changes, fmt.Sprintf("active '%t'", settings.active))
+	}
+
+	return configUpdate, update, changes
+}
+
+func webhookEventsEqual(current, wanted []string) bool {
+	if len(current) != len(wanted) {
+		return false
+	}
+
+	sortedCurrent := append([]string{}, current...)
+	sortedWanted := append([]string{}, wanted...)
+
+	sort.Strings(sortedCurrent)
+	sort.Strings(sortedWanted)
+
+	for i := range sortedCurrent {
+		if sortedCurrent[i] != sortedWanted[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/spf13/cobra"
+)
+
+func Test_webhookUpdateRepository(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	const (
+		hooksURL  = "https://api.github.com/repos/some-org/some-repo/hooks"
+		hookURL   = "https://api.github.com/repos/some-org/some-repo/hooks/123"
+		configURL = "https://api.github.com/repos/some-org/some-repo/hooks/123/config"
+	)
+
+	ctx := context.Background()
+	newURL := webhookSettings{
+		url:         "https://ci.example.com/hook",
+		contentType: "json",
+		events:      []string{"push"},
+		active:      true,
+		changed:     map[string]bool{},
+	}
+	newURLAndSecret := newURL
+	newURLAndSecret.secret = "some-secret"
+	newURLAndEvents := newURL
+	newURLAndEvents.events = []string{"push", "pull_request"}
+	newURLAndEvents.active = false
+	newURLAndEvents.changed = map[string]bool{"events": true, "active": true}
+	sameURL := newURL
+	sameURL.url = "https://some-external-webhook.com"
+	sameURLContentType := sameURL
+	sameURLContentType.changed = map[string]bool{"content-type": true}
+	duplicateURL := newURL
+	duplicateURL.url = "https://some-external-webhook.net"
+
+	tests := []struct {
+		name          string
+		oldURL        string
+		settings      webhookSettings
+		dryRun        bool
+		responses     []dependabotMockResponse
+		wantChanged   bool
+		wantCalls     map[string]int
+		wantDuplicate bool
+		wantErr       bool
+	}{
+		{
+			name:     "webhookUpdateRepository list webhooks failure",
+			oldURL:   "https://some-external-webhook.com",
+			settings: newURL,
+			responses: []dependabotMockResponse{
+				{"GET", hooksURL, "testdata/mockRest404Response.json", 404},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "webhookUpdateRepository no webhook for old url",
+			oldURL:   "https://ci.example.com/old",
+			settings: newURL,
+			responses: []dependabotMockResponse{
+				{"GET", hooksURL, "testdata/mockGetWebhooksResponse.json", 200},
+			},
+		},
+		{
+			name:     "webhookUpdateRepository new url already exists",
+			oldURL:   "https://some-external-webhook.com",
+			settings: duplicateURL,
+			responses: []dependabotMockResponse{
+				{"GET", hooksURL, "testdata/mockGetWebhooksResponse.json", 200},
+				{"PATCH", configURL, "testdata/mockRest20xEmptyResponse.json", 200},
+			},
+			wantCalls:     map[string]int{"PATCH " + configURL: 0},
+			wantDuplicate: true,
+			wantErr:       true,
+		},
+		{
+			name:     "webhookUpdateRepository nothing to change",
+			oldURL:   "https://some-external-webhook.com",
+			settings: sameURL,
+			responses: []dependabotMockResponse{
+				{"GET", hooksURL, "testdata/mockGetWebhooksResponse.json", 200},
+				{"PATCH", configURL, "testdata/mockRest20xEmptyResponse.json", 200},
+			},
+			wantCalls: map[string]int{"PATCH " + configURL: 0},
+		},
+		{
+			name:     "webhookUpdateRepository dry run",
+			oldURL:   "https://some-external-webhook.com",
+			settings: newURL,
+			dryRun:   true,
+			responses: []dependabotMockResponse{
+				{"GET", hooksURL, "testdata/mockGetWebhooksResponse.json", 200},
+				{"PATCH", configURL, "testdata/mockRest20xEmptyResponse.json", 200},
+			},
+			wantChanged: true,
+			wantCalls:   map[string]int{"PATCH " + configURL: 0},
+		},
+		{
+			name:     "webhookUpdateRepository config failure",
+			oldURL:   "https://some-external-webhook.com",
+			settings: newURL,
+			responses: []dependabotMockResponse{
+				{"GET", hooksURL, "testdata/mockGetWebhooksResponse.json", 200},
+				{"PATCH", configURL, "testdata/mockRest404Response.json", 404},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "webhookUpdateRepository url and secret",
+			oldURL:   "https://some-external-webhook.com",
+			settings: newURLAndSecret,
+			responses: []dependabotMockResponse{
+				{"GET", hooksURL, "testdata/mockGetWebhooksResponse.json", 200},
+				{"PATCH", configURL, "testdata/mockRest20xEmptyResponse.json", 200},
+				{"PATCH", hookURL, "testdata/mockGetWebhookResponse.json", 200},
+			},
+			wantChanged: true,
+			wantCalls:   map[string]int{"PATCH " + configURL: 1, "PATCH " + hookURL: 0},
+		},
+		{
+			name:     "webhookUpdateRepository url, events and active",
+			oldURL:   "https://some-external-webhook.com",
+			settings: newURLAndEvents,
+			responses: []dependabotMockResponse{
+				{"GET", hooksURL, "testdata/mockGetWebhooksResponse.json", 200},
+				{"PATCH", configURL, "testdata/mockRest20xEmptyResponse.json", 200},
+				{"PATCH", hookURL, "testdata/mockGetWebhookResponse.json", 200},
+			},
+			wantChanged: true,
+			wantCalls:   map[string]int{"PATCH " + configURL: 1, "PATCH " + hookURL: 1},
+		},
+		{
+			name:     "webhookUpdateRepository events failure",
+			oldURL:   "https://some-external-webhook.com",
+			settings: newURLAndEvents,
+			responses: []dependabotMockResponse{
+				{"GET", hooksURL, "testdata/mockGetWebhooksResponse.json", 200},
+				{"PATCH", configURL, "testdata/mockRest20xEmptyResponse.json", 200},
+				{"PATCH", hookURL, "testdata/mockRest404Response.json", 404},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "webhookUpdateRepository content type in place",
+			oldURL:   "https://some-external-webhook.com",
+			settings: sameURLContentType,
+			responses: []dependabotMockResponse{
+				{"GET", hooksURL, "testdata/mockGetWebhooksResponse.json", 200},
+				{"PATCH", configURL, "testdata/mockRest20xEmptyResponse.json", 200},
+				{"PATCH", hookURL, "testdata/mockGetWebhookResponse.json", 200},
+			},
+			wantChanged: true,
+			wantCalls:   map[string]int{"PATCH " + configURL: 1, "PATCH " + hookURL: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			for _, response := range tt.responses {
+				mockHTTPResponder(response.method, response.url, response.file, response.statusCode)
+			}
+
+			gotChanged, _, err := webhookUpdateRepository(ctx, "some-repo", tt.oldURL, tt.settings, tt.dryRun)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("webhookUpdateRepository() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if errors.Is(err, errWebhookDuplicate) != tt.wantDuplicate {
+				t.Errorf("webhookUpdateRepository() error = %v, wantDuplicate %v", err, tt.wantDuplicate)
+			}
+
+			if gotChanged != tt.wantChanged {
+				t.Errorf("webhookUpdateRepository() changed = %v, want %v", gotChanged, tt.wantChanged)
+			}
+
+			calls := httpmock.GetCallCountInfo()
+			for call, want := range tt.wantCalls {
+				if calls[call] != want {
+					t.Errorf("webhookUpdateRepository() %s called %d times, want %d", call, calls[call], want)
+				}
+			}
+		})
+	}
+}
+
+func Test_webhookEventsEqual(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []string
+		wanted  []string
+		want    bool
+	}{
+		{
+			name:    "webhookEventsEqual different length",
+			current: []string{"push"},
+			wanted:  []string{"push", "pull_request"},
+			want:    false,
+		},
+		{
+			name:    "webhookEventsEqual different events",
+			current: []string{"push"},
+			wanted:  []string{"pull_request"},
+			want:    false,
+		},
+		{
+			name:    "webhookEventsEqual different order",
+			current: []string{"push", "pull_request"},
+			wanted:  []string{"pull_request", "push"},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := webhookEventsEqual(tt.current, tt.wanted); got != tt.want {
+				t.Errorf("webhookEventsEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_webhookUpdateCommand(t *testing.T) {
+	originalConfig := config
+
+	httpmock.Activate()
+
+	defer func() {
+		httpmock.DeactivateAndReset()
+
+		config = originalConfig
+	}()
+
+	config.Org = MockOrgName
+
+	tests := []struct {
+		name      string
+		cmd       *cobra.Command
+		repo      *repository
+		responses []dependabotMockResponse
+		wantCalls map[string]int
+		wantErr   bool
+	}{
+		{
+			name:    "webhookUpdateCommand flag check failure",
+			cmd:     &cobra.Command{Use: "webhook-update"},
+			wantErr: true,
+		},
+		{
+			name: "webhookUpdateCommand invalid old url",
+			cmd: mockFlagsCmd(
+				webhookSettingsTestFlags,
+				"org", "true",
+				"old-url", "ci.example.com",
+				"url", "https://ci.example.com/hook",
+			),
+			wantErr: true,
+		},
+		{
+			name: "webhookUpdateCommand settings failure",
+			cmd: mockFlagsCmd(
+				webhookSettingsTestFlags,
+				"org", "true", "old-url", "https://some-external-webhook.com", "url", "https://ci.example.com/hook", "content-type", "xml",
+			),
+			wantErr: true,
+		},
+		{
+			name: "webhookUpdateCommand no target failure",
+			cmd: mockFlagsCmd(
+				webhookSettingsTestFlags,
+				"old-url", "https://some-external-webhook.com", "url", "https://ci.example.com/hook",
+			),
+			wantErr: true,
+		},
+		{
+			name: "webhookUpdateCommand repoints webhooks and skips duplicates",
+			cmd: mockFlagsCmd(
+				webhookSettingsTestFlags,
+				"repos", "filepath", "old-url", "https://some-external-webhook.com", "url", "https://some-external-webhook.net",
+			),
+			repo: &repository{
+				reader: &mockRepositoryReader{returnValue: []string{"repo1", "repo2", "repo3"}},
+			},
+			responses: []dependabotMockResponse{
+				{"GET", "https://api.github.com/repos/some-org/repo1/hooks", "testdata/mockGetWebhooksResponse.json", 200},
+				{"GET", "https://api.github.com/repos/some-org/repo2/hooks", "testdata/mockGetWebhookListResponse.json", 200},
+				{"GET", "https://api.github.com/repos/some-org/repo3/hooks", "testdata/mockRest404Response.json", 404},
+				{"PATCH", "https://api.github.com/repos/some-org/repo1/hooks/123/config", "testdata/mockRest20xEmptyResponse.json", 200},
+				{"PATCH", "https://api.github.com/repos/some-org/repo2/hooks/123/config", "testdata/mockRest20xEmptyResponse.json", 200},
+			},
+			wantCalls: map[string]int{
+				"PATCH https://api.github.com/repos/some-org/repo1/hooks/123/config": 0,
+				"PATCH https://api.github.com/repos/some-org/repo2/hooks/123/config": 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Reset()
+
+			for _, response := range tt.responses {
+				mockHTTPResponder(response.method, response.url, response.file, response.statusCode)
+			}
+
+			if err := webhookUpdateCommand(tt.cmd, tt.repo); (err != nil) != tt.wantErr {
+				t.Errorf("webhookUpdateCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			calls := httpmock.GetCallCountInfo()
+			for call, want := range tt.wantCalls {
+				if calls[call] != want {
+					t.Errorf("webhookUpdateCommand() %s called %d times, want %d", call, calls[call], want)
+				}
+			}
+		})
+	}
+}
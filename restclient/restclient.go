@@ -1,17 +1,23 @@
 package restclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
 const RestEndpoint = "https://api.github.com"
 
 var (
+	// ErrNotFound is returned on a 404 so callers can tell a missing resource from other failures.
+	ErrNotFound = errors.New("not found status")
+	// ErrForbidden is returned on a 403, the message from GitHub is kept as it usually gives the reason.
+	ErrForbidden        = errors.New("forbidden status")
 	errStatusCode       = errors.New("returned a non-200 status code")
 	errHTTPUnauthorised = errors.New("unauthorised status")
 )
@@ -23,6 +29,9 @@ type Client struct {
 	closeReq   bool
 	bodyReader bodyReader
 	method     string
+	body       []byte
+	accept     string
+	nextPath   string
 }
 
 type bodyReader interface {
@@ -48,16 +57,45 @@ func NewClient(path, token, method string) *Client {
 		closeReq:   true,
 		bodyReader: &bodyReaderService{},
 		method:     method,
+		accept:     "application/vnd.github.v3+json",
 	}
 }
 
+// SetAccept sets the media type to request, some endpoints only return extra data for a custom media type.
+func (c *Client) SetAccept(mediaType string) {
+	c.accept = mediaType
+}
+
+// SetBody sets the JSON body to send with the request.
+func (c *Client) SetBody(body interface{}) error {
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal body: %w", err)
+	}
+
+	c.body = requestBody
+
+	return nil
+}
+
+// NextPath returns the path of the next page from the Link header of the last response, or empty on the last
+// page. Endpoints with cursor pagination do not support the page parameter so must be followed this way.
+func (c *Client) NextPath() string {
+	return c.nextPath
+}
+
 type errorResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
 func (c *Client) Run(ctx context.Context, resp interface{}) (err error) {
-	req, err := http.NewRequest(c.method, c.endpoint, http.NoBody)
+	var requestBody io.Reader = http.NoBody
+	if c.body != nil {
+		requestBody = bytes.NewReader(c.body)
+	}
+
+	req, err := http.NewRequest(c.method, c.endpoint, requestBody)
 	if err != nil {
 		return fmt.Errorf("new request: %w", err)
 	}
@@ -67,7 +105,7 @@ func (c *Client) Run(ctx context.Context, resp interface{}) (err error) {
 	req = req.WithContext(ctx)
 
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Accept", c.accept)
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
 
 	res, err := c.httpClient.Do(req)
@@ -81,6 +119,8 @@ func (c *Client) Run(ctx context.Context, resp interface{}) (err error) {
 		return err
 	}
 
+	c.nextPath = nextLinkPath(res.Header.Get("Link"))
+
 	body, err := c.bodyReader.read(res.Body)
 	if err != nil {
 		return fmt.Errorf("reading body: %w", err)
@@ -96,7 +136,11 @@ func (c *Client) Run(ctx context.Context, resp interface{}) (err error) {
 }
 
 func checkHTTPResponse(res *http.Response, endpoint string) error {
-	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+	// Created and accepted are returned by POST and PUT calls
+	if res.StatusCode != http.StatusOK &&
+		res.StatusCode != http.StatusCreated &&
+		res.StatusCode != http.StatusAccepted &&
+		res.StatusCode != http.StatusNoContent {
 		var errRes errorResponse
 
 		if err := json.NewDecoder(res.Body).Decode(&errRes); err != nil {
@@ -107,8 +151,30 @@ func checkHTTPResponse(res *http.Response, endpoint string) error {
 			return fmt.Errorf("%w, %s", errHTTPUnauthorised, endpoint)
 		}
 
+		if res.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w, %s", ErrNotFound, endpoint)
+		}
+
+		if res.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("%w (%s), %s", ErrForbidden, errRes.Message, endpoint)
+		}
+
 		return fmt.Errorf("incorrect status: %w '%d', %s", errStatusCode, res.StatusCode, endpoint)
 	}
 
 	return nil
 }
+
+// nextLinkPath returns the path of the rel="next" link, e.g. <https://api.github.com/x?after=y>; rel="next".
+func nextLinkPath(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		fields := strings.Split(part, ";")
+		if len(fields) < 2 || strings.TrimSpace(fields[1]) != `rel="next"` {
+			continue
+		}
+
+		return strings.TrimPrefix(strings.Trim(strings.TrimSpace(fields[0]), "<>"), RestEndpoint)
+	}
+
+	return ""
+}
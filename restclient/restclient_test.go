@@ -114,6 +114,7 @@ func TestNewClient(t *testing.T) {
 				closeReq:   true,
 				bodyReader: &bodyReaderService{},
 				method:     "GET",
+				accept:     "application/vnd.github.v3+json",
 			},
 		},
 	}
@@ -296,3 +297,213 @@ func TestClient_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_SetBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     interface{}
+		wantBody []byte
+		wantErr  bool
+	}{
+		{
+			name:     "SetBody success",
+			body:     map[string]bool{"archived": true},
+			wantBody: []byte(`{"archived":true}`),
+			wantErr:  false,
+		},
+		{
+			name:    "SetBody fails on marshalling",
+			body:    make(chan int),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient("/repos/org/repo", "TOKEN", http.MethodPatch)
+			if err := c.SetBody(tt.body); (err != nil) != tt.wantErr {
+				t.Errorf("Client.SetBody() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(c.body, tt.wantBody) {
+				t.Errorf("Client.SetBody() body = %s, want %s", c.body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestClient_Run_withBody(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var gotBody string
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://api.github.com/repos/org/repo/rulesets",
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			gotBody = string(body)
+
+			return httpmock.NewStringResponse(http.StatusCreated, `{"id":1}`), nil
+		},
+	)
+
+	c := NewClient("/repos/org/repo/rulesets", "TOKEN", http.MethodPost)
+	if err := c.SetBody(map[string]string{"name": "some-ruleset"}); err != nil {
+		t.Fatalf("Client.SetBody() error = %v", err)
+	}
+
+	var response map[string]int
+	if err := c.Run(context.Background(), &response); err != nil {
+		t.Errorf("Client.Run() error = %v, wantErr false", err)
+	}
+
+	if gotBody != `{"name":"some-ruleset"}` {
+		t.Errorf("Client.Run() sent body = %s, want %s", gotBody, `{"name":"some-ruleset"}`)
+	}
+
+	if response["id"] != 1 {
+		t.Errorf("Client.Run() response = %v, want id 1", response)
+	}
+}
+
+func TestClient_Run_withAccept(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var gotAccept string
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.github.com/orgs/org/teams/team/repos/org/repo",
+		func(req *http.Request) (*http.Response, error) {
+			gotAccept = req.Header.Get("Accept")
+
+			return httpmock.NewStringResponse(http.StatusOK, `{}`), nil
+		},
+	)
+
+	c := NewClient("/orgs/org/teams/team/repos/org/repo", "TOKEN", http.MethodGet)
+	c.SetAccept("application/vnd.github.v3.repository+json")
+
+	var response interface{}
+	if err := c.Run(context.Background(), &response); err != nil {
+		t.Errorf("Client.Run() error = %v, wantErr false", err)
+	}
+
+	if gotAccept != "application/vnd.github.v3.repository+json" {
+		t.Errorf("Client.Run() sent accept = %s, want %s", gotAccept, "application/vnd.github.v3.repository+json")
+	}
+}
+
+func TestClient_Run_notFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.github.com/repos/org/repo",
+		httpmock.NewStringResponder(http.StatusNotFound, `{"message": "Not Found"}`),
+	)
+
+	c := NewClient("/repos/org/repo", "TOKEN", http.MethodGet)
+
+	var response interface{}
+	if err := c.Run(context.Background(), &response); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Client.Run() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestClient_Run_forbidden(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.github.com/repos/org/repo/code-scanning/default-setup",
+		httpmock.NewStringResponder(http.StatusForbidden, `{"message": "Advanced Security must be enabled"}`),
+	)
+
+	c := NewClient("/repos/org/repo/code-scanning/default-setup", "TOKEN", http.MethodGet)
+
+	var response interface{}
+
+	err := c.Run(context.Background(), &response)
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("Client.Run() error = %v, want %v", err, ErrForbidden)
+	}
+
+	want := "forbidden status (Advanced Security must be enabled), " +
+		"https://api.github.com/repos/org/repo/code-scanning/default-setup"
+	if err.Error() != want {
+		t.Errorf("Client.Run() error = %v, want %v", err, want)
+	}
+}
+
+func TestClient_Run_nextPath(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	response := httpmock.NewStringResponse(http.StatusOK, `[]`)
+	response.Header.Set(
+		"Link",
+		`<https://api.github.com/orgs/org/dependabot/alerts?per_page=100&after=abc>; rel="next", `+
+			`<https://api.github.com/orgs/org/dependabot/alerts?per_page=100&before=xyz>; rel="prev"`,
+	)
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.github.com/orgs/org/dependabot/alerts?per_page=100",
+		httpmock.ResponderFromResponse(response),
+	)
+
+	c := NewClient("/orgs/org/dependabot/alerts?per_page=100", "TOKEN", http.MethodGet)
+
+	var result interface{}
+	if err := c.Run(context.Background(), &result); err != nil {
+		t.Errorf("Client.Run() error = %v, wantErr false", err)
+	}
+
+	if got, want := c.NextPath(), "/orgs/org/dependabot/alerts?per_page=100&after=abc"; got != want {
+		t.Errorf("Client.NextPath() = %v, want %v", got, want)
+	}
+}
+
+func Test_nextLinkPath(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{
+			name: "nextLinkPath no header",
+			want: "",
+		},
+		{
+			name: "nextLinkPath last page",
+			link: `<https://api.github.com/orgs/org/dependabot/alerts?before=xyz>; rel="prev"`,
+			want: "",
+		},
+		{
+			name: "nextLinkPath next page",
+			link: `<https://api.github.com/repos/org/repo/hooks?page=2>; rel="next", ` +
+				`<https://api.github.com/repos/org/repo/hooks?page=5>; rel="last"`,
+			want: "/repos/org/repo/hooks?page=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextLinkPath(tt.link); got != tt.want {
+				t.Errorf("nextLinkPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}